@@ -0,0 +1,344 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const (
+	AccessAllow = "ALLOW"
+	AccessDeny  = "DENY"
+)
+
+// AuthorizationService evaluates the AuthorizationPolicies applying to a workload
+type AuthorizationService struct {
+	userClients   map[string]kubernetes.ClientInterface
+	kialiCache    cache.KialiCache
+	businessLayer *Layer
+}
+
+// GetAccessDecision evaluates the AuthorizationPolicies applying to the destination workload of the request,
+// the ones defined in the workload namespace and in the root namespace, and returns the resulting decision
+// together with the policies and rules that produced it.
+func (in *AuthorizationService) GetAccessDecision(ctx context.Context, cluster string, request models.AccessRequest) (*models.AccessDecision, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetAccessDecision",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", request.Namespace),
+		observability.Attribute("workload", request.Workload),
+	)
+	defer end()
+
+	workload, err := in.businessLayer.Workload.GetWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: request.Namespace, WorkloadName: request.Workload})
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := []string{}
+	policies := []*security_v1beta1.AuthorizationPolicy{}
+	namespaces := []string{request.Namespace}
+	if rootNamespace := config.Get().ExternalServices.Istio.RootNamespace; rootNamespace != request.Namespace {
+		namespaces = append(namespaces, rootNamespace)
+	}
+	for _, namespace := range namespaces {
+		criteria := IstioConfigCriteria{Namespace: namespace, IncludeAuthorizationPolicies: true}
+		istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigListPerCluster(ctx, criteria, cluster)
+		if err != nil {
+			if errors.IsForbidden(err) {
+				warnings = append(warnings, fmt.Sprintf("AuthorizationPolicies of namespace [%s] are not accessible", namespace))
+				continue
+			}
+			return nil, err
+		}
+		policies = append(policies, istioConfigList.AuthorizationPolicies...)
+	}
+
+	if request.SourcePrincipal == "" && request.SourceNamespace != "" {
+		serviceAccount := request.SourceServiceAccount
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		request.SourcePrincipal = fmt.Sprintf("%s/ns/%s/sa/%s", in.trustDomain(cluster), request.SourceNamespace, serviceAccount)
+	}
+
+	decision := evaluateAccess(request, workload.Labels, policies)
+	decision.Warnings = append(warnings, decision.Warnings...)
+	return decision, nil
+}
+
+// trustDomain returns the trust domain of the mesh, used to build the source principal
+func (in *AuthorizationService) trustDomain(cluster string) string {
+	meshConfig := kubernetes.IstioMeshConfig{}
+
+	cfg := config.Get()
+	var istioConfig *core_v1.ConfigMap
+	var err error
+	if IsNamespaceCached(cfg.IstioNamespace) {
+		istioConfig, err = kialiCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	} else if userClient, ok := in.userClients[cluster]; ok {
+		istioConfig, err = userClient.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	}
+	if err != nil {
+		log.Debugf("Unable to read the Istio mesh config, using the default trust domain: %s", err)
+		return meshConfig.GetTrustDomain()
+	}
+	if icm, err := kubernetes.GetIstioConfigMap(istioConfig); err == nil {
+		meshConfig = *icm
+	}
+	return meshConfig.GetTrustDomain()
+}
+
+// evaluateAccess follows the Istio evaluation order: a request matching a CUSTOM policy is delegated to its
+// external authorizer, then a request matching a DENY policy is denied, then a request is allowed when no ALLOW
+// policy applies to the workload or when it matches an ALLOW policy. AUDIT policies don't affect the decision.
+func evaluateAccess(request models.AccessRequest, workloadLabels map[string]string, policies []*security_v1beta1.AuthorizationPolicy) *models.AccessDecision {
+	decision := &models.AccessDecision{
+		Policies:  []models.AccessPolicyMatch{},
+		Evaluated: []models.AccessPolicyMatch{},
+		Warnings:  []string{},
+	}
+	evaluator := accessEvaluator{request: request, warnings: map[string]bool{}}
+
+	applicable := applicablePolicies(request.Namespace, workloadLabels, policies)
+	matches := map[api_security_v1beta1.AuthorizationPolicy_Action][]models.AccessPolicyMatch{}
+	allowPolicies := []models.AccessPolicyMatch{}
+	for _, ap := range applicable {
+		match := models.AccessPolicyMatch{
+			Name:      ap.Name,
+			Namespace: ap.Namespace,
+			Action:    ap.Spec.Action.String(),
+			Provider:  ap.Spec.GetProvider().GetName(),
+			Rule:      evaluator.matchingRule(ap),
+		}
+		decision.Evaluated = append(decision.Evaluated, match)
+		if ap.Spec.Action == api_security_v1beta1.AuthorizationPolicy_ALLOW {
+			allowPolicies = append(allowPolicies, match)
+		}
+		if match.Rule >= 0 {
+			matches[ap.Spec.Action] = append(matches[ap.Spec.Action], match)
+		}
+	}
+
+	if custom := matches[api_security_v1beta1.AuthorizationPolicy_CUSTOM]; len(custom) > 0 {
+		decision.Delegated = true
+		decision.Policies = append(decision.Policies, custom...)
+	}
+
+	switch {
+	case len(matches[api_security_v1beta1.AuthorizationPolicy_DENY]) > 0:
+		decision.Decision = AccessDeny
+		decision.Reason = "The request matches a DENY policy"
+		decision.Policies = append(decision.Policies, matches[api_security_v1beta1.AuthorizationPolicy_DENY]...)
+	case len(allowPolicies) == 0:
+		decision.Decision = AccessAllow
+		decision.Reason = "No ALLOW policy applies to the workload"
+	case len(matches[api_security_v1beta1.AuthorizationPolicy_ALLOW]) > 0:
+		decision.Decision = AccessAllow
+		decision.Reason = "The request matches an ALLOW policy"
+		decision.Policies = append(decision.Policies, matches[api_security_v1beta1.AuthorizationPolicy_ALLOW]...)
+	default:
+		decision.Decision = AccessDeny
+		decision.Reason = "The request doesn't match any of the ALLOW policies applying to the workload"
+		decision.Policies = append(decision.Policies, allowPolicies...)
+	}
+	if decision.Delegated {
+		decision.Reason += ", and it is subject to the external authorizer of a CUSTOM policy"
+	}
+
+	for warning := range evaluator.warnings {
+		decision.Warnings = append(decision.Warnings, warning)
+	}
+	sort.Strings(decision.Warnings)
+
+	return decision
+}
+
+// applicablePolicies returns the policies applying to the workload, sorted in evaluation order. Policies of the
+// root namespace apply to the workloads of every namespace.
+func applicablePolicies(namespace string, workloadLabels map[string]string, policies []*security_v1beta1.AuthorizationPolicy) []*security_v1beta1.AuthorizationPolicy {
+	applicable := []*security_v1beta1.AuthorizationPolicy{}
+	for _, ap := range policies {
+		if ap.Namespace != namespace && !config.IsRootNamespace(ap.Namespace) {
+			continue
+		}
+		if ap.Spec.Action == api_security_v1beta1.AuthorizationPolicy_AUDIT {
+			continue
+		}
+		if selector := ap.Spec.Selector; selector != nil && len(selector.MatchLabels) > 0 {
+			if !labels.SelectorFromSet(selector.MatchLabels).Matches(labels.Set(workloadLabels)) {
+				continue
+			}
+		}
+		applicable = append(applicable, ap)
+	}
+
+	order := map[api_security_v1beta1.AuthorizationPolicy_Action]int{
+		api_security_v1beta1.AuthorizationPolicy_CUSTOM: 0,
+		api_security_v1beta1.AuthorizationPolicy_DENY:   1,
+		api_security_v1beta1.AuthorizationPolicy_ALLOW:  2,
+	}
+	sort.SliceStable(applicable, func(i, j int) bool {
+		a, b := applicable[i], applicable[j]
+		if order[a.Spec.Action] != order[b.Spec.Action] {
+			return order[a.Spec.Action] < order[b.Spec.Action]
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return applicable
+}
+
+// accessEvaluator matches the rules of the policies against the request. Like Istio, the evaluation of request
+// attributes that are not part of the request (e.g. the source ip) is conservative: they match for DENY and CUSTOM
+// policies and don't match for ALLOW policies. They are reported as warnings.
+type accessEvaluator struct {
+	action   api_security_v1beta1.AuthorizationPolicy_Action // of the policy being evaluated
+	request  models.AccessRequest
+	warnings map[string]bool
+}
+
+// matchingRule returns the index of the first rule of the policy matching the request, or -1 if none matches.
+// A policy without rules matches no request.
+func (e accessEvaluator) matchingRule(ap *security_v1beta1.AuthorizationPolicy) int {
+	e.action = ap.Spec.Action
+	for i, rule := range ap.Spec.Rules {
+		if rule != nil && e.matchRule(rule) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e accessEvaluator) matchRule(rule *api_security_v1beta1.Rule) bool {
+	if len(rule.From) > 0 {
+		matched := false
+		for _, from := range rule.From {
+			if from.Source == nil || e.matchSource(from.Source) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(rule.To) > 0 {
+		matched := false
+		for _, to := range rule.To {
+			if to.Operation == nil || e.matchOperation(to.Operation) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, condition := range rule.When {
+		if condition != nil && !e.matchCondition(condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e accessEvaluator) matchSource(source *api_security_v1beta1.Source) bool {
+	return e.match("principals", source.Principals, source.NotPrincipals, e.request.SourcePrincipal, true) &&
+		e.match("requestPrincipals", source.RequestPrincipals, source.NotRequestPrincipals, e.request.RequestPrincipal, true) &&
+		e.match("namespaces", source.Namespaces, source.NotNamespaces, e.sourceNamespace(), true) &&
+		e.match("ipBlocks", source.IpBlocks, source.NotIpBlocks, "", false) &&
+		e.match("remoteIpBlocks", source.RemoteIpBlocks, source.NotRemoteIpBlocks, "", false)
+}
+
+func (e accessEvaluator) matchOperation(operation *api_security_v1beta1.Operation) bool {
+	return e.match("hosts", operation.Hosts, operation.NotHosts, e.request.Host, e.request.Host != "") &&
+		e.match("ports", operation.Ports, operation.NotPorts, e.request.Port, e.request.Port != "") &&
+		e.match("methods", operation.Methods, operation.NotMethods, e.request.Method, e.request.Method != "") &&
+		e.match("paths", operation.Paths, operation.NotPaths, e.request.Path, e.request.Path != "")
+}
+
+func (e accessEvaluator) matchCondition(condition *api_security_v1beta1.Condition) bool {
+	switch condition.Key {
+	case "source.principal":
+		return e.match(condition.Key, condition.Values, condition.NotValues, e.request.SourcePrincipal, true)
+	case "source.namespace":
+		return e.match(condition.Key, condition.Values, condition.NotValues, e.sourceNamespace(), true)
+	case "request.auth.principal":
+		return e.match(condition.Key, condition.Values, condition.NotValues, e.request.RequestPrincipal, true)
+	case "destination.port":
+		return e.match(condition.Key, condition.Values, condition.NotValues, e.request.Port, e.request.Port != "")
+	}
+	return e.match(condition.Key, condition.Values, condition.NotValues, "", false)
+}
+
+// match returns true if the value matches one of the values, if any, and none of the notValues. An unknown value
+// matches only for DENY and CUSTOM policies.
+func (e accessEvaluator) match(field string, values, notValues []string, value string, known bool) bool {
+	if len(values) == 0 && len(notValues) == 0 {
+		return true
+	}
+	if !known {
+		if e.action == api_security_v1beta1.AuthorizationPolicy_ALLOW {
+			e.warnings[fmt.Sprintf("[%s] is not part of the request, it is considered not matching for ALLOW policies", field)] = true
+			return false
+		}
+		e.warnings[fmt.Sprintf("[%s] is not part of the request, it is considered matching for %s policies", field, e.action)] = true
+		return true
+	}
+	if len(values) > 0 && !matchesAny(values, value) {
+		return false
+	}
+	return !matchesAny(notValues, value)
+}
+
+// sourceNamespace returns the source namespace of the request, taken from the source principal when provided
+func (e accessEvaluator) sourceNamespace() string {
+	// principals are formatted as <trust domain>/ns/<namespace>/sa/<service account>
+	parts := strings.Split(e.request.SourcePrincipal, "/")
+	if len(parts) == 5 && parts[1] == "ns" && parts[3] == "sa" {
+		return parts[2]
+	}
+	return e.request.SourceNamespace
+}
+
+// matchesAny returns true if the value matches one of the patterns. Istio patterns support an exact match, a
+// prefix match ("abc*"), a suffix match ("*abc") and a presence match ("*").
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*":
+			if value != "" {
+				return true
+			}
+		case strings.HasPrefix(pattern, "*"):
+			if strings.HasSuffix(value, strings.TrimPrefix(pattern, "*")) {
+				return true
+			}
+		case strings.HasSuffix(pattern, "*"):
+			if strings.HasPrefix(value, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		case pattern == value:
+			return true
+		}
+	}
+	return false
+}
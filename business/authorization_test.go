@@ -0,0 +1,226 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+var reviewsLabels = map[string]string{"app": "reviews", "version": "v1"}
+
+func fakeAccessRequest(principal, method, path string) models.AccessRequest {
+	return models.AccessRequest{
+		SourcePrincipal: principal,
+		Namespace:       "bookinfo",
+		Workload:        "reviews-v1",
+		Method:          method,
+		Path:            path,
+		Port:            "9080",
+	}
+}
+
+func fakeAccessPolicy(name, namespace string, action api_security_v1beta1.AuthorizationPolicy_Action, rules ...*api_security_v1beta1.Rule) *security_v1beta1.AuthorizationPolicy {
+	ap := data.CreateEmptyAuthorizationPolicy(name, namespace)
+	ap.Spec.Action = action
+	ap.Spec.Rules = rules
+	return ap
+}
+
+func fromPrincipals(principals ...string) *api_security_v1beta1.Rule {
+	return &api_security_v1beta1.Rule{
+		From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{Principals: principals}}},
+	}
+}
+
+func toPaths(paths ...string) *api_security_v1beta1.Rule {
+	return &api_security_v1beta1.Rule{
+		To: []*api_security_v1beta1.Rule_To{{Operation: &api_security_v1beta1.Operation{Paths: paths}}},
+	}
+}
+
+func TestAccessNoPolicies(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/"), reviewsLabels, nil)
+
+	assert.Equal(AccessAllow, decision.Decision)
+	assert.False(decision.Delegated)
+	assert.Empty(decision.Policies)
+	assert.Empty(decision.Evaluated)
+}
+
+func TestAccessAllowPolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	policies := []*security_v1beta1.AuthorizationPolicy{
+		fakeAccessPolicy("allow-productpage", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW,
+			fromPrincipals("cluster.local/ns/bookinfo/sa/ratings"),
+			fromPrincipals("cluster.local/ns/bookinfo/sa/productpage*")),
+	}
+
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/"), reviewsLabels, policies)
+	assert.Equal(AccessAllow, decision.Decision)
+	require.Len(decision.Policies, 1)
+	assert.Equal(models.AccessPolicyMatch{Name: "allow-productpage", Namespace: "bookinfo", Action: "ALLOW", Rule: 1}, decision.Policies[0])
+
+	// a source not matching any ALLOW policy is denied, by the ALLOW policies applying to the workload
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/details", "GET", "/"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+	require.Len(decision.Policies, 1)
+	assert.Equal(-1, decision.Policies[0].Rule)
+}
+
+func TestAccessAllowNothing(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	policies := []*security_v1beta1.AuthorizationPolicy{
+		data.CreateEmptyAuthorizationPolicy("allow-nothing", "bookinfo"),
+	}
+
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+	assert.Len(decision.Policies, 1)
+}
+
+func TestAccessDenyBeforeAllow(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	policies := []*security_v1beta1.AuthorizationPolicy{
+		fakeAccessPolicy("allow-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, &api_security_v1beta1.Rule{}),
+		// root namespace policies apply to every namespace
+		fakeAccessPolicy("deny-admin", "istio-system", api_security_v1beta1.AuthorizationPolicy_DENY, toPaths("/admin*")),
+	}
+
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/admin/users"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+	require.Len(decision.Policies, 1)
+	assert.Equal("deny-admin", decision.Policies[0].Name)
+	assert.Equal(0, decision.Policies[0].Rule)
+	require.Len(decision.Evaluated, 2)
+	assert.Equal("deny-admin", decision.Evaluated[0].Name)
+	assert.Equal("allow-all", decision.Evaluated[1].Name)
+
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/reviews/1"), reviewsLabels, policies)
+	assert.Equal(AccessAllow, decision.Decision)
+	require.Len(decision.Policies, 1)
+	assert.Equal("allow-all", decision.Policies[0].Name)
+}
+
+func TestAccessPolicyScope(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	otherWorkload := fakeAccessPolicy("deny-ratings", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, &api_security_v1beta1.Rule{})
+	otherWorkload.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "ratings"}}
+	policies := []*security_v1beta1.AuthorizationPolicy{
+		otherWorkload,
+		fakeAccessPolicy("deny-all", "travel", api_security_v1beta1.AuthorizationPolicy_DENY, &api_security_v1beta1.Rule{}),
+		fakeAccessPolicy("audit-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_AUDIT, &api_security_v1beta1.Rule{}),
+	}
+
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/"), reviewsLabels, policies)
+	assert.Equal(AccessAllow, decision.Decision)
+	assert.Empty(decision.Evaluated)
+}
+
+func TestAccessCustomPolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	custom := fakeAccessPolicy("ext-authz", "bookinfo", api_security_v1beta1.AuthorizationPolicy_CUSTOM, toPaths("/api/*"))
+	custom.Spec.ActionDetail = &api_security_v1beta1.AuthorizationPolicy_Provider{
+		Provider: &api_security_v1beta1.AuthorizationPolicy_ExtensionProvider{Name: "opa"},
+	}
+	policies := []*security_v1beta1.AuthorizationPolicy{custom}
+
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/api/reviews"), reviewsLabels, policies)
+	assert.Equal(AccessAllow, decision.Decision)
+	assert.True(decision.Delegated)
+	require.Len(decision.Policies, 1)
+	assert.Equal("opa", decision.Policies[0].Provider)
+
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/health"), reviewsLabels, policies)
+	assert.False(decision.Delegated)
+	assert.Empty(decision.Policies)
+}
+
+func TestAccessRuleFields(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rule := &api_security_v1beta1.Rule{
+		From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{Namespaces: []string{"bookinfo"}, NotPrincipals: []string{"*/sa/details"}}}},
+		To:   []*api_security_v1beta1.Rule_To{{Operation: &api_security_v1beta1.Operation{Methods: []string{"GET", "HEAD"}, Ports: []string{"9080"}, NotPaths: []string{"*.private"}}}},
+		When: []*api_security_v1beta1.Condition{{Key: "source.namespace", NotValues: []string{"travel"}}},
+	}
+	policies := []*security_v1beta1.AuthorizationPolicy{
+		fakeAccessPolicy("allow-get", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, rule),
+	}
+
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/reviews/1"), reviewsLabels, policies)
+	assert.Equal(AccessAllow, decision.Decision)
+	assert.Empty(decision.Warnings)
+
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/details", "GET", "/reviews/1"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "POST", "/reviews/1"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/reviews/1.private"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/travel/sa/productpage", "GET", "/reviews/1"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+}
+
+func TestAccessUnknownAttributes(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	policies := []*security_v1beta1.AuthorizationPolicy{
+		fakeAccessPolicy("deny-ips", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, &api_security_v1beta1.Rule{
+			From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{IpBlocks: []string{"10.0.0.0/8"}}}},
+		}),
+	}
+
+	// a DENY policy on an unknown attribute is assumed to match, as Istio would deny the request
+	decision := evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+	assert.Equal("deny-ips", decision.Policies[0].Name)
+	assert.Equal([]string{"[ipBlocks] is not part of the request, it is considered matching for DENY policies"}, decision.Warnings)
+
+	// and so is a DENY policy excluding an unknown attribute
+	policies = []*security_v1beta1.AuthorizationPolicy{
+		fakeAccessPolicy("deny-not-ips", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, &api_security_v1beta1.Rule{
+			From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{NotRemoteIpBlocks: []string{"10.0.0.0/8"}}}},
+		}),
+	}
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+
+	// an ALLOW policy on an unknown attribute is assumed not to match
+	policies = []*security_v1beta1.AuthorizationPolicy{
+		fakeAccessPolicy("allow-headers", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, &api_security_v1beta1.Rule{
+			When: []*api_security_v1beta1.Condition{{Key: "request.headers[x-token]", Values: []string{"secret"}}},
+		}),
+	}
+	decision = evaluateAccess(fakeAccessRequest("cluster.local/ns/bookinfo/sa/productpage", "GET", "/"), reviewsLabels, policies)
+	assert.Equal(AccessDeny, decision.Decision)
+	assert.Equal([]string{"[request.headers[x-token]] is not part of the request, it is considered not matching for ALLOW policies"}, decision.Warnings)
+}
@@ -1,6 +1,11 @@
 package checkers
 
-import "github.com/kiali/kiali/models"
+import (
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/models"
+)
 
 type Checker interface {
 	Check() ([]*models.IstioCheck, bool)
@@ -27,3 +32,66 @@ func EmptyValidValidation(name, namespace, objectType string) (models.IstioValid
 
 	return key, emptyValidation
 }
+
+// IstioObjectsPerType returns the Istio objects by their validation object type. The AuthorizationPolicies and the
+// PeerAuthentications are passed apart, as the validations fetch them filtered by namespace.
+func IstioObjectsPerType(istioConfigList models.IstioConfigList, authorizationPolicies []*security_v1beta.AuthorizationPolicy, peerAuthentications []*security_v1beta.PeerAuthentication) map[string][]meta_v1.Object {
+	objects := map[string][]meta_v1.Object{}
+	add := func(objectType string, object meta_v1.Object) {
+		objects[objectType] = append(objects[objectType], object)
+	}
+
+	for _, o := range authorizationPolicies {
+		add(AuthorizationPolicyCheckerType, o)
+	}
+	for _, o := range istioConfigList.DestinationRules {
+		add(DestinationRuleCheckerType, o)
+	}
+	for _, o := range istioConfigList.EnvoyFilters {
+		add(EnvoyFilterCheckerType, o)
+	}
+	for _, o := range istioConfigList.Gateways {
+		add(GatewayCheckerType, o)
+	}
+	for _, o := range istioConfigList.K8sGateways {
+		add(K8sGatewayCheckerType, o)
+	}
+	for _, o := range istioConfigList.K8sGRPCRoutes {
+		add(K8sGRPCRouteCheckerType, o)
+	}
+	for _, o := range istioConfigList.K8sHTTPRoutes {
+		add(K8sHTTPRouteCheckerType, o)
+	}
+	for _, o := range istioConfigList.K8sReferenceGrants {
+		add(K8sReferenceGrantCheckerType, o)
+	}
+	for _, o := range istioConfigList.K8sTCPRoutes {
+		add(K8sTCPRouteCheckerType, o)
+	}
+	for _, o := range istioConfigList.K8sTLSRoutes {
+		add(K8sTLSRouteCheckerType, o)
+	}
+	for _, o := range peerAuthentications {
+		add(PeerAuthenticationCheckerType, o)
+	}
+	for _, o := range istioConfigList.RequestAuthentications {
+		add(RequestAuthenticationCheckerType, o)
+	}
+	for _, o := range istioConfigList.ServiceEntries {
+		add(ServiceEntryCheckerType, o)
+	}
+	for _, o := range istioConfigList.Sidecars {
+		add(SidecarCheckerType, o)
+	}
+	for _, o := range istioConfigList.Telemetries {
+		add(TelemetryCheckerType, o)
+	}
+	for _, o := range istioConfigList.VirtualServices {
+		add(VirtualCheckerType, o)
+	}
+	for _, o := range istioConfigList.WasmPlugins {
+		add(WasmPluginCheckerType, o)
+	}
+
+	return objects
+}
@@ -0,0 +1,94 @@
+package checkers
+
+import (
+	"encoding/json"
+
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers/customrules"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// CustomRulesChecker runs the validation rules defined in the Kiali config over the Istio objects of their type
+type CustomRulesChecker struct {
+	AuthorizationPolicies []*security_v1beta.AuthorizationPolicy
+	IstioConfigList       models.IstioConfigList
+	Namespaces            models.Namespaces
+	PeerAuthentications   []*security_v1beta.PeerAuthentication
+	Rules                 []config.ValidationRule
+}
+
+func (in CustomRulesChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+	if len(in.Rules) == 0 {
+		return validations
+	}
+
+	objectsPerType := IstioObjectsPerType(in.IstioConfigList, in.AuthorizationPolicies, in.PeerAuthentications)
+	for _, rule := range in.Rules {
+		if rule.Code == "" {
+			log.Errorf("Validation rule for [%s] without a code, the rule is skipped", rule.ObjectType)
+			continue
+		}
+		selector, err := labels.Parse(rule.NamespaceSelector)
+		if err != nil {
+			log.Errorf("Validation rule [%s] with an invalid namespace selector, the rule is skipped: %s", rule.Code, err)
+			continue
+		}
+
+		for _, object := range objectsPerType[rule.ObjectType] {
+			if !in.namespaceMatches(object.GetNamespace(), selector) {
+				continue
+			}
+			validations.MergeValidations(in.runChecks(rule, rule.ObjectType, object))
+		}
+	}
+
+	return validations
+}
+
+func (in CustomRulesChecker) runChecks(rule config.ValidationRule, objectType string, object meta_v1.Object) models.IstioValidations {
+	key, validation := EmptyValidValidation(object.GetName(), object.GetNamespace(), objectType)
+
+	unstructured, err := toUnstructured(object)
+	if err != nil {
+		log.Errorf("Unable to evaluate the validation rule [%s] on %s [%s/%s]: %s", rule.Code, objectType, object.GetNamespace(), object.GetName(), err)
+		return models.IstioValidations{key: validation}
+	}
+
+	checks, valid := customrules.RuleChecker{Rule: rule, Object: unstructured}.Check()
+	validation.Checks = append(validation.Checks, checks...)
+	validation.Valid = validation.Valid && valid
+
+	return models.IstioValidations{key: validation}
+}
+
+// namespaceMatches returns true when the labels of the namespace match the selector. The namespaces that are not
+// known only match the empty selector.
+func (in CustomRulesChecker) namespaceMatches(namespace string, selector labels.Selector) bool {
+	if selector.Empty() {
+		return true
+	}
+	for _, ns := range in.Namespaces {
+		if ns.Name == namespace {
+			return selector.Matches(labels.Set(ns.Labels))
+		}
+	}
+	return false
+}
+
+// toUnstructured returns the JSON representation of the object, the Istio specs are protobuf messages with their
+// own JSON marshalling
+func toUnstructured(object meta_v1.Object) (map[string]interface{}, error) {
+	b, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	unstructured := map[string]interface{}{}
+	err = json.Unmarshal(b, &unstructured)
+	return unstructured, err
+}
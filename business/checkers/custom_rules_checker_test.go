@@ -0,0 +1,108 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func outlierDetectionRule() config.ValidationRule {
+	return config.ValidationRule{
+		Code:              "ORG002",
+		Expression:        "has(object.spec.trafficPolicy) && has(object.spec.trafficPolicy.outlierDetection)",
+		Message:           "DestinationRules must define outlierDetection in prod namespaces",
+		NamespaceSelector: "env=prod",
+		ObjectType:        "destinationrule",
+		Path:              "spec/trafficPolicy",
+		Severity:          "error",
+	}
+}
+
+func TestCustomRulesNamespaceSelector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	withOutlierDetection := data.AddTrafficPolicyToDestinationRule(&api_networking_v1beta1.TrafficPolicy{OutlierDetection: &api_networking_v1beta1.OutlierDetection{}},
+		data.CreateEmptyDestinationRule("prod", "reviews", "reviews"))
+
+	validations := CustomRulesChecker{
+		IstioConfigList: models.IstioConfigList{
+			DestinationRules: []*networking_v1beta1.DestinationRule{
+				data.CreateEmptyDestinationRule("prod", "details", "details"),
+				withOutlierDetection,
+				data.CreateEmptyDestinationRule("dev", "details", "details"),
+			},
+		},
+		Namespaces: models.Namespaces{
+			{Name: "prod", Labels: map[string]string{"env": "prod"}},
+			{Name: "dev", Labels: map[string]string{"env": "dev"}},
+		},
+		Rules: []config.ValidationRule{outlierDetectionRule()},
+	}.Check()
+
+	require.Len(validations, 2)
+
+	validation := validations[models.IstioValidationKey{ObjectType: "destinationrule", Namespace: "prod", Name: "details"}]
+	require.NotNil(validation)
+	assert.False(validation.Valid)
+	require.Len(validation.Checks, 1)
+	assert.Equal("ORG002", validation.Checks[0].Code)
+	assert.Equal("spec/trafficPolicy", validation.Checks[0].Path)
+
+	validation = validations[models.IstioValidationKey{ObjectType: "destinationrule", Namespace: "prod", Name: "reviews"}]
+	require.NotNil(validation)
+	assert.True(validation.Valid)
+	assert.Empty(validation.Checks)
+}
+
+func TestCustomRulesObjectTypes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rules := []config.ValidationRule{
+		{Code: "ORG003", Expression: "has(object.metadata.labels) && 'team' in object.metadata.labels", Message: "Missing team label", ObjectType: "authorizationpolicy"},
+		{Code: "ORG004", Expression: "object.metadata.name.startsWith('vs-')", Message: "Invalid name", ObjectType: "virtualservice"},
+		// rules without a code or with an invalid selector are skipped
+		{Expression: "false", ObjectType: "virtualservice"},
+		{Code: "ORG005", Expression: "false", ObjectType: "virtualservice", NamespaceSelector: "env in (prod"},
+	}
+
+	validations := CustomRulesChecker{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{data.CreateEmptyAuthorizationPolicy("allow-all", "bookinfo")},
+		IstioConfigList: models.IstioConfigList{
+			VirtualServices: []*networking_v1beta1.VirtualService{data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})},
+		},
+		Rules: rules,
+	}.Check()
+
+	require.Len(validations, 2)
+	validation := validations[models.IstioValidationKey{ObjectType: "authorizationpolicy", Namespace: "bookinfo", Name: "allow-all"}]
+	require.NotNil(validation)
+	require.Len(validation.Checks, 1)
+	assert.Equal("ORG003", validation.Checks[0].Code)
+	assert.Equal(models.WarningSeverity, validation.Checks[0].Severity)
+	assert.True(validation.Valid)
+
+	validation = validations[models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "reviews"}]
+	require.NotNil(validation)
+	require.Len(validation.Checks, 1)
+	assert.Equal("ORG004", validation.Checks[0].Code)
+}
+
+func TestCustomRulesWithoutRules(t *testing.T) {
+	validations := CustomRulesChecker{
+		IstioConfigList: models.IstioConfigList{
+			VirtualServices: []*networking_v1beta1.VirtualService{data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})},
+		},
+	}.Check()
+
+	assert.Empty(t, validations)
+}
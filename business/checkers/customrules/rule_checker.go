@@ -0,0 +1,110 @@
+package customrules
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// programs caches the compiled expressions, the rules only change with the config
+var programs sync.Map
+
+type compiledExpression struct {
+	program cel.Program
+	err     error
+}
+
+type RuleChecker struct {
+	Rule config.ValidationRule
+	// Object is the Istio object as unstructured JSON, exposed to the expression as "object"
+	Object map[string]interface{}
+}
+
+// Check evaluates the expression of the rule over the object, the object is valid when it evaluates to true.
+// The rules are validated when the config is loaded, a rule with an invalid expression is still skipped. Evaluation
+// errors (e.g. a missing field) fail the rule.
+func (rc RuleChecker) Check() ([]*models.IstioCheck, bool) {
+	program, err := Compile(rc.Rule.Expression)
+	if err != nil {
+		return []*models.IstioCheck{}, true
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{"object": rc.Object})
+	if err == nil {
+		if satisfied, ok := out.Value().(bool); ok && satisfied {
+			return []*models.IstioCheck{}, true
+		}
+	} else {
+		log.Debugf("Validation rule [%s] failed to evaluate: %s", rc.Rule.Code, err)
+	}
+
+	severity := Severity(rc.Rule)
+	check := models.IstioCheck{
+		Code:     rc.Rule.Code,
+		Message:  rc.Rule.Message,
+		Severity: severity,
+		Path:     rc.Rule.Path,
+	}
+	return []*models.IstioCheck{&check}, severity != models.ErrorSeverity
+}
+
+// Severity returns the severity of the checks of the rule, warning when it is not set or not supported
+func Severity(rule config.ValidationRule) models.SeverityLevel {
+	if models.SeverityLevel(rule.Severity) == models.ErrorSeverity {
+		return models.ErrorSeverity
+	}
+	return models.WarningSeverity
+}
+
+// ValidateRules returns an error for the first invalid rule: a rule without code, with the code of a built-in check,
+// or with an expression that doesn't compile. It is called when the config is loaded, so that an invalid rule is
+// rejected instead of being skipped at check time.
+func ValidateRules(rules []config.ValidationRule) error {
+	for i, rule := range rules {
+		if rule.Code == "" {
+			return fmt.Errorf("validation rule [%d] has no code", i)
+		}
+		if models.IsBuiltInCheckCode(rule.Code) {
+			return fmt.Errorf("validation rule [%s] uses the code of a built-in Kiali check", rule.Code)
+		}
+		if _, err := compile(rule.Expression); err != nil {
+			return fmt.Errorf("validation rule [%s] has an invalid expression [%s]: %w", rule.Code, rule.Expression, err)
+		}
+	}
+	return nil
+}
+
+// Compile returns the program of a boolean CEL expression over an "object" variable
+func Compile(expression string) (cel.Program, error) {
+	if cached, found := programs.Load(expression); found {
+		compiled := cached.(compiledExpression)
+		return compiled.program, compiled.err
+	}
+
+	program, err := compile(expression)
+	if err != nil {
+		log.Errorf("Invalid validation rule expression [%s], the rule is skipped: %s", expression, err)
+	}
+	programs.Store(expression, compiledExpression{program: program, err: err})
+	return program, err
+}
+
+func compile(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("the expression must evaluate to a bool, not to %s", ast.OutputType())
+	}
+	return env.Program(ast)
+}
@@ -0,0 +1,129 @@
+package customrules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func timeoutRule() config.ValidationRule {
+	return config.ValidationRule{
+		Code:       "ORG001",
+		Expression: "object.spec.http.all(r, has(r.timeout))",
+		Message:    "Every route must set a timeout",
+		ObjectType: "virtualservice",
+		Path:       "spec/http",
+		Severity:   "error",
+	}
+}
+
+func virtualService(routes ...map[string]interface{}) map[string]interface{} {
+	http := []interface{}{}
+	for _, route := range routes {
+		http = append(http, route)
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "reviews", "namespace": "bookinfo"},
+		"spec":     map[string]interface{}{"http": http},
+	}
+}
+
+func TestRuleSatisfied(t *testing.T) {
+	assert := assert.New(t)
+
+	object := virtualService(map[string]interface{}{"timeout": "5s"}, map[string]interface{}{"timeout": "1s"})
+	checks, valid := RuleChecker{Rule: timeoutRule(), Object: object}.Check()
+
+	assert.Empty(checks)
+	assert.True(valid)
+}
+
+func TestRuleNotSatisfied(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	object := virtualService(map[string]interface{}{"timeout": "5s"}, map[string]interface{}{})
+	checks, valid := RuleChecker{Rule: timeoutRule(), Object: object}.Check()
+
+	assert.False(valid)
+	require.Len(checks, 1)
+	assert.Equal("ORG001", checks[0].Code)
+	assert.Equal("Every route must set a timeout", checks[0].Message)
+	assert.Equal(models.ErrorSeverity, checks[0].Severity)
+	assert.Equal("spec/http", checks[0].Path)
+}
+
+func TestRuleWarning(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rule := timeoutRule()
+	rule.Severity = ""
+	checks, valid := RuleChecker{Rule: rule, Object: virtualService(map[string]interface{}{})}.Check()
+
+	// warnings don't make the object invalid
+	assert.True(valid)
+	require.Len(checks, 1)
+	assert.Equal(models.WarningSeverity, checks[0].Severity)
+}
+
+func TestRuleEvaluationError(t *testing.T) {
+	assert := assert.New(t)
+
+	// a VirtualService without http routes has no spec.http field
+	object := map[string]interface{}{"spec": map[string]interface{}{"tcp": []interface{}{}}}
+	checks, valid := RuleChecker{Rule: timeoutRule(), Object: object}.Check()
+	assert.False(valid)
+	assert.Len(checks, 1)
+
+	rule := timeoutRule()
+	rule.Expression = "!has(object.spec.http) || object.spec.http.all(r, has(r.timeout))"
+	checks, valid = RuleChecker{Rule: rule, Object: object}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+}
+
+func TestRuleInvalidExpression(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := timeoutRule()
+	rule.Expression = "object.spec.http.all(r, "
+	checks, valid := RuleChecker{Rule: rule, Object: virtualService(map[string]interface{}{})}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+
+	_, err := Compile("object.metadata.name")
+	assert.NoError(err, "dynamic results are checked on evaluation")
+	_, err = Compile("size(object.spec.http)")
+	assert.Error(err)
+	_, err = Compile("1 + 1")
+	assert.Error(err)
+}
+
+func TestValidateRules(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ValidateRules([]config.ValidationRule{timeoutRule()}))
+	assert.NoError(ValidateRules(nil))
+
+	invalidExpression := timeoutRule()
+	invalidExpression.Expression = "object.spec.http.all(r, "
+	assert.ErrorContains(ValidateRules([]config.ValidationRule{timeoutRule(), invalidExpression}), "validation rule [ORG001] has an invalid expression")
+
+	notBool := timeoutRule()
+	notBool.Expression = "size(object.spec.http)"
+	assert.Error(ValidateRules([]config.ValidationRule{notBool}))
+
+	// a rule can't impersonate a built-in check
+	builtIn := timeoutRule()
+	builtIn.Code = "KIA1101"
+	assert.EqualError(ValidateRules([]config.ValidationRule{builtIn}), "validation rule [KIA1101] uses the code of a built-in Kiali check")
+
+	noCode := timeoutRule()
+	noCode.Code = ""
+	assert.EqualError(ValidateRules([]config.ValidationRule{noCode}), "validation rule [0] has no code")
+}
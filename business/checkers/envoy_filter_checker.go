@@ -0,0 +1,78 @@
+package checkers
+
+import (
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/business/checkers/envoyfilters"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const EnvoyFilterCheckerType = envoyfilters.EnvoyFilterCheckerType
+
+type EnvoyFilterChecker struct {
+	EnvoyFilters          []*networking_v1alpha3.EnvoyFilter
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	ProxyVersions         []string                                             // Istio versions of the running proxies
+	ConfigDumps           map[models.IstioValidationKey]*kubernetes.ConfigDump // sampled config dump per EnvoyFilter
+}
+
+// An Object Checker runs all checkers for an specific object type (i.e.: pod, route rule,...)
+// It run two kinds of checkers:
+// 1. Individual checks: validating individual objects.
+// 2. Group checks: validating behaviour between configurations.
+func (in EnvoyFilterChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	validations = validations.MergeValidations(in.runIndividualChecks())
+	validations = validations.MergeValidations(in.runGroupChecks())
+
+	return validations
+}
+
+func (in EnvoyFilterChecker) runGroupChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	enabledCheckers := []GroupChecker{
+		envoyfilters.OrderingChecker{EnvoyFilters: in.EnvoyFilters, WorkloadsPerNamespace: in.WorkloadsPerNamespace},
+	}
+
+	for _, checker := range enabledCheckers {
+		validations = validations.MergeValidations(checker.Check())
+	}
+
+	return validations
+}
+
+func (in EnvoyFilterChecker) runIndividualChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, envoyFilter := range in.EnvoyFilters {
+		validations.MergeValidations(in.runChecks(envoyFilter))
+	}
+
+	return validations
+}
+
+func (in EnvoyFilterChecker) runChecks(envoyFilter *networking_v1alpha3.EnvoyFilter) models.IstioValidations {
+	key, rrValidation := EmptyValidValidation(envoyFilter.Name, envoyFilter.Namespace, EnvoyFilterCheckerType)
+	selectorLabels := make(map[string]string)
+	if envoyFilter.Spec.WorkloadSelector != nil {
+		selectorLabels = envoyFilter.Spec.WorkloadSelector.Labels
+	}
+
+	enabledCheckers := []Checker{
+		common.WorkloadSelectorNoWorkloadFoundChecker(EnvoyFilterCheckerType, selectorLabels, in.WorkloadsPerNamespace),
+		envoyfilters.ProxyVersionChecker{EnvoyFilter: envoyFilter, ProxyVersions: in.ProxyVersions},
+		envoyfilters.PatchTargetChecker{EnvoyFilter: envoyFilter, ConfigDump: in.ConfigDumps[key]},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
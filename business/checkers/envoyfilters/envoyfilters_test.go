@@ -0,0 +1,167 @@
+package envoyfilters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestProxyVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	ef := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE,
+		&api_networking_v1alpha3.EnvoyFilter_ListenerMatch{PortNumber: 9080},
+		data.CreateEnvoyFilter("filter", "bookinfo", nil))
+	ef = data.AddProxyVersionToEnvoyFilter(`^1\.16.*`, ef)
+
+	// unknown versions are not validated
+	vals, valid := ProxyVersionChecker{EnvoyFilter: ef}.Check()
+	assert.Empty(vals)
+	assert.True(valid)
+
+	vals, valid = ProxyVersionChecker{EnvoyFilter: ef, ProxyVersions: []string{"1.17.1", "1.16.2"}}.Check()
+	assert.Empty(vals)
+	assert.True(valid)
+
+	vals, valid = ProxyVersionChecker{EnvoyFilter: ef, ProxyVersions: []string{"1.17.1"}}.Check()
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.proxyversion.nomatch", vals[0]))
+	assert.Equal("spec/configPatches[0]/match/proxy/proxyVersion", vals[0].Path)
+}
+
+func fakeConfigDump() *kubernetes.ConfigDump {
+	return &kubernetes.ConfigDump{
+		Configs: []interface{}{
+			map[string]interface{}{
+				"@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+				"dynamic_listeners": []interface{}{
+					map[string]interface{}{
+						"name": "0.0.0.0_9080",
+						"active_state": map[string]interface{}{
+							"listener": map[string]interface{}{
+								"name":    "0.0.0.0_9080",
+								"address": map[string]interface{}{"socket_address": map[string]interface{}{"address": "0.0.0.0", "port_value": 9080.0}},
+							},
+						},
+					},
+				},
+			},
+			map[string]interface{}{
+				"@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+				"dynamic_active_clusters": []interface{}{
+					map[string]interface{}{"cluster": map[string]interface{}{"name": "outbound|9080|v1|reviews.bookinfo.svc.cluster.local"}},
+				},
+			},
+		},
+	}
+}
+
+func TestPatchTargetFound(t *testing.T) {
+	assert := assert.New(t)
+
+	ef := data.CreateEnvoyFilter("filter", "bookinfo", nil)
+	ef = data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{PortNumber: 9080}, ef)
+	ef = data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{Name: "0.0.0.0_9080"}, ef)
+	ef = data.AddClusterPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, &api_networking_v1alpha3.EnvoyFilter_ClusterMatch{Service: "reviews.bookinfo.svc.cluster.local", PortNumber: 9080}, ef)
+	// ADD patches are not validated
+	ef = data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_ADD, &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{PortNumber: 8080}, ef)
+
+	vals, valid := PatchTargetChecker{EnvoyFilter: ef, ConfigDump: fakeConfigDump()}.Check()
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+func TestPatchTargetNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	ef := data.CreateEnvoyFilter("filter", "bookinfo", nil)
+	ef = data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{PortNumber: 8080}, ef)
+	ef = data.AddClusterPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_REPLACE, &api_networking_v1alpha3.EnvoyFilter_ClusterMatch{Service: "reviews.bookinfo.svc.cluster.local", Subset: "v2"}, ef)
+
+	// without a sampled config dump the targets are not validated
+	vals, valid := PatchTargetChecker{EnvoyFilter: ef}.Check()
+	assert.Empty(vals)
+	assert.True(valid)
+
+	vals, valid = PatchTargetChecker{EnvoyFilter: ef, ConfigDump: fakeConfigDump()}.Check()
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.patch.targetnotfound", vals[0]))
+	assert.Equal("spec/configPatches[0]/match/listener", vals[0].Path)
+	assert.Equal("spec/configPatches[1]/match/cluster", vals[1].Path)
+}
+
+func TestAmbiguousOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	listener := &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{PortNumber: 9080}
+	filter1 := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, data.CreateEnvoyFilter("filter1", "bookinfo", nil))
+	filter2 := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, data.CreateEnvoyFilter("filter2", "bookinfo", map[string]string{"app": "reviews"}))
+	// different priority
+	filter3 := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, data.CreateEnvoyFilter("filter3", "bookinfo", nil))
+	filter3.Spec.Priority = 10
+	// no overlapping workload
+	filter4 := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, data.CreateEnvoyFilter("filter4", "bookinfo", map[string]string{"app": "ratings"}))
+
+	vals := OrderingChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{filter1, filter2, filter3, filter4},
+		WorkloadsPerNamespace: data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+			data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		),
+	}.Check()
+
+	assert.Len(vals, 2)
+	validation, ok := vals[models.BuildKey(EnvoyFilterCheckerType, "filter1", "bookinfo")]
+	assert.True(ok)
+	assert.True(validation.Valid)
+	assert.Len(validation.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.patch.ambiguousorder", validation.Checks[0]))
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(EnvoyFilterCheckerType, "filter2", "bookinfo")}, validation.References)
+
+	_, ok = vals[models.BuildKey(EnvoyFilterCheckerType, "filter2", "bookinfo")]
+	assert.True(ok)
+}
+
+func TestAmbiguousOrderReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	listener := &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{PortNumber: 9080}
+	// two patches of filter1 and two EnvoyFilters patching the same listener
+	filter1 := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, data.CreateEnvoyFilter("filter1", "bookinfo", nil))
+	filter1 = data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, filter1)
+	filter2 := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, data.CreateEnvoyFilter("filter2", "bookinfo", map[string]string{"app": "reviews"}))
+	filter3 := data.AddListenerPatchToEnvoyFilter(api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, listener, data.CreateEnvoyFilter("filter3", "bookinfo", map[string]string{"version": "v1"}))
+
+	vals := OrderingChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{filter1, filter2, filter3},
+		WorkloadsPerNamespace: data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+			data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		),
+	}.Check()
+
+	assert.Len(vals, 3)
+	validation := vals[models.BuildKey(EnvoyFilterCheckerType, "filter1", "bookinfo")]
+	if assert.Len(validation.Checks, 2) {
+		assert.Equal("spec/configPatches[0]", validation.Checks[0].Path)
+		assert.Equal("spec/configPatches[1]", validation.Checks[1].Path)
+	}
+	assert.Equal([]models.IstioValidationKey{
+		models.BuildKey(EnvoyFilterCheckerType, "filter2", "bookinfo"),
+		models.BuildKey(EnvoyFilterCheckerType, "filter3", "bookinfo"),
+	}, validation.References)
+
+	validation = vals[models.BuildKey(EnvoyFilterCheckerType, "filter2", "bookinfo")]
+	assert.Len(validation.Checks, 1)
+	assert.Equal([]models.IstioValidationKey{
+		models.BuildKey(EnvoyFilterCheckerType, "filter1", "bookinfo"),
+		models.BuildKey(EnvoyFilterCheckerType, "filter3", "bookinfo"),
+	}, validation.References)
+}
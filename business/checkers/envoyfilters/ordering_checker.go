@@ -0,0 +1,137 @@
+package envoyfilters
+
+import (
+	"fmt"
+
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/models"
+)
+
+const EnvoyFilterCheckerType = "envoyfilter"
+
+type OrderingChecker struct {
+	EnvoyFilters          []*networking_v1alpha3.EnvoyFilter
+	WorkloadsPerNamespace map[string]models.WorkloadList
+}
+
+type patchRef struct {
+	envoyFilter *networking_v1alpha3.EnvoyFilter
+	index       int
+}
+
+// Check validates that no two EnvoyFilters of the same namespace, with the same priority and applied to the
+// same workload, patch the same object. Istio then applies the patches in creation order, which is fragile.
+func (m OrderingChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	// group the patches by namespace, priority and patched object
+	patches := map[string][]patchRef{}
+	for _, ef := range m.EnvoyFilters {
+		for i, patch := range ef.Spec.ConfigPatches {
+			if patch == nil {
+				continue
+			}
+			key := fmt.Sprintf("%s|%d|%s", ef.Namespace, ef.Spec.Priority, patchTarget(patch))
+			patches[key] = append(patches[key], patchRef{envoyFilter: ef, index: i})
+		}
+	}
+
+	// every pair of patches is checked once, the overlap once per pair of EnvoyFilters
+	overlaps := map[[2]*networking_v1alpha3.EnvoyFilter]bool{}
+	for _, refs := range patches {
+		for i, ref := range refs {
+			for _, other := range refs[i+1:] {
+				if ref.envoyFilter == other.envoyFilter {
+					continue
+				}
+				pair := [2]*networking_v1alpha3.EnvoyFilter{ref.envoyFilter, other.envoyFilter}
+				overlap, found := overlaps[pair]
+				if !found {
+					overlap = m.overlap(ref.envoyFilter, other.envoyFilter)
+					overlaps[pair] = overlap
+					overlaps[[2]*networking_v1alpha3.EnvoyFilter{other.envoyFilter, ref.envoyFilter}] = overlap
+				}
+				if overlap {
+					addAmbiguousOrder(validations, ref, other.envoyFilter)
+					addAmbiguousOrder(validations, other, ref.envoyFilter)
+				}
+			}
+		}
+	}
+
+	return validations
+}
+
+// addAmbiguousOrder adds the check of the patch, once per patch, and the reference to the other EnvoyFilter, once
+// per EnvoyFilter
+func addAmbiguousOrder(validations models.IstioValidations, ref patchRef, other *networking_v1alpha3.EnvoyFilter) {
+	key := models.BuildKey(EnvoyFilterCheckerType, ref.envoyFilter.Name, ref.envoyFilter.Namespace)
+	validation, found := validations[key]
+	if !found {
+		validation = &models.IstioValidation{
+			Name:       ref.envoyFilter.Name,
+			ObjectType: EnvoyFilterCheckerType,
+			Valid:      true,
+			References: []models.IstioValidationKey{},
+			Checks:     []*models.IstioCheck{},
+		}
+		validations[key] = validation
+	}
+
+	path := fmt.Sprintf("spec/configPatches[%d]", ref.index)
+	hasCheck := false
+	for _, check := range validation.Checks {
+		if check.Path == path {
+			hasCheck = true
+			break
+		}
+	}
+	if !hasCheck {
+		check := models.Build("envoyfilter.patch.ambiguousorder", path)
+		validation.Checks = append(validation.Checks, &check)
+	}
+
+	otherKey := models.BuildKey(EnvoyFilterCheckerType, other.Name, other.Namespace)
+	for _, reference := range validation.References {
+		if reference == otherKey {
+			return
+		}
+	}
+	validation.References = append(validation.References, otherKey)
+}
+
+// patchTarget identifies the object patched by the patch, regardless of the proxy match
+func patchTarget(patch *api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch) string {
+	target := patch.ApplyTo.String()
+	if patch.Match != nil {
+		target = fmt.Sprintf("%s|%s|%v|%v|%v", target, patch.Match.Context, patch.Match.GetListener(), patch.Match.GetRouteConfiguration(), patch.Match.GetCluster())
+	}
+	return target
+}
+
+// overlap returns true if a workload of the namespace is selected by both EnvoyFilters
+func (m OrderingChecker) overlap(ef1, ef2 *networking_v1alpha3.EnvoyFilter) bool {
+	wls, found := m.WorkloadsPerNamespace[ef1.Namespace]
+	if !found {
+		return false
+	}
+	selector1 := workloadSelector(ef1)
+	selector2 := workloadSelector(ef2)
+	for _, w := range wls.Workloads {
+		wlLabels := labels.Set(w.Labels)
+		if selector1.Matches(wlLabels) && selector2.Matches(wlLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+func workloadSelector(ef *networking_v1alpha3.EnvoyFilter) labels.Selector {
+	if ef.Spec.WorkloadSelector == nil {
+		return labels.Everything()
+	}
+	return labels.SelectorFromSet(ef.Spec.WorkloadSelector.Labels)
+}
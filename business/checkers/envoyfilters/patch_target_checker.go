@@ -0,0 +1,151 @@
+package envoyfilters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+type PatchTargetChecker struct {
+	EnvoyFilter *networking_v1alpha3.EnvoyFilter
+	// ConfigDump is the config dump of a proxy sampled from the proxies the EnvoyFilter applies to. When nil
+	// the patch targets are not validated.
+	ConfigDump *kubernetes.ConfigDump
+}
+
+// HasPatchTargets returns true if the EnvoyFilter has MERGE or REPLACE patches matching a listener or a
+// cluster, which are validated against a sampled config dump.
+func HasPatchTargets(ef *networking_v1alpha3.EnvoyFilter) bool {
+	for _, patch := range ef.Spec.ConfigPatches {
+		if isTargetedPatch(patch) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTargetedPatch(patch *api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch) bool {
+	if patch == nil || patch.Match == nil || patch.Patch == nil {
+		return false
+	}
+	if patch.Patch.Operation != api_networking_v1alpha3.EnvoyFilter_Patch_MERGE && patch.Patch.Operation != api_networking_v1alpha3.EnvoyFilter_Patch_REPLACE {
+		return false
+	}
+	if listener := patch.Match.GetListener(); listener != nil {
+		return listener.Name != "" || listener.PortNumber != 0
+	}
+	if cluster := patch.Match.GetCluster(); cluster != nil {
+		return cluster.Name != "" || cluster.Service != "" || cluster.PortNumber != 0
+	}
+	return false
+}
+
+// Check validates that the listeners and clusters matched by the MERGE and REPLACE patches exist in the
+// sampled config dump
+func (pc PatchTargetChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	if pc.ConfigDump == nil || !HasPatchTargets(pc.EnvoyFilter) {
+		return checks, valid
+	}
+
+	listeners, err := pc.ConfigDump.GetListeners()
+	if err != nil {
+		log.Debugf("Unable to read the listeners of the config dump for EnvoyFilter [%s/%s]: %v", pc.EnvoyFilter.Namespace, pc.EnvoyFilter.Name, err)
+		return checks, valid
+	}
+	clusters, err := pc.ConfigDump.GetClusters()
+	if err != nil {
+		log.Debugf("Unable to read the clusters of the config dump for EnvoyFilter [%s/%s]: %v", pc.EnvoyFilter.Namespace, pc.EnvoyFilter.Name, err)
+		return checks, valid
+	}
+
+	for i, patch := range pc.EnvoyFilter.Spec.ConfigPatches {
+		if !isTargetedPatch(patch) {
+			continue
+		}
+		if listener := patch.Match.GetListener(); listener != nil && !hasListener(listeners, listener) {
+			check := models.Build("envoyfilter.patch.targetnotfound", fmt.Sprintf("spec/configPatches[%d]/match/listener", i))
+			checks = append(checks, &check)
+		}
+		if cluster := patch.Match.GetCluster(); cluster != nil && !hasCluster(clusters, cluster) {
+			check := models.Build("envoyfilter.patch.targetnotfound", fmt.Sprintf("spec/configPatches[%d]/match/cluster", i))
+			checks = append(checks, &check)
+		}
+	}
+
+	return checks, valid
+}
+
+func hasListener(dump *kubernetes.ListenerDump, match *api_networking_v1alpha3.EnvoyFilter_ListenerMatch) bool {
+	listeners := make([]kubernetes.EnvoyListener, 0, len(dump.DynamicListeners)+len(dump.StaticListeners))
+	for _, l := range dump.DynamicListeners {
+		listener := l.ActiveState.Listener
+		listener.Name = l.Name
+		listeners = append(listeners, listener)
+	}
+	for _, l := range dump.StaticListeners {
+		listeners = append(listeners, l.Listener)
+	}
+
+	for _, l := range listeners {
+		if match.Name != "" && l.Name != match.Name {
+			continue
+		}
+		if match.PortNumber == 0 || hasPort(l, match.PortNumber) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPort returns true if the listener binds the port, or has a filter chain for the port (e.g. virtualInbound)
+func hasPort(l kubernetes.EnvoyListener, port uint32) bool {
+	if uint32(l.Address.SocketAddress.PortValue) == port {
+		return true
+	}
+	for _, fc := range l.FilterChains {
+		if fc.FilterChainMatch != nil && fc.FilterChainMatch.DestinationPort != nil && uint32(*fc.FilterChainMatch.DestinationPort) == port {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCluster(dump *kubernetes.ClusterDump, match *api_networking_v1alpha3.EnvoyFilter_ClusterMatch) bool {
+	clusters := make([]kubernetes.EnvoyClusterWrapper, 0, len(dump.DynamicClusters)+len(dump.StaticClusters))
+	clusters = append(clusters, dump.DynamicClusters...)
+	clusters = append(clusters, dump.StaticClusters...)
+
+	for _, c := range clusters {
+		if match.Name != "" {
+			if c.Cluster.Name == match.Name {
+				return true
+			}
+			continue
+		}
+		// Istio cluster names are of the form <direction>|<port>|<subset>|<service>
+		tokens := strings.Split(c.Cluster.Name, "|")
+		if len(tokens) != 4 {
+			continue
+		}
+		if match.PortNumber != 0 && tokens[1] != strconv.FormatUint(uint64(match.PortNumber), 10) {
+			continue
+		}
+		if match.Subset != "" && tokens[2] != match.Subset {
+			continue
+		}
+		if match.Service != "" && tokens[3] != match.Service {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package envoyfilters
+
+import (
+	"fmt"
+	"regexp"
+
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/models"
+)
+
+type ProxyVersionChecker struct {
+	EnvoyFilter *networking_v1alpha3.EnvoyFilter
+	// ProxyVersions are the Istio versions of the running proxies. When empty the versions are unknown, and
+	// are not validated.
+	ProxyVersions []string
+}
+
+// Check validates that the proxyVersion regex of every patch matches at least one running proxy
+func (pc ProxyVersionChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	if len(pc.ProxyVersions) == 0 {
+		return checks, valid
+	}
+
+	for i, patch := range pc.EnvoyFilter.Spec.ConfigPatches {
+		if patch == nil || patch.Match == nil || patch.Match.Proxy == nil || patch.Match.Proxy.ProxyVersion == "" {
+			continue
+		}
+		// an invalid regex is rejected by the Istio validation webhook
+		regex, err := regexp.Compile(patch.Match.Proxy.ProxyVersion)
+		if err != nil || matchesAny(regex, pc.ProxyVersions) {
+			continue
+		}
+		check := models.Build("envoyfilter.proxyversion.nomatch", fmt.Sprintf("spec/configPatches[%d]/match/proxy/proxyVersion", i))
+		checks = append(checks, &check)
+	}
+
+	return checks, valid
+}
+
+func matchesAny(regex *regexp.Regexp, versions []string) bool {
+	for _, version := range versions {
+		if regex.MatchString(version) {
+			return true
+		}
+	}
+	return false
+}
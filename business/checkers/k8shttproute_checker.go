@@ -1,9 +1,11 @@
 package checkers
 
 import (
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiali/kiali/business/checkers/k8shttproutes"
+	"github.com/kiali/kiali/business/checkers/k8sroutes"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 )
@@ -11,10 +13,11 @@ import (
 const K8sHTTPRouteCheckerType = "k8shttproute"
 
 type K8sHTTPRouteChecker struct {
-	K8sHTTPRoutes    []*k8s_networking_v1beta1.HTTPRoute
-	K8sGateways      []*k8s_networking_v1beta1.Gateway
-	Namespaces       models.Namespaces
-	RegistryServices []*kubernetes.RegistryService
+	K8sHTTPRoutes      []*k8s_networking_v1beta1.HTTPRoute
+	K8sGateways        []*k8s_networking_v1beta1.Gateway
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
+	Namespaces         models.Namespaces
+	RegistryServices   []*kubernetes.RegistryService
 }
 
 // Check runs checks for the all namespaces actions as well as for the single namespace validations
@@ -52,6 +55,10 @@ func (in K8sHTTPRouteChecker) runChecks(rt *k8s_networking_v1beta1.HTTPRoute, ga
 			Namespaces:       in.Namespaces,
 			RegistryServices: in.RegistryServices,
 		},
+		k8sroutes.ReferenceGrantChecker{
+			K8sReferenceGrants: in.K8sReferenceGrants,
+			Route:              k8sroutes.HTTPRoute(rt),
+		},
 	}
 
 	for _, checker := range enabledCheckers {
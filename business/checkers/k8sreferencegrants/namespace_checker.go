@@ -0,0 +1,28 @@
+package k8sreferencegrants
+
+import (
+	"fmt"
+
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/models"
+)
+
+type NamespaceChecker struct {
+	K8sReferenceGrant *k8s_networking_v1alpha2.ReferenceGrant
+	Namespaces        models.Namespaces
+}
+
+// Check validates that the namespaces of the from entries of the ReferenceGrant exist
+func (n NamespaceChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for i, from := range n.K8sReferenceGrant.Spec.From {
+		if !n.Namespaces.Includes(string(from.Namespace)) {
+			validation := models.Build("k8sreferencegrants.from.namespacenotfound", fmt.Sprintf("spec/from[%d]/namespace", i))
+			validations = append(validations, &validation)
+		}
+	}
+
+	return validations, true
+}
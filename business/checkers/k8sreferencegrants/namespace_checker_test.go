@@ -0,0 +1,30 @@
+package k8sreferencegrants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestReferenceGrantNamespaces(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rg := data.CreateReferenceGrant("grant", "bookinfo", "istio-system", "HTTPRoute")
+
+	vals, valid := NamespaceChecker{K8sReferenceGrant: rg, Namespaces: models.Namespaces{{Name: "bookinfo"}, {Name: "istio-system"}}}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	vals, valid = NamespaceChecker{K8sReferenceGrant: rg, Namespaces: models.Namespaces{{Name: "bookinfo"}}}.Check()
+	assert.True(valid)
+	require.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sreferencegrants.from.namespacenotfound", vals[0]))
+	assert.Equal("spec/from[0]/namespace", vals[0].Path)
+}
@@ -0,0 +1,72 @@
+package checkers
+
+import (
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/business/checkers/k8sroutes"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	K8sGRPCRouteCheckerType = "k8sgrpcroute"
+	K8sTCPRouteCheckerType  = "k8stcproute"
+	K8sTLSRouteCheckerType  = "k8stlsroute"
+)
+
+// K8sRouteChecker validates the GRPCRoutes, TCPRoutes and TLSRoutes, which share the parentRefs and backendRefs checks
+type K8sRouteChecker struct {
+	K8sGateways        []*k8s_networking_v1beta1.Gateway
+	K8sGRPCRoutes      []*k8s_networking_v1alpha2.GRPCRoute
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
+	K8sTCPRoutes       []*k8s_networking_v1alpha2.TCPRoute
+	K8sTLSRoutes       []*k8s_networking_v1alpha2.TLSRoute
+	Namespaces         models.Namespaces
+	RegistryServices   []*kubernetes.RegistryService
+}
+
+func (in K8sRouteChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, rt := range in.K8sGRPCRoutes {
+		validations.MergeValidations(in.runChecks(k8sroutes.GRPCRoute(rt), K8sGRPCRouteCheckerType))
+	}
+	for _, rt := range in.K8sTCPRoutes {
+		validations.MergeValidations(in.runChecks(k8sroutes.TCPRoute(rt), K8sTCPRouteCheckerType))
+	}
+	for _, rt := range in.K8sTLSRoutes {
+		validations.MergeValidations(in.runChecks(k8sroutes.TLSRoute(rt), K8sTLSRouteCheckerType))
+	}
+
+	return validations
+}
+
+func (in K8sRouteChecker) runChecks(route k8sroutes.Route, checkerType string) models.IstioValidations {
+	key, validations := EmptyValidValidation(route.Name, route.Namespace, checkerType)
+
+	enabledCheckers := []Checker{
+		k8sroutes.NoK8sGatewayChecker{
+			K8sGateways: in.K8sGateways,
+			Namespaces:  in.Namespaces,
+			Route:       route,
+		},
+		k8sroutes.NoHostChecker{
+			Namespaces:       in.Namespaces,
+			Route:            route,
+			RegistryServices: in.RegistryServices,
+		},
+		k8sroutes.ReferenceGrantChecker{
+			K8sReferenceGrants: in.K8sReferenceGrants,
+			Route:              route,
+		},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
@@ -0,0 +1,61 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestK8sRouteChecker(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	gateway := data.AddListenerToK8sGateway(data.CreateListener("tcp", "", 9000, "TCP"),
+		data.AddListenerToK8sGateway(data.CreateListener("http", "", 80, "HTTP"), data.CreateEmptyK8sGateway("gateway", "bookinfo")))
+	registryServices := append(data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+		data.CreateFakeRegistryServices("ratings.bookinfo2.svc.cluster.local", "bookinfo2", "*")...)
+
+	vals := K8sRouteChecker{
+		K8sGateways: []*k8s_networking_v1beta1.Gateway{gateway},
+		K8sGRPCRoutes: []*k8s_networking_v1alpha2.GRPCRoute{
+			data.AddBackendRefToGRPCRoute("reviews", "bookinfo", data.CreateGRPCRoute("grpc", "bookinfo", "gateway")),
+		},
+		K8sTCPRoutes: []*k8s_networking_v1alpha2.TCPRoute{
+			data.AddBackendRefToTCPRoute("ratings", "bookinfo2", data.CreateTCPRoute("tcp", "bookinfo", "gateway")),
+		},
+		K8sTLSRoutes: []*k8s_networking_v1alpha2.TLSRoute{
+			data.AddBackendRefToTLSRoute("reviews", "bookinfo", data.CreateTLSRoute("tls", "bookinfo", "gateway")),
+		},
+		Namespaces:       models.Namespaces{{Name: "bookinfo"}, {Name: "bookinfo2"}},
+		RegistryServices: registryServices,
+	}.Check()
+
+	require.Len(vals, 3)
+
+	grpc := vals[models.IstioValidationKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: "grpc"}]
+	require.NotNil(grpc)
+	assert.True(grpc.Valid)
+	assert.Empty(grpc.Checks)
+
+	tcp := vals[models.IstioValidationKey{ObjectType: "k8stcproute", Namespace: "bookinfo", Name: "tcp"}]
+	require.NotNil(tcp)
+	assert.False(tcp.Valid)
+	require.Len(tcp.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sreferencegrant", tcp.Checks[0]))
+
+	tls := vals[models.IstioValidationKey{ObjectType: "k8stlsroute", Namespace: "bookinfo", Name: "tls"}]
+	require.NotNil(tls)
+	assert.False(tls.Valid)
+	require.Len(tls.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway.notallowed", tls.Checks[0]))
+}
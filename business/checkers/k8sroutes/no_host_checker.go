@@ -0,0 +1,38 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type NoHostChecker struct {
+	Namespaces       models.Namespaces
+	Route            Route
+	RegistryServices []*kubernetes.RegistryService
+}
+
+// Check validates that the Service backendRefs of the route are found in the registry
+func (n NoHostChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	for k, rule := range n.Route.Rules {
+		for i, ref := range rule {
+			if !isService(ref) {
+				continue
+			}
+			namespace := n.Route.backendNamespace(ref)
+			fqdn := kubernetes.GetHost(string(ref.Name), namespace, n.Namespaces.GetNames())
+			if !kubernetes.HasMatchingRegistryService(namespace, fqdn.String(), n.RegistryServices) {
+				path := fmt.Sprintf("spec/rules[%d]/backendRefs[%d]/name", k, i)
+				validation := models.Build("k8sroutes.nohost.namenotfound", path)
+				validations = append(validations, &validation)
+				valid = false
+			}
+		}
+	}
+
+	return validations, valid
+}
@@ -0,0 +1,39 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestRouteBackendRefHost(t *testing.T) {
+	c := config.Get()
+	c.ExternalServices.Istio.IstioIdentityDomain = "svc.cluster.local"
+	config.Set(c)
+
+	assert := assert.New(t)
+	require := require.New(t)
+
+	registryServices := data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*")
+
+	vals, valid := NoHostChecker{
+		RegistryServices: registryServices,
+		Route:            TCPRoute(data.AddBackendRefToTCPRoute("reviews", "", data.CreateTCPRoute("route", "bookinfo", "gateway"))),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	vals, valid = NoHostChecker{
+		RegistryServices: registryServices,
+		Route:            TCPRoute(data.AddBackendRefToTCPRoute("ratings", "bookinfo", data.AddBackendRefToTCPRoute("reviews", "bookinfo", data.CreateTCPRoute("route", "bookinfo", "gateway")))),
+	}.Check()
+	assert.False(valid)
+	require.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", vals[0]))
+	assert.Equal("spec/rules[1]/backendRefs[0]/name", vals[0].Path)
+}
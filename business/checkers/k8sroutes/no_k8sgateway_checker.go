@@ -0,0 +1,165 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// protocolRouteKinds are the route kinds supported by the listener protocols, when the listener doesn't set its
+// allowed kinds
+var protocolRouteKinds = map[k8s_networking_v1beta1.ProtocolType][]string{
+	k8s_networking_v1beta1.HTTPProtocolType:  {kubernetes.K8sActualHTTPRouteType, kubernetes.K8sActualGRPCRouteType},
+	k8s_networking_v1beta1.HTTPSProtocolType: {kubernetes.K8sActualHTTPRouteType, kubernetes.K8sActualGRPCRouteType},
+	k8s_networking_v1beta1.TLSProtocolType:   {kubernetes.K8sActualTLSRouteType, kubernetes.K8sActualTCPRouteType},
+	k8s_networking_v1beta1.TCPProtocolType:   {kubernetes.K8sActualTCPRouteType},
+}
+
+type NoK8sGatewayChecker struct {
+	K8sGateways []*k8s_networking_v1beta1.Gateway
+	Namespaces  models.Namespaces
+	Route       Route
+}
+
+// Check validates that the route is pointing to existing Gateways, and that a listener of the Gateway matching the
+// parentRef accepts the route
+func (s NoK8sGatewayChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	for index, parentRef := range s.Route.ParentRefs {
+		if !isGateway(parentRef) {
+			continue
+		}
+		namespace := s.Route.Namespace
+		if parentRef.Namespace != nil && *parentRef.Namespace != "" {
+			namespace = string(*parentRef.Namespace)
+		}
+
+		gw := s.findGateway(string(parentRef.Name), namespace)
+		if gw == nil {
+			validation := models.Build("k8sroutes.nok8sgateway", fmt.Sprintf("spec/parentRefs[%d]/name", index))
+			validations = append(validations, &validation)
+			valid = false
+			continue
+		}
+
+		listeners := matchingListeners(gw, parentRef)
+		if len(listeners) == 0 {
+			path := fmt.Sprintf("spec/parentRefs[%d]/name", index)
+			if parentRef.SectionName != nil {
+				path = fmt.Sprintf("spec/parentRefs[%d]/sectionName", index)
+			} else if parentRef.Port != nil {
+				path = fmt.Sprintf("spec/parentRefs[%d]/port", index)
+			}
+			validation := models.Build("k8sroutes.nok8sgateway.listener", path)
+			validations = append(validations, &validation)
+			valid = false
+			continue
+		}
+
+		if !s.isAllowed(gw, listeners) {
+			validation := models.Build("k8sroutes.nok8sgateway.notallowed", fmt.Sprintf("spec/parentRefs[%d]/name", index))
+			validations = append(validations, &validation)
+			valid = false
+		}
+	}
+
+	return validations, valid
+}
+
+func (s NoK8sGatewayChecker) findGateway(name, namespace string) *k8s_networking_v1beta1.Gateway {
+	for _, gw := range s.K8sGateways {
+		if gw.Name == name && gw.Namespace == namespace {
+			return gw
+		}
+	}
+	return nil
+}
+
+// isAllowed returns true when any of the listeners accepts the kind and the namespace of the route
+func (s NoK8sGatewayChecker) isAllowed(gw *k8s_networking_v1beta1.Gateway, listeners []k8s_networking_v1beta1.Listener) bool {
+	for _, listener := range listeners {
+		if s.isKindAllowed(listener) && s.isNamespaceAllowed(gw, listener) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s NoK8sGatewayChecker) isKindAllowed(listener k8s_networking_v1beta1.Listener) bool {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		for _, kind := range protocolRouteKinds[listener.Protocol] {
+			if kind == s.Route.Kind {
+				return true
+			}
+		}
+		return false
+	}
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if (kind.Group == nil || string(*kind.Group) == kubernetes.K8sNetworkingGroupVersionV1Beta1.Group) && string(kind.Kind) == s.Route.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (s NoK8sGatewayChecker) isNamespaceAllowed(gw *k8s_networking_v1beta1.Gateway, listener k8s_networking_v1beta1.Listener) bool {
+	from := k8s_networking_v1beta1.NamespacesFromSame
+	var selector *meta_v1.LabelSelector
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil {
+		if listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		selector = listener.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case k8s_networking_v1beta1.NamespacesFromAll:
+		return true
+	case k8s_networking_v1beta1.NamespacesFromSelector:
+		if selector == nil {
+			return false
+		}
+		nsSelector, err := meta_v1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return false
+		}
+		for _, ns := range s.Namespaces {
+			if ns.Name == s.Route.Namespace {
+				return nsSelector.Matches(labels.Set(ns.Labels))
+			}
+		}
+		return false
+	default:
+		return gw.Namespace == s.Route.Namespace
+	}
+}
+
+// matchingListeners returns the listeners of the Gateway selected by the sectionName and the port of the parentRef
+func matchingListeners(gw *k8s_networking_v1beta1.Gateway, parentRef k8s_networking_v1alpha2.ParentReference) []k8s_networking_v1beta1.Listener {
+	listeners := []k8s_networking_v1beta1.Listener{}
+	for _, listener := range gw.Spec.Listeners {
+		if parentRef.SectionName != nil && string(*parentRef.SectionName) != string(listener.Name) {
+			continue
+		}
+		if parentRef.Port != nil && int32(*parentRef.Port) != int32(listener.Port) {
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// isGateway returns true when the parentRef points to a Gateway, the default kind of the parentRefs
+func isGateway(parentRef k8s_networking_v1alpha2.ParentReference) bool {
+	return (parentRef.Group == nil || string(*parentRef.Group) == kubernetes.K8sNetworkingGroupVersionV1Alpha2.Group) &&
+		(parentRef.Kind == nil || string(*parentRef.Kind) == kubernetes.K8sActualGatewayType) &&
+		parentRef.Name != ""
+}
@@ -0,0 +1,117 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func gatewayWithListeners(listeners ...k8s_networking_v1beta1.Listener) *k8s_networking_v1beta1.Gateway {
+	gw := data.CreateEmptyK8sGateway("gateway", "bookinfo")
+	for _, l := range listeners {
+		data.AddListenerToK8sGateway(l, gw)
+	}
+	return gw
+}
+
+func TestRouteWithoutK8sGateway(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	vals, valid := NoK8sGatewayChecker{
+		K8sGateways: []*k8s_networking_v1beta1.Gateway{gatewayWithListeners(data.CreateListener("grpc", "", 80, "HTTP"))},
+		Route:       GRPCRoute(data.CreateGRPCRoute("route", "bookinfo", "other")),
+	}.Check()
+
+	assert.False(valid)
+	require.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", vals[0]))
+	assert.Equal("spec/parentRefs[0]/name", vals[0].Path)
+}
+
+func TestRouteListenerProtocol(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	gateways := []*k8s_networking_v1beta1.Gateway{gatewayWithListeners(data.CreateListener("http", "", 80, "HTTP"), data.CreateListener("tls", "", 443, "TLS"))}
+
+	vals, valid := NoK8sGatewayChecker{K8sGateways: gateways, Route: GRPCRoute(data.CreateGRPCRoute("route", "bookinfo", "gateway"))}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	vals, valid = NoK8sGatewayChecker{K8sGateways: gateways, Route: TLSRoute(data.CreateTLSRoute("route", "bookinfo", "gateway"))}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	// TCP routes are only accepted by TCP and TLS listeners
+	route := data.CreateTCPRoute("route", "bookinfo", "gateway")
+	sectionName := k8s_networking_v1alpha2.SectionName("http")
+	route.Spec.ParentRefs[0].SectionName = &sectionName
+	vals, valid = NoK8sGatewayChecker{K8sGateways: gateways, Route: TCPRoute(route)}.Check()
+	assert.False(valid)
+	require.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway.notallowed", vals[0]))
+}
+
+func TestRouteListenerNotFound(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	gateways := []*k8s_networking_v1beta1.Gateway{gatewayWithListeners(data.CreateListener("tcp", "", 9000, "TCP"))}
+
+	route := data.CreateTCPRoute("route", "bookinfo", "gateway")
+	port := k8s_networking_v1alpha2.PortNumber(9001)
+	route.Spec.ParentRefs[0].Port = &port
+	vals, valid := NoK8sGatewayChecker{K8sGateways: gateways, Route: TCPRoute(route)}.Check()
+	assert.False(valid)
+	require.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway.listener", vals[0]))
+	assert.Equal("spec/parentRefs[0]/port", vals[0].Path)
+
+	port = k8s_networking_v1alpha2.PortNumber(9000)
+	vals, valid = NoK8sGatewayChecker{K8sGateways: gateways, Route: TCPRoute(route)}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestRouteListenerAllowedRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	all := k8s_networking_v1beta1.NamespacesFromAll
+	selector := k8s_networking_v1beta1.NamespacesFromSelector
+	listener := data.CreateListener("tcp", "", 9000, "TCP")
+	listener.AllowedRoutes = &k8s_networking_v1beta1.AllowedRoutes{Namespaces: &k8s_networking_v1beta1.RouteNamespaces{From: &all}}
+	gateways := []*k8s_networking_v1beta1.Gateway{gatewayWithListeners(listener)}
+
+	// Routes from other namespaces are only allowed by the listeners that select them
+	route := data.CreateTCPRoute("route", "bookinfo2", "gateway")
+	route.Spec.ParentRefs[0] = data.CreateParentRef("gateway", "bookinfo")
+	_, valid := NoK8sGatewayChecker{K8sGateways: gateways, Route: TCPRoute(route)}.Check()
+	assert.True(valid)
+
+	listener.AllowedRoutes.Namespaces = &k8s_networking_v1beta1.RouteNamespaces{From: &selector, Selector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"gateway": "allowed"}}}
+	gateways = []*k8s_networking_v1beta1.Gateway{gatewayWithListeners(listener)}
+	namespaces := models.Namespaces{{Name: "bookinfo2", Labels: map[string]string{"gateway": "allowed"}}}
+	_, valid = NoK8sGatewayChecker{K8sGateways: gateways, Namespaces: namespaces, Route: TCPRoute(route)}.Check()
+	assert.True(valid)
+
+	namespaces = models.Namespaces{{Name: "bookinfo2"}}
+	_, valid = NoK8sGatewayChecker{K8sGateways: gateways, Namespaces: namespaces, Route: TCPRoute(route)}.Check()
+	assert.False(valid)
+
+	// The kinds set in the listener take precedence over its protocol
+	listener = data.CreateListener("tcp", "", 9000, "TCP")
+	listener.AllowedRoutes = &k8s_networking_v1beta1.AllowedRoutes{Kinds: []k8s_networking_v1beta1.RouteGroupKind{{Kind: "TLSRoute"}}}
+	gateways = []*k8s_networking_v1beta1.Gateway{gatewayWithListeners(listener)}
+	_, valid = NoK8sGatewayChecker{K8sGateways: gateways, Route: TCPRoute(data.CreateTCPRoute("route", "bookinfo", "gateway"))}.Check()
+	assert.False(valid)
+}
@@ -0,0 +1,74 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type ReferenceGrantChecker struct {
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
+	Route              Route
+}
+
+// Check validates that the backendRefs to other namespaces are allowed by a ReferenceGrant of the backend namespace
+func (r ReferenceGrantChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	for k, rule := range r.Route.Rules {
+		for i, ref := range rule {
+			namespace := r.Route.backendNamespace(ref)
+			if namespace == r.Route.Namespace || r.isGranted(ref, namespace) {
+				continue
+			}
+			path := fmt.Sprintf("spec/rules[%d]/backendRefs[%d]/namespace", k, i)
+			validation := models.Build("k8sroutes.nok8sreferencegrant", path)
+			validations = append(validations, &validation)
+			valid = false
+		}
+	}
+
+	return validations, valid
+}
+
+func (r ReferenceGrantChecker) isGranted(ref k8s_networking_v1alpha2.BackendObjectReference, namespace string) bool {
+	group, kind := "", "Service"
+	if ref.Group != nil {
+		group = string(*ref.Group)
+	}
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+
+	for _, rg := range r.K8sReferenceGrants {
+		if rg.Namespace != namespace {
+			continue
+		}
+		if r.isGrantedFrom(rg) && isGrantedTo(rg, group, kind, string(ref.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r ReferenceGrantChecker) isGrantedFrom(rg *k8s_networking_v1alpha2.ReferenceGrant) bool {
+	for _, from := range rg.Spec.From {
+		if string(from.Group) == kubernetes.K8sNetworkingGroupVersionV1Alpha2.Group && string(from.Kind) == r.Route.Kind && string(from.Namespace) == r.Route.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func isGrantedTo(rg *k8s_networking_v1alpha2.ReferenceGrant, group, kind, name string) bool {
+	for _, to := range rg.Spec.To {
+		if string(to.Group) == group && string(to.Kind) == kind && (to.Name == nil || string(*to.Name) == name) {
+			return true
+		}
+	}
+	return false
+}
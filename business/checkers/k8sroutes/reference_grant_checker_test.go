@@ -0,0 +1,81 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestSameNamespaceBackendRef(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := ReferenceGrantChecker{
+		Route: GRPCRoute(data.AddBackendRefToGRPCRoute("reviews", "", data.AddBackendRefToGRPCRoute("ratings", "bookinfo", data.CreateGRPCRoute("route", "bookinfo", "gateway")))),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestCrossNamespaceBackendRefWithoutReferenceGrant(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	vals, valid := ReferenceGrantChecker{
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{
+			// grants in other namespaces, or for other route kinds, don't allow the reference
+			data.CreateReferenceGrant("grant", "bookinfo", "istio-system", "TCPRoute"),
+			data.CreateReferenceGrant("grant", "default", "istio-system", "GRPCRoute"),
+		},
+		Route: GRPCRoute(data.AddBackendRefToGRPCRoute("reviews", "bookinfo", data.CreateGRPCRoute("route", "istio-system", "gateway"))),
+	}.Check()
+
+	assert.False(valid)
+	require.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sreferencegrant", vals[0]))
+	assert.Equal("spec/rules[0]/backendRefs[0]/namespace", vals[0].Path)
+}
+
+func TestCrossNamespaceBackendRefWithReferenceGrant(t *testing.T) {
+	assert := assert.New(t)
+
+	grant := data.CreateReferenceGrant("grant", "bookinfo", "istio-system", "TLSRoute")
+	vals, valid := ReferenceGrantChecker{
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{grant},
+		Route:              TLSRoute(data.AddBackendRefToTLSRoute("reviews", "bookinfo", data.CreateTLSRoute("route", "istio-system", "gateway"))),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	// the grant can be restricted to a Service name
+	name := k8s_networking_v1alpha2.ObjectName("ratings")
+	grant.Spec.To[0].Name = &name
+	vals, valid = ReferenceGrantChecker{
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{grant},
+		Route:              TLSRoute(data.AddBackendRefToTLSRoute("reviews", "bookinfo", data.CreateTLSRoute("route", "istio-system", "gateway"))),
+	}.Check()
+	assert.False(valid)
+	assert.Len(vals, 1)
+}
+
+func TestHTTPRouteCrossNamespaceBackendRef(t *testing.T) {
+	assert := assert.New(t)
+
+	route := HTTPRoute(data.AddBackendRefToHTTPRoute("reviews", "bookinfo", data.CreateHTTPRoute("route", "istio-system", "gateway", []string{"bookinfo"})))
+
+	_, valid := ReferenceGrantChecker{Route: route}.Check()
+	assert.False(valid)
+
+	_, valid = ReferenceGrantChecker{
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{data.CreateReferenceGrant("grant", "bookinfo", "istio-system", "HTTPRoute")},
+		Route:              route,
+	}.Check()
+	assert.True(valid)
+}
@@ -0,0 +1,87 @@
+package k8sroutes
+
+import (
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// Route holds the fields shared by the Gateway API routes that are validated the same way for every route kind
+type Route struct {
+	// Kind is the actual kind of the route, i.e. GRPCRoute
+	Kind       string
+	Name       string
+	Namespace  string
+	ParentRefs []k8s_networking_v1alpha2.ParentReference
+	// Rules holds the backendRefs of each rule of the route
+	Rules [][]k8s_networking_v1alpha2.BackendObjectReference
+}
+
+func GRPCRoute(rt *k8s_networking_v1alpha2.GRPCRoute) Route {
+	route := Route{Kind: kubernetes.K8sActualGRPCRouteType, Name: rt.Name, Namespace: rt.Namespace, ParentRefs: rt.Spec.ParentRefs}
+	for _, rule := range rt.Spec.Rules {
+		refs := []k8s_networking_v1alpha2.BackendObjectReference{}
+		for _, ref := range rule.BackendRefs {
+			refs = append(refs, ref.BackendRefs.BackendObjectReference)
+		}
+		route.Rules = append(route.Rules, refs)
+	}
+	return route
+}
+
+func TCPRoute(rt *k8s_networking_v1alpha2.TCPRoute) Route {
+	route := Route{Kind: kubernetes.K8sActualTCPRouteType, Name: rt.Name, Namespace: rt.Namespace, ParentRefs: rt.Spec.ParentRefs}
+	for _, rule := range rt.Spec.Rules {
+		route.Rules = append(route.Rules, backendObjectReferences(rule.BackendRefs))
+	}
+	return route
+}
+
+func TLSRoute(rt *k8s_networking_v1alpha2.TLSRoute) Route {
+	route := Route{Kind: kubernetes.K8sActualTLSRouteType, Name: rt.Name, Namespace: rt.Namespace, ParentRefs: rt.Spec.ParentRefs}
+	for _, rule := range rt.Spec.Rules {
+		route.Rules = append(route.Rules, backendObjectReferences(rule.BackendRefs))
+	}
+	return route
+}
+
+// HTTPRoute converts the v1beta1 HTTPRoute, only its backendRefs are used by the ReferenceGrantChecker
+func HTTPRoute(rt *k8s_networking_v1beta1.HTTPRoute) Route {
+	route := Route{Kind: kubernetes.K8sActualHTTPRouteType, Name: rt.Name, Namespace: rt.Namespace}
+	for _, rule := range rt.Spec.Rules {
+		refs := []k8s_networking_v1alpha2.BackendObjectReference{}
+		for _, ref := range rule.BackendRefs {
+			refs = append(refs, k8s_networking_v1alpha2.BackendObjectReference{
+				Group:     (*k8s_networking_v1alpha2.Group)(ref.Group),
+				Kind:      (*k8s_networking_v1alpha2.Kind)(ref.Kind),
+				Name:      k8s_networking_v1alpha2.ObjectName(ref.Name),
+				Namespace: (*k8s_networking_v1alpha2.Namespace)(ref.Namespace),
+				Port:      (*k8s_networking_v1alpha2.PortNumber)(ref.Port),
+			})
+		}
+		route.Rules = append(route.Rules, refs)
+	}
+	return route
+}
+
+func backendObjectReferences(backendRefs []k8s_networking_v1alpha2.BackendRef) []k8s_networking_v1alpha2.BackendObjectReference {
+	refs := []k8s_networking_v1alpha2.BackendObjectReference{}
+	for _, ref := range backendRefs {
+		refs = append(refs, ref.BackendObjectReference)
+	}
+	return refs
+}
+
+// backendNamespace returns the namespace of the backend, the namespace of the route when it is not set
+func (r Route) backendNamespace(ref k8s_networking_v1alpha2.BackendObjectReference) string {
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		return string(*ref.Namespace)
+	}
+	return r.Namespace
+}
+
+// isService returns true when the backend is a Service, the default kind of the backendRefs
+func isService(ref k8s_networking_v1alpha2.BackendObjectReference) bool {
+	return (ref.Group == nil || *ref.Group == "") && (ref.Kind == nil || *ref.Kind == "Service")
+}
@@ -0,0 +1,60 @@
+package telemetries
+
+import (
+	"fmt"
+
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// defaultProviders are the extension providers Istio always adds to the mesh config
+var defaultProviders = []string{"envoy", "prometheus", "stackdriver"}
+
+type ProviderChecker struct {
+	Telemetry          *telemetry_v1alpha1.Telemetry
+	ExtensionProviders []string
+}
+
+// Check validates that the providers referenced by the Telemetry are defined in the mesh config
+func (pc ProviderChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	providers := make(map[string]bool, len(defaultProviders)+len(pc.ExtensionProviders))
+	for _, name := range defaultProviders {
+		providers[name] = true
+	}
+	for _, name := range pc.ExtensionProviders {
+		providers[name] = true
+	}
+
+	validateProviders := func(refs []*api_telemetry_v1alpha1.ProviderRef, path string) {
+		for i, ref := range refs {
+			if ref == nil || providers[ref.Name] {
+				continue
+			}
+			check := models.Build("telemetry.provider.notfound", fmt.Sprintf("%s/providers[%d]/name", path, i))
+			checks = append(checks, &check)
+			valid = false
+		}
+	}
+
+	for i, tracing := range pc.Telemetry.Spec.Tracing {
+		if tracing != nil {
+			validateProviders(tracing.Providers, fmt.Sprintf("spec/tracing[%d]", i))
+		}
+	}
+	for i, metrics := range pc.Telemetry.Spec.Metrics {
+		if metrics != nil {
+			validateProviders(metrics.Providers, fmt.Sprintf("spec/metrics[%d]", i))
+		}
+	}
+	for i, accessLogging := range pc.Telemetry.Spec.AccessLogging {
+		if accessLogging != nil {
+			validateProviders(accessLogging.Providers, fmt.Sprintf("spec/accessLogging[%d]", i))
+		}
+	}
+
+	return checks, valid
+}
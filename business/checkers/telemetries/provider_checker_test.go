@@ -0,0 +1,49 @@
+package telemetries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestTelemetryWithDefaultProviders(t *testing.T) {
+	assert := assert.New(t)
+
+	telemetry := data.CreateTelemetry("telemetry1", "bookinfo", nil)
+	telemetry = data.AddAccessLoggingProviderToTelemetry("envoy", telemetry)
+
+	vals, valid := ProviderChecker{Telemetry: telemetry}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+func TestTelemetryWithExtensionProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	telemetry := data.CreateTelemetry("telemetry1", "bookinfo", nil)
+	telemetry = data.AddTracingProviderToTelemetry("zipkin", telemetry)
+
+	vals, valid := ProviderChecker{Telemetry: telemetry, ExtensionProviders: []string{"zipkin"}}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+func TestTelemetryWithUnknownProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	telemetry := data.CreateTelemetry("telemetry1", "bookinfo", nil)
+	telemetry = data.AddTracingProviderToTelemetry("zipkin", telemetry)
+	telemetry = data.AddTracingProviderToTelemetry("jaeger", telemetry)
+
+	vals, valid := ProviderChecker{Telemetry: telemetry, ExtensionProviders: []string{"zipkin"}}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("telemetry.provider.notfound", vals[0]))
+	assert.Equal("spec/tracing[1]/providers[0]/name", vals[0].Path)
+}
@@ -3,14 +3,18 @@ package checkers
 import (
 	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/business/checkers/telemetries"
 	"github.com/kiali/kiali/models"
 )
 
 const TelemetryCheckerType = "telemetry"
 
 type TelemetryChecker struct {
-	Namespaces  models.Namespaces
-	Telemetries []*v1alpha1.Telemetry
+	Namespaces            models.Namespaces
+	Telemetries           []*v1alpha1.Telemetry
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	ExtensionProviders    []string
 }
 
 // An Object Checker runs all checkers for an specific object type (i.e.: pod, route rule,...)
@@ -20,5 +24,53 @@ type TelemetryChecker struct {
 func (in TelemetryChecker) Check() models.IstioValidations {
 	validations := models.IstioValidations{}
 
+	validations = validations.MergeValidations(in.runIndividualChecks())
+	validations = validations.MergeValidations(in.runGroupChecks())
+
+	return validations
+}
+
+func (in TelemetryChecker) runGroupChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	enabledCheckers := []GroupChecker{
+		common.TelemetryMultiMatchChecker(TelemetryCheckerType, in.Telemetries, in.WorkloadsPerNamespace),
+	}
+
+	for _, checker := range enabledCheckers {
+		validations = validations.MergeValidations(checker.Check())
+	}
+
 	return validations
 }
+
+func (in TelemetryChecker) runIndividualChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, telemetry := range in.Telemetries {
+		validations.MergeValidations(in.runChecks(telemetry))
+	}
+
+	return validations
+}
+
+func (in TelemetryChecker) runChecks(telemetry *v1alpha1.Telemetry) models.IstioValidations {
+	key, rrValidation := EmptyValidValidation(telemetry.Name, telemetry.Namespace, TelemetryCheckerType)
+	matchLabels := make(map[string]string)
+	if telemetry.Spec.Selector != nil {
+		matchLabels = telemetry.Spec.Selector.MatchLabels
+	}
+
+	enabledCheckers := []Checker{
+		common.SelectorNoWorkloadFoundChecker(TelemetryCheckerType, matchLabels, in.WorkloadsPerNamespace),
+		telemetries.ProviderChecker{Telemetry: telemetry, ExtensionProviders: in.ExtensionProviders},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
@@ -0,0 +1,235 @@
+package virtualservices
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+type RouteMatchChecker struct {
+	VirtualService *networking_v1beta1.VirtualService
+}
+
+// Check returns both an array of IstioCheck and a boolean indicating if the current route rule is valid.
+// The http routes are evaluated in order, the first matching route wins. The array of IstioChecks contains
+// the result of running the following validations:
+// 1. Match requests whose conditions contradict each other, so they never match.
+// 2. Routes that never match, because every request they match is matched by an earlier route.
+func (m RouteMatchChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	var previous []*api_networking_v1beta1.HTTPRoute
+	for routeIdx, httpRoute := range m.VirtualService.Spec.Http {
+		if httpRoute == nil {
+			continue
+		}
+
+		for matchIdx, match := range httpRoute.Match {
+			if match != nil && isContradictory(match) {
+				path := fmt.Sprintf("spec/http[%d]/match[%d]", routeIdx, matchIdx)
+				check := models.Build("virtualservices.route.contradictorymatch", path)
+				checks = append(checks, &check)
+			}
+		}
+
+		if isShadowed(httpRoute, previous) {
+			path := fmt.Sprintf("spec/http[%d]", routeIdx)
+			check := models.Build("virtualservices.route.unreachable", path)
+			checks = append(checks, &check)
+		}
+		previous = append(previous, httpRoute)
+	}
+
+	return checks, valid
+}
+
+// isShadowed returns true if every match request of the route is covered by a match request of a previous route
+func isShadowed(route *api_networking_v1beta1.HTTPRoute, previous []*api_networking_v1beta1.HTTPRoute) bool {
+	if len(route.Match) == 0 {
+		// a route without match requests matches everything, it is shadowed only by another match-all route
+		return coversAll(previous, &api_networking_v1beta1.HTTPMatchRequest{})
+	}
+	for _, match := range route.Match {
+		if match != nil && !coversAll(previous, match) {
+			return false
+		}
+	}
+	return true
+}
+
+func coversAll(routes []*api_networking_v1beta1.HTTPRoute, match *api_networking_v1beta1.HTTPMatchRequest) bool {
+	for _, route := range routes {
+		if len(route.Match) == 0 {
+			return true
+		}
+		for _, earlier := range route.Match {
+			if earlier != nil && coversMatch(earlier, match) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coversMatch returns true if every request matched by b is also matched by a. It is conservative: when in
+// doubt it returns false.
+func coversMatch(a, b *api_networking_v1beta1.HTTPMatchRequest) bool {
+	switch {
+	case isMatchAllUri(a.Uri):
+		// any uri
+	case a.IgnoreUriCase != b.IgnoreUriCase:
+		// a case insensitive uri match covers a case sensitive one, compared in lower case
+		if !a.IgnoreUriCase || !coversString(lowerCase(a.Uri), lowerCase(b.Uri)) {
+			return false
+		}
+	case !coversString(a.Uri, b.Uri):
+		return false
+	}
+
+	if !coversString(a.Scheme, b.Scheme) || !coversString(a.Method, b.Method) || !coversString(a.Authority, b.Authority) {
+		return false
+	}
+	if !coversStrings(a.Headers, b.Headers) || !coversStrings(a.QueryParams, b.QueryParams) {
+		return false
+	}
+	for name, without := range a.WithoutHeaders {
+		if other, found := b.WithoutHeaders[name]; !found || !sameString(without, other) {
+			return false
+		}
+	}
+
+	if a.Port != 0 && a.Port != b.Port {
+		return false
+	}
+	if a.SourceNamespace != "" && a.SourceNamespace != b.SourceNamespace {
+		return false
+	}
+	for name, value := range a.SourceLabels {
+		if other, found := b.SourceLabels[name]; !found || other != value {
+			return false
+		}
+	}
+	if len(a.Gateways) > 0 {
+		if len(b.Gateways) == 0 {
+			return false
+		}
+		for _, gw := range b.Gateways {
+			if !contains(a.Gateways, gw) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isContradictory returns true if the match request can't match any request
+func isContradictory(match *api_networking_v1beta1.HTTPMatchRequest) bool {
+	for name, header := range match.Headers {
+		if without, found := match.WithoutHeaders[name]; found && coversString(without, header) {
+			return true
+		}
+	}
+	return false
+}
+
+func coversStrings(a, b map[string]*api_networking_v1beta1.StringMatch) bool {
+	for name, match := range a {
+		other, found := b[name]
+		if !found || !coversString(match, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// coversString returns true if every value matched by b is also matched by a. A nil StringMatch is no
+// condition, a StringMatch without match type matches any (present) value.
+func coversString(a, b *api_networking_v1beta1.StringMatch) bool {
+	if a == nil || a.MatchType == nil {
+		return a == nil || b != nil
+	}
+	if b == nil || b.MatchType == nil {
+		return isMatchAny(a)
+	}
+
+	switch am := a.MatchType.(type) {
+	case *api_networking_v1beta1.StringMatch_Exact:
+		exact, ok := b.MatchType.(*api_networking_v1beta1.StringMatch_Exact)
+		return ok && exact.Exact == am.Exact
+	case *api_networking_v1beta1.StringMatch_Prefix:
+		switch bm := b.MatchType.(type) {
+		case *api_networking_v1beta1.StringMatch_Exact:
+			return strings.HasPrefix(bm.Exact, am.Prefix)
+		case *api_networking_v1beta1.StringMatch_Prefix:
+			return strings.HasPrefix(bm.Prefix, am.Prefix)
+		case *api_networking_v1beta1.StringMatch_Regex:
+			return am.Prefix == ""
+		}
+	case *api_networking_v1beta1.StringMatch_Regex:
+		if isMatchAny(a) {
+			return true
+		}
+		switch bm := b.MatchType.(type) {
+		case *api_networking_v1beta1.StringMatch_Exact:
+			// Istio regexes must match the full value
+			regex, err := regexp.Compile("^(?:" + am.Regex + ")$")
+			return err == nil && regex.MatchString(bm.Exact)
+		case *api_networking_v1beta1.StringMatch_Regex:
+			return bm.Regex == am.Regex
+		}
+	}
+	return false
+}
+
+func isMatchAny(m *api_networking_v1beta1.StringMatch) bool {
+	switch mt := m.MatchType.(type) {
+	case *api_networking_v1beta1.StringMatch_Prefix:
+		return mt.Prefix == ""
+	case *api_networking_v1beta1.StringMatch_Regex:
+		return mt.Regex == ".*"
+	}
+	return false
+}
+
+// isMatchAllUri returns true if the uri match matches any path, as every path starts with /
+func isMatchAllUri(m *api_networking_v1beta1.StringMatch) bool {
+	if m == nil {
+		return true
+	}
+	if prefix, ok := m.MatchType.(*api_networking_v1beta1.StringMatch_Prefix); ok && prefix.Prefix == "/" {
+		return true
+	}
+	return m.MatchType != nil && isMatchAny(m)
+}
+
+func sameString(a, b *api_networking_v1beta1.StringMatch) bool {
+	return coversString(a, b) && coversString(b, a)
+}
+
+func lowerCase(m *api_networking_v1beta1.StringMatch) *api_networking_v1beta1.StringMatch {
+	if m == nil {
+		return nil
+	}
+	switch mt := m.MatchType.(type) {
+	case *api_networking_v1beta1.StringMatch_Exact:
+		return &api_networking_v1beta1.StringMatch{MatchType: &api_networking_v1beta1.StringMatch_Exact{Exact: strings.ToLower(mt.Exact)}}
+	case *api_networking_v1beta1.StringMatch_Prefix:
+		return &api_networking_v1beta1.StringMatch{MatchType: &api_networking_v1beta1.StringMatch_Prefix{Prefix: strings.ToLower(mt.Prefix)}}
+	}
+	return m
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
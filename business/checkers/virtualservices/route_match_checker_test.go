@@ -0,0 +1,59 @@
+package virtualservices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestRouteMatchWellConfigured(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := RouteMatchChecker{VirtualService: fakeValidVirtualService()}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestShadowedRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	loader := yamlFixtureLoaderFor("route-match-shadowed.yaml")
+	err := loader.Load()
+	if err != nil {
+		t.Error("Error loading test data.")
+	}
+
+	vals, valid := RouteMatchChecker{VirtualService: loader.GetResources().VirtualServices[0]}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 4)
+	for _, val := range vals {
+		assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.unreachable", val))
+		assert.Equal(models.WarningSeverity, val.Severity)
+	}
+	assert.Equal("spec/http[1]", vals[0].Path)
+	assert.Equal("spec/http[3]", vals[1].Path)
+	assert.Equal("spec/http[5]", vals[2].Path)
+	assert.Equal("spec/http[6]", vals[3].Path)
+}
+
+func TestContradictoryMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	loader := yamlFixtureLoaderFor("route-match-contradictory.yaml")
+	err := loader.Load()
+	if err != nil {
+		t.Error("Error loading test data.")
+	}
+
+	vals, valid := RouteMatchChecker{VirtualService: loader.GetResources().VirtualServices[0]}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.contradictorymatch", vals[0]))
+	assert.Equal("spec/http[0]/match[0]", vals[0].Path)
+}
@@ -0,0 +1,31 @@
+package wasmplugins
+
+import (
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/models"
+)
+
+type ImagePullSecretChecker struct {
+	WasmPlugin *extensions_v1alpha1.WasmPlugin
+	// ImagePullSecrets tells whether a secret exists, keyed by <namespace>/<name>. Secrets not present could
+	// not be resolved, and are not validated.
+	ImagePullSecrets map[string]bool
+}
+
+// Check validates that the imagePullSecret of the WasmPlugin exists in its namespace
+func (ic ImagePullSecretChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	secret := ic.WasmPlugin.Spec.ImagePullSecret
+	if secret == "" {
+		return checks, valid
+	}
+
+	if exists, found := ic.ImagePullSecrets[ic.WasmPlugin.Namespace+"/"+secret]; found && !exists {
+		check := models.Build("wasmplugin.imagepullsecret.notfound", "spec/imagePullSecret")
+		checks = append(checks, &check)
+		valid = false
+	}
+	return checks, valid
+}
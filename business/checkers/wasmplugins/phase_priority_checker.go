@@ -0,0 +1,88 @@
+package wasmplugins
+
+import (
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+const WasmPluginCheckerType = "wasmplugin"
+
+type PhasePriorityChecker struct {
+	WasmPlugins           []*extensions_v1alpha1.WasmPlugin
+	WorkloadsPerNamespace map[string]models.WorkloadList
+}
+
+type phasePriority struct {
+	phase    int32
+	priority int64
+}
+
+// Check validates that no two WasmPlugins applied to the same workload share the same phase and priority,
+// in which case Istio does not guarantee their order in the filter chain.
+func (m PhasePriorityChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, wls := range m.WorkloadsPerNamespace {
+		for _, w := range wls.Workloads {
+			plugins := map[phasePriority][]models.IstioValidationKey{}
+			for _, wp := range m.WasmPlugins {
+				if !appliesTo(wp, wls.Namespace.Name, w.Labels) {
+					continue
+				}
+				pp := phasePriority{phase: int32(wp.Spec.Phase)}
+				if wp.Spec.Priority != nil {
+					pp.priority = wp.Spec.Priority.Value
+				}
+				plugins[pp] = append(plugins[pp], models.BuildKey(WasmPluginCheckerType, wp.Name, wp.Namespace))
+			}
+
+			for _, keys := range plugins {
+				if len(keys) > 1 {
+					validations.MergeValidations(buildConflictValidations(keys))
+				}
+			}
+		}
+	}
+
+	return validations
+}
+
+// appliesTo returns true if the WasmPlugin applies to a workload with the given labels in the given namespace
+func appliesTo(wp *extensions_v1alpha1.WasmPlugin, namespace string, workloadLabels map[string]string) bool {
+	if wp.Namespace != namespace && !config.IsRootNamespace(wp.Namespace) {
+		return false
+	}
+	if wp.Spec.Selector == nil || len(wp.Spec.Selector.MatchLabels) == 0 {
+		return true
+	}
+	return labels.SelectorFromSet(wp.Spec.Selector.MatchLabels).Matches(labels.Set(workloadLabels))
+}
+
+func buildConflictValidations(keys []models.IstioValidationKey) models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for i, key := range keys {
+		refs := make([]models.IstioValidationKey, 0, len(keys)-1)
+		for refIndex, ref := range keys {
+			if refIndex != i {
+				refs = append(refs, ref)
+			}
+		}
+
+		check := models.Build("wasmplugin.phase.priority.conflict", "spec/phase")
+		validations.MergeValidations(models.IstioValidations{
+			key: &models.IstioValidation{
+				Name:       key.Name,
+				ObjectType: key.ObjectType,
+				Valid:      true,
+				References: refs,
+				Checks:     []*models.IstioCheck{&check},
+			},
+		})
+	}
+
+	return validations
+}
@@ -0,0 +1,65 @@
+package wasmplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestPhasePriorityConflict(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals := PhasePriorityChecker{
+		WasmPlugins: []*extensions_v1alpha1.WasmPlugin{
+			data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, data.CreateWasmPlugin("plugin1", "bookinfo", "oci://filter1", map[string]string{"app": "reviews"})),
+			data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, data.CreateWasmPlugin("plugin2", conf.ExternalServices.Istio.RootNamespace, "oci://filter2", nil)),
+			data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_STATS, data.CreateWasmPlugin("plugin3", "bookinfo", "oci://filter3", nil)),
+		},
+		WorkloadsPerNamespace: data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+			data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		),
+	}.Check()
+
+	assert.Len(vals, 2)
+	validation, ok := vals[models.BuildKey(WasmPluginCheckerType, "plugin1", "bookinfo")]
+	assert.True(ok)
+	assert.True(validation.Valid)
+	assert.Len(validation.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("wasmplugin.phase.priority.conflict", validation.Checks[0]))
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(WasmPluginCheckerType, "plugin2", conf.ExternalServices.Istio.RootNamespace)}, validation.References)
+
+	_, ok = vals[models.BuildKey(WasmPluginCheckerType, "plugin2", conf.ExternalServices.Istio.RootNamespace)]
+	assert.True(ok)
+}
+
+func TestPhasePriorityNoConflict(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	plugin2 := data.CreateWasmPlugin("plugin2", "bookinfo", "oci://filter2", nil)
+	plugin2.Spec.Priority = wrapperspb.Int64(10)
+
+	vals := PhasePriorityChecker{
+		WasmPlugins: []*extensions_v1alpha1.WasmPlugin{
+			data.CreateWasmPlugin("plugin1", "bookinfo", "oci://filter1", nil),
+			plugin2,
+			// different namespace
+			data.CreateWasmPlugin("plugin3", "travels", "oci://filter3", nil),
+		},
+		WorkloadsPerNamespace: data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+			data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		),
+	}.Check()
+
+	assert.Empty(vals)
+}
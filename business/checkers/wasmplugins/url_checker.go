@@ -0,0 +1,38 @@
+package wasmplugins
+
+import (
+	"strings"
+
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// supportedSchemes are the url schemes supported by Istio, a url without scheme is assumed to be oci://
+var supportedSchemes = []string{"oci", "file", "http", "https"}
+
+type URLChecker struct {
+	WasmPlugin *extensions_v1alpha1.WasmPlugin
+}
+
+// Check validates the scheme of the WasmPlugin url
+func (uc URLChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	url := uc.WasmPlugin.Spec.Url
+	index := strings.Index(url, "://")
+	if index < 0 {
+		return checks, valid
+	}
+
+	scheme := strings.ToLower(url[:index])
+	for _, supported := range supportedSchemes {
+		if scheme == supported {
+			return checks, valid
+		}
+	}
+
+	check := models.Build("wasmplugin.url.invalidscheme", "spec/url")
+	checks = append(checks, &check)
+	return checks, false
+}
@@ -0,0 +1,53 @@
+package wasmplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestValidURLSchemes(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, url := range []string{"oci://ghcr.io/istio/filter:1.0", "file:///opt/filter.wasm", "https://example.com/filter.wasm", "ghcr.io/istio/filter:1.0"} {
+		vals, valid := URLChecker{WasmPlugin: data.CreateWasmPlugin("plugin", "bookinfo", url, nil)}.Check()
+
+		assert.Empty(vals, url)
+		assert.True(valid, url)
+	}
+}
+
+func TestInvalidURLScheme(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := URLChecker{WasmPlugin: data.CreateWasmPlugin("plugin", "bookinfo", "ftp://example.com/filter.wasm", nil)}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("wasmplugin.url.invalidscheme", vals[0]))
+	assert.Equal("spec/url", vals[0].Path)
+}
+
+func TestImagePullSecret(t *testing.T) {
+	assert := assert.New(t)
+
+	wasmPlugin := data.CreateWasmPlugin("plugin", "bookinfo", "oci://ghcr.io/istio/filter:1.0", nil)
+	wasmPlugin.Spec.ImagePullSecret = "registry"
+
+	// unresolved secrets are not validated
+	vals, valid := ImagePullSecretChecker{WasmPlugin: wasmPlugin, ImagePullSecrets: map[string]bool{}}.Check()
+	assert.Empty(vals)
+	assert.True(valid)
+
+	vals, valid = ImagePullSecretChecker{WasmPlugin: wasmPlugin, ImagePullSecrets: map[string]bool{"bookinfo/registry": true}}.Check()
+	assert.Empty(vals)
+	assert.True(valid)
+
+	vals, valid = ImagePullSecretChecker{WasmPlugin: wasmPlugin, ImagePullSecrets: map[string]bool{"bookinfo/registry": false}}.Check()
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("wasmplugin.imagepullsecret.notfound", vals[0]))
+}
@@ -3,12 +3,15 @@ package business
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus"
@@ -23,14 +26,19 @@ type HealthService struct {
 
 type NamespaceHealthCriteria struct {
 	IncludeMetrics bool
+	// IncludeLatency fetches the response times checked by the latency tolerances, they are also fetched with IncludeMetrics
+	IncludeLatency bool
 	Namespace      string
 	Cluster        string
 	QueryTime      time.Time
 	RateInterval   string
 }
 
+// latencyMetric is the histogram of the response times checked by the latency tolerances
+const latencyMetric = "istio_request_duration_milliseconds"
+
 // Annotation Filter for Health
-var HealthAnnotation = []models.AnnotationKey{models.RateHealthAnnotation}
+var HealthAnnotation = []models.AnnotationKey{models.LatencyHealthAnnotation, models.RateHealthAnnotation}
 
 // GetServiceHealth returns a service health (service request error rate)
 func (in *HealthService) GetServiceHealth(ctx context.Context, namespace, cluster, service, rateInterval string, queryTime time.Time, svc *models.Service) (models.ServiceHealth, error) {
@@ -45,7 +53,12 @@ func (in *HealthService) GetServiceHealth(ctx context.Context, namespace, cluste
 	defer end()
 
 	rqHealth, err := in.getServiceRequestsHealth(namespace, cluster, service, rateInterval, queryTime, svc)
-	return models.ServiceHealth{Requests: rqHealth}, err
+	health := models.ServiceHealth{Requests: rqHealth}
+	health.EvaluateStatus(namespace, service)
+	if err == nil && svc.Type != "External" {
+		in.mergeSLOs(namespace, cluster, []models.ServiceOverview{{Name: service, HealthAnnotations: svc.HealthAnnotations}}, queryTime, models.NamespaceServiceHealth{service: &health})
+	}
+	return health, err
 }
 
 // GetAppHealth returns an app health from just Namespace and app name (thus, it fetches data from K8S and Prometheus)
@@ -86,6 +99,7 @@ func (in *HealthService) getAppHealth(namespace, cluster, app, rateInterval stri
 
 	// Deployment status
 	health.WorkloadStatuses = ws.CastWorkloadStatuses()
+	health.EvaluateStatus(namespace, app)
 
 	return health, errRate
 }
@@ -104,18 +118,22 @@ func (in *HealthService) GetWorkloadHealth(ctx context.Context, namespace, clust
 
 	// Perf: do not bother fetching request rate if workload has no sidecar
 	if !w.IstioSidecar {
-		return models.WorkloadHealth{
+		health := models.WorkloadHealth{
 			WorkloadStatus: w.CastWorkloadStatus(),
 			Requests:       models.NewEmptyRequestHealth(),
-		}, nil
+		}
+		health.EvaluateStatus(namespace, workload)
+		return health, nil
 	}
 
 	// Add Telemetry info
 	rate, err := in.getWorkloadRequestsHealth(namespace, cluster, workload, rateInterval, queryTime, w)
-	return models.WorkloadHealth{
+	health := models.WorkloadHealth{
 		WorkloadStatus: w.CastWorkloadStatus(),
 		Requests:       rate,
-	}, err
+	}
+	health.EvaluateStatus(namespace, workload)
+	return health, err
 }
 
 // GetNamespaceAppHealth returns a health for all apps in given Namespace (thus, it fetches data from K8S and Prometheus)
@@ -182,6 +200,36 @@ func (in *HealthService) getNamespaceAppHealth(appEntities namespaceApps, criter
 		fillAppRequestRates(allHealth, rates)
 	}
 
+	if criteria.IncludeMetrics || criteria.IncludeLatency {
+		annotations := make(map[string]map[string]string, len(allHealth))
+		for app, health := range allHealth {
+			annotations[app] = health.Requests.HealthAnnotations
+		}
+		if quantiles := latencyQuantiles(namespace, models.HealthKindApp, annotations); len(quantiles) > 0 {
+			lblIn := fmt.Sprintf(`{reporter="destination",destination_workload_namespace="%s",destination_cluster="%s"}`, namespace, cluster)
+			err := in.fetchLatencies(criteria, lblIn, "destination_canonical_service", quantiles, func(app, quantile string, sample *model.Sample) {
+				if health, ok := allHealth[app]; ok {
+					health.Requests.AddInboundLatency(quantile, sample)
+				}
+			})
+			if err != nil {
+				return allHealth, errors.NewServiceUnavailable(err.Error())
+			}
+			lblOut := fmt.Sprintf(`{reporter="source",source_workload_namespace="%s",source_cluster="%s"}`, namespace, cluster)
+			err = in.fetchLatencies(criteria, lblOut, "source_canonical_service", quantiles, func(app, quantile string, sample *model.Sample) {
+				if health, ok := allHealth[app]; ok {
+					health.Requests.AddOutboundLatency(quantile, sample)
+				}
+			})
+			if err != nil {
+				return allHealth, errors.NewServiceUnavailable(err.Error())
+			}
+		}
+	}
+
+	for app, health := range allHealth {
+		health.EvaluateStatus(namespace, app)
+	}
 	return allHealth, nil
 }
 
@@ -221,10 +269,10 @@ func (in *HealthService) GetNamespaceServiceHealth(ctx context.Context, criteria
 	if err != nil {
 		return nil, err
 	}
-	return in.getNamespaceServiceHealth(services, criteria), nil
+	return in.getNamespaceServiceHealth(services, criteria)
 }
 
-func (in *HealthService) getNamespaceServiceHealth(services *models.ServiceList, criteria NamespaceHealthCriteria) models.NamespaceServiceHealth {
+func (in *HealthService) getNamespaceServiceHealth(services *models.ServiceList, criteria NamespaceHealthCriteria) (models.NamespaceServiceHealth, error) {
 	namespace := criteria.Namespace
 	queryTime := criteria.QueryTime
 	rateInterval := criteria.RateInterval
@@ -256,7 +304,45 @@ func (in *HealthService) getNamespaceServiceHealth(services *models.ServiceList,
 			health.Requests.CombineReporters()
 		}
 	}
-	return allHealth
+	if criteria.IncludeMetrics || criteria.IncludeLatency {
+		annotations := make(map[string]map[string]string, len(allHealth))
+		for service, health := range allHealth {
+			annotations[service] = health.Requests.HealthAnnotations
+		}
+		if quantiles := latencyQuantiles(namespace, models.HealthKindService, annotations); len(quantiles) > 0 {
+			lbl := fmt.Sprintf(`{reporter="destination",destination_service_namespace="%s",destination_cluster="%s"}`, namespace, cluster)
+			err := in.fetchLatencies(criteria, lbl, "destination_service_name", quantiles, func(service, quantile string, sample *model.Sample) {
+				if health, ok := allHealth[service]; ok {
+					health.Requests.AddInboundLatency(quantile, sample)
+				}
+			})
+			if err != nil {
+				return allHealth, errors.NewServiceUnavailable(err.Error())
+			}
+		}
+	}
+	for service, health := range allHealth {
+		health.EvaluateStatus(namespace, service)
+	}
+	if criteria.IncludeMetrics && services != nil {
+		in.mergeSLOs(namespace, cluster, services.Services, queryTime, allHealth)
+	}
+	return allHealth, nil
+}
+
+// mergeSLOs adds the exhausted error budgets of the SLOs of the services to their health status. The errors are only
+// logged, the health is returned without the SLOs.
+func (in *HealthService) mergeSLOs(namespace, cluster string, services []models.ServiceOverview, queryTime time.Time, allHealth models.NamespaceServiceHealth) {
+	slos, err := in.businessLayer.SLO.getSLOs(namespace, cluster, services, queryTime)
+	if err != nil {
+		log.Errorf("Error computing the SLOs of the services of namespace [%s]: %s", namespace, err)
+		return
+	}
+	for service, statuses := range slos {
+		if health, ok := allHealth[service]; ok {
+			health.MergeSLOs(statuses)
+		}
+	}
 }
 
 // GetNamespaceWorkloadHealth returns a health for all workloads in given Namespace (thus, it fetches data from K8S and Prometheus)
@@ -319,9 +405,66 @@ func (in *HealthService) getNamespaceWorkloadHealth(ws models.Workloads, criteri
 		fillWorkloadRequestRates(allHealth, rates)
 	}
 
+	if criteria.IncludeMetrics || criteria.IncludeLatency {
+		annotations := make(map[string]map[string]string, len(allHealth))
+		for workload, health := range allHealth {
+			annotations[workload] = health.Requests.HealthAnnotations
+		}
+		if quantiles := latencyQuantiles(namespace, models.HealthKindWorkload, annotations); len(quantiles) > 0 {
+			lblIn := fmt.Sprintf(`{reporter="destination",destination_workload_namespace="%s",destination_cluster="%s"}`, namespace, cluster)
+			err := in.fetchLatencies(criteria, lblIn, "destination_workload", quantiles, func(workload, quantile string, sample *model.Sample) {
+				if health, ok := allHealth[workload]; ok {
+					health.Requests.AddInboundLatency(quantile, sample)
+				}
+			})
+			if err != nil {
+				return allHealth, errors.NewServiceUnavailable(err.Error())
+			}
+			lblOut := fmt.Sprintf(`{reporter="source",source_workload_namespace="%s",source_cluster="%s"}`, namespace, cluster)
+			err = in.fetchLatencies(criteria, lblOut, "source_workload", quantiles, func(workload, quantile string, sample *model.Sample) {
+				if health, ok := allHealth[workload]; ok {
+					health.Requests.AddOutboundLatency(quantile, sample)
+				}
+			})
+			if err != nil {
+				return allHealth, errors.NewServiceUnavailable(err.Error())
+			}
+		}
+	}
+
+	for workload, health := range allHealth {
+		health.EvaluateStatus(namespace, workload)
+	}
 	return allHealth, nil
 }
 
+// latencyQuantiles returns the quantiles of the latency tolerances of the items of the namespace, keyed by name with
+// their health annotations. Without quantiles the latencies are not fetched.
+func latencyQuantiles(namespace, kind string, annotations map[string]map[string]string) []string {
+	tolerances := []config.LatencyTolerance{}
+	for name, itemAnnotations := range annotations {
+		tolerances = append(tolerances, models.GetLatencyTolerances(namespace, name, kind, itemAnnotations)...)
+	}
+	quantiles := models.LatencyQuantiles(tolerances)
+	sort.Strings(quantiles)
+	return quantiles
+}
+
+// fetchLatencies fetches the response times of the quantiles for the requests matching the labels, grouped by the
+// name label and the request protocol, and adds every sample with the value of its name label.
+func (in *HealthService) fetchLatencies(criteria NamespaceHealthCriteria, labels, nameLabel string, quantiles []string, add func(name, quantile string, sample *model.Sample)) error {
+	latencies, err := in.prom.FetchHistogramValues(latencyMetric, labels, nameLabel+",request_protocol", criteria.RateInterval, false, quantiles, criteria.QueryTime)
+	if err != nil {
+		return err
+	}
+	for quantile, vector := range latencies {
+		for _, sample := range vector {
+			add(string(sample.Metric[model.LabelName(nameLabel)]), quantile, sample)
+		}
+	}
+	return nil
+}
+
 // fillAppRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
 func fillAppRequestRates(allHealth models.NamespaceAppHealth, rates model.Vector) {
 	lblDest := model.LabelName("destination_canonical_service")
@@ -0,0 +1,350 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// HealthHistoryCriteria selects the namespace and the time range of a health history. The health is evaluated at
+// every step of the range, with the request rates over the rate interval ending at the step.
+type HealthHistoryCriteria struct {
+	Namespace    string
+	Cluster      string
+	RateInterval string
+	Range        prom_v1.Range
+}
+
+// requestsMetric is the counter of the requests checked by the rate tolerances
+const requestsMetric = "istio_requests_total"
+
+// maxHealthHistorySteps is the maximum number of steps of a health history, the limit of points per series of the
+// Prometheus range queries
+const maxHealthHistorySteps = 11000
+
+// GetNamespaceAppHealthHistory returns the health history of all the apps of the namespace. It is computed from the
+// request rates and latencies only, the replicas of the workloads have no history.
+func (in *HealthService) GetNamespaceAppHealthHistory(ctx context.Context, criteria HealthHistoryCriteria) (models.NamespaceHealthHistory, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceAppHealthHistory",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", criteria.Namespace),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("rateInterval", criteria.RateInterval),
+		observability.Attribute("start", criteria.Range.Start),
+		observability.Attribute("end", criteria.Range.End),
+	)
+	defer end()
+
+	if _, ok := in.userClients[criteria.Cluster]; !ok {
+		return nil, fmt.Errorf("Cluster [%s] is not found or is not accessible for Kiali", criteria.Cluster)
+	}
+
+	appEntities, err := in.businessLayer.App.fetchNamespaceApps(ctx, criteria.Namespace, criteria.Cluster, "")
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := criteria.Namespace
+	cluster := criteria.Cluster
+	annotations := make(map[string]map[string]string, len(appEntities))
+	for app := range appEntities {
+		if app != "" {
+			annotations[app] = map[string]string{}
+		}
+	}
+
+	rates, err := in.fetchRatesRange(criteria, "reporter,source_canonical_service,destination_canonical_service,request_protocol,response_code,grpc_response_status",
+		fmt.Sprintf(`{destination_service_namespace="%s",source_workload_namespace!="%s",destination_cluster="%s"}`, namespace, namespace, cluster),
+		fmt.Sprintf(`{source_workload_namespace="%s",source_cluster="%s"}`, namespace, cluster))
+	if err != nil {
+		return nil, err
+	}
+	var inLatencies, outLatencies map[int]map[string]model.Vector
+	if quantiles := latencyQuantiles(namespace, models.HealthKindApp, annotations); len(quantiles) > 0 {
+		lblIn := fmt.Sprintf(`{reporter="destination",destination_workload_namespace="%s",destination_cluster="%s"}`, namespace, cluster)
+		if inLatencies, err = in.fetchLatenciesRange(criteria, lblIn, "destination_canonical_service", quantiles); err != nil {
+			return nil, err
+		}
+		lblOut := fmt.Sprintf(`{reporter="source",source_workload_namespace="%s",source_cluster="%s"}`, namespace, cluster)
+		if outLatencies, err = in.fetchLatenciesRange(criteria, lblOut, "source_canonical_service", quantiles); err != nil {
+			return nil, err
+		}
+	}
+
+	history := make(models.NamespaceHealthHistory, len(annotations))
+	for step, t := range historySteps(criteria.Range) {
+		allHealth := make(models.NamespaceAppHealth, len(annotations))
+		for app := range annotations {
+			h := models.EmptyAppHealth()
+			allHealth[app] = &h
+		}
+		fillAppRequestRates(allHealth, rates[step])
+		addLatencies(inLatencies[step], "destination_canonical_service", func(app, quantile string, sample *model.Sample) {
+			if health, ok := allHealth[app]; ok {
+				health.Requests.AddInboundLatency(quantile, sample)
+			}
+		})
+		addLatencies(outLatencies[step], "source_canonical_service", func(app, quantile string, sample *model.Sample) {
+			if health, ok := allHealth[app]; ok {
+				health.Requests.AddOutboundLatency(quantile, sample)
+			}
+		})
+		for app, health := range allHealth {
+			health.EvaluateStatus(namespace, app)
+			history[app] = history[app].Add(t, health.Status)
+		}
+	}
+	return history, nil
+}
+
+// GetNamespaceServiceHealthHistory returns the health history of all the services of the namespace
+func (in *HealthService) GetNamespaceServiceHealthHistory(ctx context.Context, criteria HealthHistoryCriteria) (models.NamespaceHealthHistory, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceServiceHealthHistory",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", criteria.Namespace),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("rateInterval", criteria.RateInterval),
+		observability.Attribute("start", criteria.Range.Start),
+		observability.Attribute("end", criteria.Range.End),
+	)
+	defer end()
+
+	namespace := criteria.Namespace
+	cluster := criteria.Cluster
+
+	if _, ok := in.userClients[cluster]; !ok {
+		return nil, fmt.Errorf("Cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+
+	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	svcCriteria := ServiceCriteria{
+		Namespace:              namespace,
+		IncludeHealth:          false,
+		IncludeIstioResources:  false,
+		IncludeOnlyDefinitions: true,
+	}
+	services, err := in.businessLayer.Svc.GetServiceListForCluster(ctx, svcCriteria, cluster)
+	if err != nil {
+		return nil, err
+	}
+	annotations := make(map[string]map[string]string, len(services.Services))
+	for _, service := range services.Services {
+		annotations[service.Name] = service.HealthAnnotations
+	}
+
+	rates, err := in.fetchRatesRange(criteria, "reporter,destination_service_name,request_protocol,response_code,grpc_response_status",
+		fmt.Sprintf(`{destination_service_namespace="%s",destination_cluster="%s"}`, namespace, cluster))
+	if err != nil {
+		return nil, err
+	}
+	var latencies map[int]map[string]model.Vector
+	if quantiles := latencyQuantiles(namespace, models.HealthKindService, annotations); len(quantiles) > 0 {
+		lbl := fmt.Sprintf(`{reporter="destination",destination_service_namespace="%s",destination_cluster="%s"}`, namespace, cluster)
+		if latencies, err = in.fetchLatenciesRange(criteria, lbl, "destination_service_name", quantiles); err != nil {
+			return nil, err
+		}
+	}
+
+	lblDestSvc := model.LabelName("destination_service_name")
+	history := make(models.NamespaceHealthHistory, len(annotations))
+	for step, t := range historySteps(criteria.Range) {
+		allHealth := make(models.NamespaceServiceHealth, len(annotations))
+		for service, serviceAnnotations := range annotations {
+			h := models.EmptyServiceHealth()
+			h.Requests.HealthAnnotations = serviceAnnotations
+			allHealth[service] = &h
+		}
+		for _, sample := range rates[step] {
+			if health, ok := allHealth[string(sample.Metric[lblDestSvc])]; ok {
+				health.Requests.AggregateInbound(sample)
+			}
+		}
+		addLatencies(latencies[step], "destination_service_name", func(service, quantile string, sample *model.Sample) {
+			if health, ok := allHealth[service]; ok {
+				health.Requests.AddInboundLatency(quantile, sample)
+			}
+		})
+		for service, health := range allHealth {
+			health.Requests.CombineReporters()
+			health.EvaluateStatus(namespace, service)
+			history[service] = history[service].Add(t, health.Status)
+		}
+	}
+	return history, nil
+}
+
+// GetNamespaceWorkloadHealthHistory returns the health history of all the workloads of the namespace. It is computed
+// from the request rates and latencies only, the replicas of the workloads have no history.
+func (in *HealthService) GetNamespaceWorkloadHealthHistory(ctx context.Context, criteria HealthHistoryCriteria) (models.NamespaceHealthHistory, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceWorkloadHealthHistory",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", criteria.Namespace),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("rateInterval", criteria.RateInterval),
+		observability.Attribute("start", criteria.Range.Start),
+		observability.Attribute("end", criteria.Range.End),
+	)
+	defer end()
+
+	namespace := criteria.Namespace
+	cluster := criteria.Cluster
+
+	if _, ok := in.userClients[cluster]; !ok {
+		return nil, fmt.Errorf("Cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+
+	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	ws, err := in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	annotations := make(map[string]map[string]string, len(ws))
+	for _, w := range ws {
+		annotations[w.Name] = models.GetHealthAnnotation(w.HealthAnnotations, HealthAnnotation)
+	}
+
+	rates, err := in.fetchRatesRange(criteria, "reporter,source_workload,destination_workload,request_protocol,response_code,grpc_response_status",
+		fmt.Sprintf(`{destination_service_namespace="%s",source_workload_namespace!="%s",destination_cluster="%s"}`, namespace, namespace, cluster),
+		fmt.Sprintf(`{source_workload_namespace="%s",source_cluster="%s"}`, namespace, cluster))
+	if err != nil {
+		return nil, err
+	}
+	var inLatencies, outLatencies map[int]map[string]model.Vector
+	if quantiles := latencyQuantiles(namespace, models.HealthKindWorkload, annotations); len(quantiles) > 0 {
+		lblIn := fmt.Sprintf(`{reporter="destination",destination_workload_namespace="%s",destination_cluster="%s"}`, namespace, cluster)
+		if inLatencies, err = in.fetchLatenciesRange(criteria, lblIn, "destination_workload", quantiles); err != nil {
+			return nil, err
+		}
+		lblOut := fmt.Sprintf(`{reporter="source",source_workload_namespace="%s",source_cluster="%s"}`, namespace, cluster)
+		if outLatencies, err = in.fetchLatenciesRange(criteria, lblOut, "source_workload", quantiles); err != nil {
+			return nil, err
+		}
+	}
+
+	history := make(models.NamespaceHealthHistory, len(annotations))
+	for step, t := range historySteps(criteria.Range) {
+		allHealth := make(models.NamespaceWorkloadHealth, len(annotations))
+		for workload, workloadAnnotations := range annotations {
+			allHealth[workload] = models.EmptyWorkloadHealth()
+			allHealth[workload].Requests.HealthAnnotations = workloadAnnotations
+		}
+		fillWorkloadRequestRates(allHealth, rates[step])
+		addLatencies(inLatencies[step], "destination_workload", func(workload, quantile string, sample *model.Sample) {
+			if health, ok := allHealth[workload]; ok {
+				health.Requests.AddInboundLatency(quantile, sample)
+			}
+		})
+		addLatencies(outLatencies[step], "source_workload", func(workload, quantile string, sample *model.Sample) {
+			if health, ok := allHealth[workload]; ok {
+				health.Requests.AddOutboundLatency(quantile, sample)
+			}
+		})
+		for workload, health := range allHealth {
+			health.EvaluateStatus(namespace, workload)
+			history[workload] = history[workload].Add(t, health.Status)
+		}
+	}
+	return history, nil
+}
+
+// fetchRatesRange fetches the request rates over the range with a range query per labels, and returns the samples of
+// all the queries at every step of the range
+func (in *HealthService) fetchRatesRange(criteria HealthHistoryCriteria, grouping string, labels ...string) (map[int]model.Vector, error) {
+	q := &prometheus.RangeQuery{Range: criteria.Range, RateInterval: criteria.RateInterval, RateFunc: "rate"}
+	rates := make(map[int]model.Vector)
+	for _, lbl := range labels {
+		metric := in.prom.FetchRateRange(requestsMetric, []string{lbl}, grouping, q)
+		if metric.Err != nil {
+			return nil, errors.NewServiceUnavailable(metric.Err.Error())
+		}
+		addSamplesPerStep(criteria.Range, metric.Matrix, rates)
+	}
+	return rates, nil
+}
+
+// fetchLatenciesRange fetches the response times of the quantiles over the range, grouped by the name label and the
+// request protocol, and returns the samples of every quantile at every step of the range
+func (in *HealthService) fetchLatenciesRange(criteria HealthHistoryCriteria, labels, nameLabel string, quantiles []string) (map[int]map[string]model.Vector, error) {
+	q := &prometheus.RangeQuery{Range: criteria.Range, RateInterval: criteria.RateInterval, Quantiles: quantiles}
+	histogram := in.prom.FetchHistogramRange(latencyMetric, labels, nameLabel+",request_protocol", q)
+	latencies := make(map[int]map[string]model.Vector)
+	for quantile, metric := range histogram {
+		if metric.Err != nil {
+			return nil, errors.NewServiceUnavailable(metric.Err.Error())
+		}
+		samples := make(map[int]model.Vector)
+		addSamplesPerStep(criteria.Range, metric.Matrix, samples)
+		for step, vector := range samples {
+			if _, ok := latencies[step]; !ok {
+				latencies[step] = make(map[string]model.Vector)
+			}
+			latencies[step][quantile] = vector
+		}
+	}
+	return latencies, nil
+}
+
+// addSamplesPerStep adds the values of the series of the matrix to the samples of their step, keyed by the index of
+// the step in the range. The samples are matched to the nearest step, Prometheus rounds the timestamps of the steps to
+// the millisecond.
+func addSamplesPerStep(r prom_v1.Range, matrix model.Matrix, samples map[int]model.Vector) {
+	for _, stream := range matrix {
+		for _, pair := range stream.Values {
+			step := int(math.Round(float64(pair.Timestamp.Time().Sub(r.Start)) / float64(r.Step)))
+			if step < 0 {
+				continue
+			}
+			samples[step] = append(samples[step], &model.Sample{Metric: stream.Metric, Value: pair.Value, Timestamp: pair.Timestamp})
+		}
+	}
+}
+
+// addLatencies adds every sample of the quantiles with the value of its name label
+func addLatencies(latencies map[string]model.Vector, nameLabel string, add func(name, quantile string, sample *model.Sample)) {
+	for quantile, vector := range latencies {
+		for _, sample := range vector {
+			add(string(sample.Metric[model.LabelName(nameLabel)]), quantile, sample)
+		}
+	}
+}
+
+// historySteps returns the times of the steps of the range, the ones of the samples of the range queries
+func historySteps(r prom_v1.Range) []time.Time {
+	steps := []time.Time{}
+	if r.Step <= 0 {
+		return steps
+	}
+	for t := r.Start; !t.After(r.End); t = t.Add(r.Step) {
+		steps = append(steps, t)
+	}
+	return steps
+}
+
+// ValidateHealthHistoryRange returns an error when the range is empty or has too many steps
+func ValidateHealthHistoryRange(r prom_v1.Range) error {
+	if r.Step <= 0 || !r.End.After(r.Start) {
+		return fmt.Errorf("the health history range must have a positive duration and step")
+	}
+	if int(r.End.Sub(r.Start)/r.Step) >= maxHealthHistorySteps {
+		return fmt.Errorf("the health history range exceeds the maximum of %d steps, increase the step", maxHealthHistorySteps)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package business
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func TestGetNamespaceServiceHealthHistory(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+	reviews := kubetest.FakeService("tutorial", "reviews")
+	httpbin := kubetest.FakeService("tutorial", "httpbin")
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&reviews,
+		&httpbin,
+	)
+	k8s.OpenShift = true
+	prom := new(prometheustest.PromClientMock)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	start := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) model.Time {
+		return model.TimeFromUnixNano(start.Add(time.Duration(minutes) * time.Minute).UnixNano())
+	}
+	// httpbin starts failing at the third minute
+	rates := model.Matrix{
+		&model.SampleStream{
+			Metric: model.Metric{"destination_service_name": "httpbin", "reporter": "destination", "request_protocol": "http", "response_code": "200"},
+			Values: []model.SamplePair{{Timestamp: at(0), Value: 10}, {Timestamp: at(1), Value: 10}, {Timestamp: at(2), Value: 5}, {Timestamp: at(3), Value: 5}},
+		},
+		&model.SampleStream{
+			Metric: model.Metric{"destination_service_name": "httpbin", "reporter": "destination", "request_protocol": "http", "response_code": "503"},
+			Values: []model.SamplePair{{Timestamp: at(2), Value: 5}, {Timestamp: at(3), Value: 5}},
+		},
+	}
+	prom.On("FetchRateRange", "istio_requests_total", []string{`{destination_service_namespace="tutorial",destination_cluster="` + conf.KubernetesConfig.ClusterName + `"}`},
+		"reporter,destination_service_name,request_protocol,response_code,grpc_response_status", mock.AnythingOfType("*prometheus.RangeQuery")).Return(prometheus.Metric{Matrix: rates})
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	hs := HealthService{prom: prom, businessLayer: NewWithBackends(clients, clients, prom, nil), userClients: clients}
+
+	criteria := HealthHistoryCriteria{
+		Namespace:    "tutorial",
+		Cluster:      conf.KubernetesConfig.ClusterName,
+		RateInterval: "1m",
+		Range:        prom_v1.Range{Start: start, End: start.Add(3 * time.Minute), Step: time.Minute},
+	}
+	history, err := hs.GetNamespaceServiceHealthHistory(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(history, 2)
+
+	require.Len(history["httpbin"], 2)
+	assert.Equal(models.HealthStatusHealthy, history["httpbin"][0].Status)
+	assert.Equal(start, history["httpbin"][0].Start.UTC())
+	assert.Equal(models.HealthStatusFailure, history["httpbin"][1].Status)
+	assert.Equal(start.Add(2*time.Minute), history["httpbin"][1].Start.UTC())
+	assert.Equal(start.Add(3*time.Minute), history["httpbin"][1].End.UTC())
+	assert.NotEmpty(history["httpbin"][1].Reasons)
+
+	require.Len(history["reviews"], 1)
+	assert.Equal(models.HealthStatusNA, history["reviews"][0].Status)
+	assert.Equal(start.Add(3*time.Minute), history["reviews"][0].End.UTC())
+}
+
+func TestGetNamespaceServiceHealthHistoryNotAlignedStart(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+	httpbin := kubetest.FakeService("tutorial", "httpbin")
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&httpbin,
+	)
+	k8s.OpenShift = true
+	prom := new(prometheustest.PromClientMock)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	start := time.Date(2017, 1, 15, 0, 0, 0, 123600000, time.UTC)
+	// Prometheus rounds the timestamps of the steps to the millisecond
+	at := func(minutes int) model.Time {
+		return model.Time(math.Round(float64(start.Add(time.Duration(minutes)*time.Minute).UnixNano()) / 1e6))
+	}
+	rates := model.Matrix{
+		&model.SampleStream{
+			Metric: model.Metric{"destination_service_name": "httpbin", "reporter": "destination", "request_protocol": "http", "response_code": "200"},
+			Values: []model.SamplePair{{Timestamp: at(0), Value: 10}, {Timestamp: at(1), Value: 5}},
+		},
+		&model.SampleStream{
+			Metric: model.Metric{"destination_service_name": "httpbin", "reporter": "destination", "request_protocol": "http", "response_code": "503"},
+			Values: []model.SamplePair{{Timestamp: at(1), Value: 5}},
+		},
+	}
+	prom.On("FetchRateRange", "istio_requests_total", mock.AnythingOfType("[]string"), mock.AnythingOfType("string"), mock.AnythingOfType("*prometheus.RangeQuery")).Return(prometheus.Metric{Matrix: rates})
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	hs := HealthService{prom: prom, businessLayer: NewWithBackends(clients, clients, prom, nil), userClients: clients}
+
+	criteria := HealthHistoryCriteria{
+		Namespace:    "tutorial",
+		Cluster:      conf.KubernetesConfig.ClusterName,
+		RateInterval: "1m",
+		Range:        prom_v1.Range{Start: start, End: start.Add(time.Minute), Step: time.Minute},
+	}
+	history, err := hs.GetNamespaceServiceHealthHistory(context.TODO(), criteria)
+	require.NoError(err)
+
+	require.Len(history["httpbin"], 2)
+	assert.Equal(models.HealthStatusHealthy, history["httpbin"][0].Status)
+	assert.Equal(start, history["httpbin"][0].Start.UTC())
+	assert.Equal(models.HealthStatusFailure, history["httpbin"][1].Status)
+	assert.Equal(start.Add(time.Minute), history["httpbin"][1].Start.UTC())
+}
+
+func TestValidateHealthHistoryRange(t *testing.T) {
+	assert := assert.New(t)
+
+	end := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	assert.NoError(ValidateHealthHistoryRange(prom_v1.Range{Start: end.Add(-6 * time.Hour), End: end, Step: time.Minute}))
+	assert.Error(ValidateHealthHistoryRange(prom_v1.Range{Start: end, End: end, Step: time.Minute}))
+	assert.Error(ValidateHealthHistoryRange(prom_v1.Range{Start: end.Add(-6 * time.Hour), End: end}))
+	assert.Error(ValidateHealthHistoryRange(prom_v1.Range{Start: end.Add(-30 * 24 * time.Hour), End: end, Step: time.Minute}))
+}
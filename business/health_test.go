@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -64,6 +65,13 @@ func TestGetServiceHealth(t *testing.T) {
 	}
 	assert.Equal(result, health.Requests.Inbound)
 	assert.Equal(emptyResult, health.Requests.Outbound)
+
+	// grpc errors are over the degraded threshold, http 4XX errors are under the degraded one
+	require.NotNil(t, health.Status)
+	assert.Equal(models.HealthStatusDegraded, health.Status.Status)
+	require.Len(t, health.Status.Reasons, 1)
+	assert.Equal("inbound", health.Status.Reasons[0].Direction)
+	assert.Equal("grpc", health.Status.Reasons[0].Protocol)
 }
 
 func TestGetAppHealth(t *testing.T) {
@@ -172,6 +180,18 @@ func TestGetWorkloadHealth(t *testing.T) {
 		},
 	}
 	assert.Equal(result, health.Requests.Outbound)
+
+	require.NotNil(t, health.Status)
+	assert.Equal(models.HealthStatusFailure, health.Status.Status)
+	assert.Contains(health.Status.Reasons, models.HealthReason{
+		Status:    models.HealthStatusFailure,
+		Message:   "inbound http 5XX error rate 100.00% >= 10%",
+		Code:      "5XX",
+		Direction: "inbound",
+		Protocol:  "http",
+		Threshold: 10,
+		Value:     100,
+	})
 }
 
 func TestGetAppHealthWithoutIstio(t *testing.T) {
@@ -324,6 +344,89 @@ func TestGetNamespaceServiceHealthWithNA(t *testing.T) {
 	assert.Equal(emptyResult, health["httpbin"].Requests.Outbound)
 }
 
+func TestGetNamespaceServiceHealthWithLatency(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.HealthConfig.Latency = []config.Latency{
+		{Namespace: "tutorial", Tolerance: []config.LatencyTolerance{{Quantile: "0.95", Degraded: 200, Failure: 500, Protocol: "http", Direction: "inbound"}}},
+	}
+	config.Set(conf)
+	reviews := kubetest.FakeService("tutorial", "reviews")
+	httpbin := kubetest.FakeService("tutorial", "httpbin")
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&reviews,
+		&httpbin,
+	)
+	k8s.OpenShift = true
+	prom := new(prometheustest.PromClientMock)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	latencies := map[string]model.Vector{
+		"0.95": {
+			&model.Sample{Metric: model.Metric{"destination_service_name": "httpbin", "request_protocol": "http"}, Value: 620},
+			&model.Sample{Metric: model.Metric{"destination_service_name": "reviews", "request_protocol": "http"}, Value: 120},
+		},
+	}
+	prom.On("GetNamespaceServicesRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, "1m", mock.AnythingOfType("time.Time")).Return(model.Vector{}, nil)
+	prom.On("FetchHistogramValues", "istio_request_duration_milliseconds",
+		`{reporter="destination",destination_service_namespace="tutorial",destination_cluster="`+conf.KubernetesConfig.ClusterName+`"}`,
+		"destination_service_name,request_protocol", "1m", false, []string{"0.95"}, mock.AnythingOfType("time.Time")).Return(latencies, nil)
+
+	clients := make(map[string]kubernetes.ClientInterface)
+	clients[conf.KubernetesConfig.ClusterName] = k8s
+	hs := HealthService{prom: prom, businessLayer: NewWithBackends(clients, clients, prom, nil), userClients: clients}
+
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC), IncludeMetrics: true}
+	health, err := hs.GetNamespaceServiceHealth(context.TODO(), criteria)
+
+	require.NoError(err)
+	prom.AssertNumberOfCalls(t, "FetchHistogramValues", 1)
+	require.Len(health, 2)
+
+	assert.Equal(map[string]map[string]float64{"http": {"0.95": 620}}, health["httpbin"].Requests.InboundLatency)
+	assert.Equal(models.HealthStatusFailure, health["httpbin"].Status.Status)
+	require.Len(health["httpbin"].Status.Reasons, 1)
+	assert.Equal("0.95", health["httpbin"].Status.Reasons[0].Quantile)
+	assert.Equal(float64(500), health["httpbin"].Status.Reasons[0].Threshold)
+
+	assert.Equal(models.HealthStatusHealthy, health["reviews"].Status.Status)
+	assert.Empty(health["reviews"].Status.Reasons)
+}
+
+func TestGetNamespaceServiceHealthLatencyError(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.HealthConfig.Latency = []config.Latency{
+		{Namespace: "tutorial", Tolerance: []config.LatencyTolerance{{Quantile: "0.95", Degraded: 200, Failure: 500, Protocol: "http", Direction: "inbound"}}},
+	}
+	config.Set(conf)
+	reviews := kubetest.FakeService("tutorial", "reviews")
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&reviews,
+	)
+	k8s.OpenShift = true
+	prom := new(prometheustest.PromClientMock)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	prom.On("GetNamespaceServicesRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, "1m", mock.AnythingOfType("time.Time")).Return(model.Vector{}, nil)
+	prom.On("FetchHistogramValues", "istio_request_duration_milliseconds", mock.AnythingOfType("string"), "destination_service_name,request_protocol", "1m", false, []string{"0.95"}, mock.AnythingOfType("time.Time")).
+		Return(map[string]model.Vector{}, errors.NewServiceUnavailable("prometheus is down"))
+
+	clients := make(map[string]kubernetes.ClientInterface)
+	clients[conf.KubernetesConfig.ClusterName] = k8s
+	hs := HealthService{prom: prom, businessLayer: NewWithBackends(clients, clients, prom, nil), userClients: clients}
+
+	// The health is not evaluated without the latencies, as for the apps and the workloads
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC), IncludeMetrics: true}
+	_, err := hs.GetNamespaceServiceHealth(context.TODO(), criteria)
+	assert.True(errors.IsServiceUnavailable(err))
+}
+
 func TestGetNamespaceServicesHealthMultiCluster(t *testing.T) {
 	assert := assert.New(t)
 
@@ -17,6 +17,7 @@ import (
 	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	api_types "k8s.io/apimachinery/pkg/types"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiali/kiali/config"
@@ -46,7 +47,11 @@ type IstioConfigCriteria struct {
 	Cluster                       string
 	IncludeGateways               bool
 	IncludeK8sGateways            bool
+	IncludeK8sGRPCRoutes          bool
 	IncludeK8sHTTPRoutes          bool
+	IncludeK8sReferenceGrants     bool
+	IncludeK8sTCPRoutes           bool
+	IncludeK8sTLSRoutes           bool
 	IncludeVirtualServices        bool
 	IncludeDestinationRules       bool
 	IncludeServiceEntries         bool
@@ -71,8 +76,16 @@ func (icc IstioConfigCriteria) Include(resource string) bool {
 		return icc.IncludeGateways
 	case kubernetes.K8sGateways:
 		return icc.IncludeK8sGateways
+	case kubernetes.K8sGRPCRoutes:
+		return icc.IncludeK8sGRPCRoutes
 	case kubernetes.K8sHTTPRoutes:
 		return icc.IncludeK8sHTTPRoutes
+	case kubernetes.K8sReferenceGrants:
+		return icc.IncludeK8sReferenceGrants
+	case kubernetes.K8sTCPRoutes:
+		return icc.IncludeK8sTCPRoutes
+	case kubernetes.K8sTLSRoutes:
+		return icc.IncludeK8sTLSRoutes
 	case kubernetes.VirtualServices:
 		return icc.IncludeVirtualServices && !isWorkloadSelector
 	case kubernetes.DestinationRules:
@@ -148,7 +161,11 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, criteria I
 		istioConfigList.EnvoyFilters = append(istioConfigList.EnvoyFilters, singleClusterConfigList.EnvoyFilters...)
 		istioConfigList.Gateways = append(istioConfigList.Gateways, singleClusterConfigList.Gateways...)
 		istioConfigList.K8sGateways = append(istioConfigList.K8sGateways, singleClusterConfigList.K8sGateways...)
+		istioConfigList.K8sGRPCRoutes = append(istioConfigList.K8sGRPCRoutes, singleClusterConfigList.K8sGRPCRoutes...)
 		istioConfigList.K8sHTTPRoutes = append(istioConfigList.K8sHTTPRoutes, singleClusterConfigList.K8sHTTPRoutes...)
+		istioConfigList.K8sReferenceGrants = append(istioConfigList.K8sReferenceGrants, singleClusterConfigList.K8sReferenceGrants...)
+		istioConfigList.K8sTCPRoutes = append(istioConfigList.K8sTCPRoutes, singleClusterConfigList.K8sTCPRoutes...)
+		istioConfigList.K8sTLSRoutes = append(istioConfigList.K8sTLSRoutes, singleClusterConfigList.K8sTLSRoutes...)
 		istioConfigList.VirtualServices = append(istioConfigList.VirtualServices, singleClusterConfigList.VirtualServices...)
 		istioConfigList.ServiceEntries = append(istioConfigList.ServiceEntries, singleClusterConfigList.ServiceEntries...)
 		istioConfigList.Sidecars = append(istioConfigList.Sidecars, singleClusterConfigList.Sidecars...)
@@ -190,8 +207,12 @@ func (in *IstioConfigService) GetIstioConfigListPerCluster(ctx context.Context,
 		WasmPlugins:      []*extentions_v1alpha1.WasmPlugin{},
 		Telemetries:      []*v1alpha1.Telemetry{},
 
-		K8sGateways:   []*k8s_networking_v1beta1.Gateway{},
-		K8sHTTPRoutes: []*k8s_networking_v1beta1.HTTPRoute{},
+		K8sGateways:        []*k8s_networking_v1beta1.Gateway{},
+		K8sGRPCRoutes:      []*k8s_networking_v1alpha2.GRPCRoute{},
+		K8sHTTPRoutes:      []*k8s_networking_v1beta1.HTTPRoute{},
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{},
+		K8sTCPRoutes:       []*k8s_networking_v1alpha2.TCPRoute{},
+		K8sTLSRoutes:       []*k8s_networking_v1alpha2.TLSRoute{},
 
 		AuthorizationPolicies:  []*security_v1beta1.AuthorizationPolicy{},
 		PeerAuthentications:    []*security_v1beta1.PeerAuthentication{},
@@ -233,6 +254,18 @@ func (in *IstioConfigService) GetIstioConfigListPerCluster(ctx context.Context,
 		if criteria.Include(kubernetes.K8sHTTPRoutes) {
 			istioConfigList.K8sHTTPRoutes = registryConfiguration.K8sHTTPRoutes
 		}
+		if criteria.Include(kubernetes.K8sGRPCRoutes) {
+			istioConfigList.K8sGRPCRoutes = registryConfiguration.K8sGRPCRoutes
+		}
+		if criteria.Include(kubernetes.K8sReferenceGrants) {
+			istioConfigList.K8sReferenceGrants = registryConfiguration.K8sReferenceGrants
+		}
+		if criteria.Include(kubernetes.K8sTCPRoutes) {
+			istioConfigList.K8sTCPRoutes = registryConfiguration.K8sTCPRoutes
+		}
+		if criteria.Include(kubernetes.K8sTLSRoutes) {
+			istioConfigList.K8sTLSRoutes = registryConfiguration.K8sTLSRoutes
+		}
 		if criteria.Include(kubernetes.VirtualServices) {
 			istioConfigList.VirtualServices = registryConfiguration.VirtualServices
 		}
@@ -289,10 +322,10 @@ func (in *IstioConfigService) GetIstioConfigListPerCluster(ctx context.Context,
 		workloadSelector = criteria.WorkloadSelector
 	}
 
-	errChan := make(chan error, 15)
+	errChan := make(chan error, 19)
 
 	var wg sync.WaitGroup
-	wg.Add(15)
+	wg.Add(19)
 
 	listOpts := meta_v1.ListOptions{LabelSelector: criteria.LabelSelector}
 
@@ -401,6 +434,66 @@ func (in *IstioConfigService) GetIstioConfigListPerCluster(ctx context.Context,
 		}
 	}(ctx, errChan)
 
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sGRPCRoutes) {
+			var err error
+			// ignore an error as system could not be configured to support K8s Gateway API
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sGRPCRoutes) {
+				istioConfigList.K8sGRPCRoutes, err = kubeCache.GetK8sGRPCRoutes(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sReferenceGrants) {
+			var err error
+			// ignore an error as system could not be configured to support K8s Gateway API
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sReferenceGrants) {
+				istioConfigList.K8sReferenceGrants, err = kubeCache.GetK8sReferenceGrants(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sTCPRoutes) {
+			var err error
+			// ignore an error as system could not be configured to support K8s Gateway API
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sTCPRoutes) {
+				istioConfigList.K8sTCPRoutes, err = kubeCache.GetK8sTCPRoutes(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sTLSRoutes) {
+			var err error
+			// ignore an error as system could not be configured to support K8s Gateway API
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sTLSRoutes) {
+				istioConfigList.K8sTLSRoutes, err = kubeCache.GetK8sTLSRoutes(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
 		if criteria.Include(kubernetes.ServiceEntries) {
@@ -679,6 +772,30 @@ func (in *IstioConfigService) GetIstioConfigDetails(ctx context.Context, cluster
 			istioConfigDetail.K8sHTTPRoute.Kind = kubernetes.K8sActualHTTPRouteType
 			istioConfigDetail.K8sHTTPRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Beta1
 		}
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigDetail.K8sGRPCRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sGRPCRoute.Kind = kubernetes.K8sActualGRPCRouteType
+			istioConfigDetail.K8sGRPCRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
+	case kubernetes.K8sReferenceGrants:
+		istioConfigDetail.K8sReferenceGrant, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sReferenceGrant.Kind = kubernetes.K8sActualReferenceGrantType
+			istioConfigDetail.K8sReferenceGrant.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sTCPRoute.Kind = kubernetes.K8sActualTCPRouteType
+			istioConfigDetail.K8sTCPRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
+	case kubernetes.K8sTLSRoutes:
+		istioConfigDetail.K8sTLSRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sTLSRoute.Kind = kubernetes.K8sActualTLSRouteType
+			istioConfigDetail.K8sTLSRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry, err = in.userClients[cluster].Istio().NetworkingV1beta1().ServiceEntries(namespace).Get(ctx, object, getOpts)
 		if err == nil {
@@ -833,6 +950,46 @@ func (in *IstioConfigService) GetIstioConfigDetailsFromRegistry(ctx context.Cont
 				return istioConfigDetail, nil
 			}
 		}
+	case kubernetes.K8sGRPCRoutes:
+		configs := registryConfiguration.K8sGRPCRoutes
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sGRPCRoute = cfg
+				istioConfigDetail.K8sGRPCRoute.Kind = kubernetes.K8sGRPCRouteType
+				istioConfigDetail.K8sGRPCRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
+	case kubernetes.K8sReferenceGrants:
+		configs := registryConfiguration.K8sReferenceGrants
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sReferenceGrant = cfg
+				istioConfigDetail.K8sReferenceGrant.Kind = kubernetes.K8sReferenceGrantType
+				istioConfigDetail.K8sReferenceGrant.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
+	case kubernetes.K8sTCPRoutes:
+		configs := registryConfiguration.K8sTCPRoutes
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sTCPRoute = cfg
+				istioConfigDetail.K8sTCPRoute.Kind = kubernetes.K8sTCPRouteType
+				istioConfigDetail.K8sTCPRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
+	case kubernetes.K8sTLSRoutes:
+		configs := registryConfiguration.K8sTLSRoutes
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sTLSRoute = cfg
+				istioConfigDetail.K8sTLSRoute.Kind = kubernetes.K8sTLSRouteType
+				istioConfigDetail.K8sTLSRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
 	case kubernetes.ServiceEntries:
 		configs := registryConfiguration.ServiceEntries
 		for _, cfg := range configs {
@@ -966,6 +1123,14 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(cluster, namespace, resour
 		err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().Gateways(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.K8sHTTPRoutes:
 		err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().HTTPRoutes(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sGRPCRoutes:
+		err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sReferenceGrants:
+		err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sTCPRoutes:
+		err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sTLSRoutes:
+		err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.ServiceEntries:
 		err = in.userClients[cluster].Istio().NetworkingV1beta1().ServiceEntries(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.Sidecars:
@@ -1000,11 +1165,26 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(cluster, namespace, resour
 }
 
 func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resourceType, name, jsonPatch string) (models.IstioConfigDetails, error) {
+	istioConfigDetail, err := in.patchIstioConfigDetail(cluster, namespace, resourceType, name, jsonPatch, meta_v1.PatchOptions{})
+
+	// Cache is stopped after a Create/Update/Delete operation to force a refresh
+	if kialiCache != nil && err == nil {
+		kialiCache.Refresh(namespace)
+	}
+	return istioConfigDetail, err
+}
+
+// DryRunUpdateIstioConfigDetail returns the object that would result from the update, without persisting it.
+// The patch is applied by the API server, so the object is also checked by the admission webhooks.
+func (in *IstioConfigService) DryRunUpdateIstioConfigDetail(cluster, namespace, resourceType, name, jsonPatch string) (models.IstioConfigDetails, error) {
+	return in.patchIstioConfigDetail(cluster, namespace, resourceType, name, jsonPatch, meta_v1.PatchOptions{DryRun: []string{meta_v1.DryRunAll}})
+}
+
+func (in *IstioConfigService) patchIstioConfigDetail(cluster, namespace, resourceType, name, jsonPatch string, patchOpts meta_v1.PatchOptions) (models.IstioConfigDetails, error) {
 	istioConfigDetail := models.IstioConfigDetails{}
 	istioConfigDetail.Namespace = models.Namespace{Name: namespace}
 	istioConfigDetail.ObjectType = resourceType
 
-	patchOpts := meta_v1.PatchOptions{}
 	ctx := context.TODO()
 	patchType := api_types.MergePatchType
 	bytePatch := []byte(jsonPatch)
@@ -1026,6 +1206,18 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resour
 	case kubernetes.K8sHTTPRoutes:
 		istioConfigDetail.K8sHTTPRoute = &k8s_networking_v1beta1.HTTPRoute{}
 		istioConfigDetail.K8sHTTPRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().HTTPRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigDetail.K8sGRPCRoute = &k8s_networking_v1alpha2.GRPCRoute{}
+		istioConfigDetail.K8sGRPCRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sReferenceGrants:
+		istioConfigDetail.K8sReferenceGrant = &k8s_networking_v1alpha2.ReferenceGrant{}
+		istioConfigDetail.K8sReferenceGrant, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute = &k8s_networking_v1alpha2.TCPRoute{}
+		istioConfigDetail.K8sTCPRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sTLSRoutes:
+		istioConfigDetail.K8sTLSRoute = &k8s_networking_v1alpha2.TLSRoute{}
+		istioConfigDetail.K8sTLSRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry = &networking_v1beta1.ServiceEntry{}
 		istioConfigDetail.ServiceEntry, err = in.userClients[cluster].Istio().NetworkingV1beta1().ServiceEntries(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
@@ -1060,6 +1252,12 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resour
 		err = fmt.Errorf("object type not found: %v", resourceType)
 	}
 
+	return istioConfigDetail, err
+}
+
+func (in *IstioConfigService) CreateIstioConfigDetail(cluster, namespace, resourceType string, body []byte) (models.IstioConfigDetails, error) {
+	istioConfigDetail, err := in.createIstioConfigDetail(cluster, namespace, resourceType, body, meta_v1.CreateOptions{})
+
 	// Cache is stopped after a Create/Update/Delete operation to force a refresh
 	if kialiCache != nil && err == nil {
 		kialiCache.Refresh(namespace)
@@ -1067,12 +1265,17 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resour
 	return istioConfigDetail, err
 }
 
-func (in *IstioConfigService) CreateIstioConfigDetail(cluster, namespace, resourceType string, body []byte) (models.IstioConfigDetails, error) {
+// DryRunCreateIstioConfigDetail returns the object that would be created, without persisting it.
+// The object is defaulted by the API server and checked by the admission webhooks.
+func (in *IstioConfigService) DryRunCreateIstioConfigDetail(cluster, namespace, resourceType string, body []byte) (models.IstioConfigDetails, error) {
+	return in.createIstioConfigDetail(cluster, namespace, resourceType, body, meta_v1.CreateOptions{DryRun: []string{meta_v1.DryRunAll}})
+}
+
+func (in *IstioConfigService) createIstioConfigDetail(cluster, namespace, resourceType string, body []byte, createOpts meta_v1.CreateOptions) (models.IstioConfigDetails, error) {
 	istioConfigDetail := models.IstioConfigDetails{}
 	istioConfigDetail.Namespace = models.Namespace{Name: namespace}
 	istioConfigDetail.ObjectType = resourceType
 
-	createOpts := meta_v1.CreateOptions{}
 	ctx := context.TODO()
 
 	var err error
@@ -1112,6 +1315,34 @@ func (in *IstioConfigService) CreateIstioConfigDetail(cluster, namespace, resour
 			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
 		}
 		istioConfigDetail.K8sHTTPRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().HTTPRoutes(namespace).Create(ctx, istioConfigDetail.K8sHTTPRoute, createOpts)
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigDetail.K8sGRPCRoute = &k8s_networking_v1alpha2.GRPCRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sGRPCRoute)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sGRPCRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Create(ctx, istioConfigDetail.K8sGRPCRoute, createOpts)
+	case kubernetes.K8sReferenceGrants:
+		istioConfigDetail.K8sReferenceGrant = &k8s_networking_v1alpha2.ReferenceGrant{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sReferenceGrant)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sReferenceGrant, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Create(ctx, istioConfigDetail.K8sReferenceGrant, createOpts)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute = &k8s_networking_v1alpha2.TCPRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sTCPRoute)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sTCPRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Create(ctx, istioConfigDetail.K8sTCPRoute, createOpts)
+	case kubernetes.K8sTLSRoutes:
+		istioConfigDetail.K8sTLSRoute = &k8s_networking_v1alpha2.TLSRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sTLSRoute)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sTLSRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Create(ctx, istioConfigDetail.K8sTLSRoute, createOpts)
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry = &networking_v1beta1.ServiceEntry{}
 		err = json.Unmarshal(body, istioConfigDetail.ServiceEntry)
@@ -1185,10 +1416,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(cluster, namespace, resour
 	default:
 		err = fmt.Errorf("object type not found: %v", resourceType)
 	}
-	// Cache is stopped after a Create/Update/Delete operation to force a refresh
-	if kialiCache != nil && err == nil {
-		kialiCache.Refresh(namespace)
-	}
 	return istioConfigDetail, err
 }
 
@@ -1380,7 +1607,11 @@ func ParseIstioConfigCriteria(namespace, objects, labelSelector, workloadSelecto
 	criteria := IstioConfigCriteria{}
 	criteria.IncludeGateways = defaultInclude
 	criteria.IncludeK8sGateways = defaultInclude
+	criteria.IncludeK8sGRPCRoutes = defaultInclude
 	criteria.IncludeK8sHTTPRoutes = defaultInclude
+	criteria.IncludeK8sReferenceGrants = defaultInclude
+	criteria.IncludeK8sTCPRoutes = defaultInclude
+	criteria.IncludeK8sTLSRoutes = defaultInclude
 	criteria.IncludeVirtualServices = defaultInclude
 	criteria.IncludeDestinationRules = defaultInclude
 	criteria.IncludeServiceEntries = defaultInclude
@@ -1416,6 +1647,18 @@ func ParseIstioConfigCriteria(namespace, objects, labelSelector, workloadSelecto
 	if checkType(types, kubernetes.K8sHTTPRoutes) {
 		criteria.IncludeK8sHTTPRoutes = true
 	}
+	if checkType(types, kubernetes.K8sGRPCRoutes) {
+		criteria.IncludeK8sGRPCRoutes = true
+	}
+	if checkType(types, kubernetes.K8sReferenceGrants) {
+		criteria.IncludeK8sReferenceGrants = true
+	}
+	if checkType(types, kubernetes.K8sTCPRoutes) {
+		criteria.IncludeK8sTCPRoutes = true
+	}
+	if checkType(types, kubernetes.K8sTLSRoutes) {
+		criteria.IncludeK8sTLSRoutes = true
+	}
 	if checkType(types, kubernetes.VirtualServices) {
 		criteria.IncludeVirtualServices = true
 	}
@@ -3,13 +3,19 @@ package business
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/business/checkers/envoyfilters"
 	"github.com/kiali/kiali/business/references"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -36,6 +42,35 @@ type ReferenceChecker interface {
 // all the enabled checkers. If service is "" then the whole namespace is validated.
 // If service is not empty string, then all of its associated Istio objects are validated.
 func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster, namespace, service, workload string) (models.IstioValidations, error) {
+	return in.getValidations(ctx, cluster, namespace, service, workload, nil)
+}
+
+// GetDryRunNewErrors returns the validation errors that a dry-run create or update of an Istio object introduces,
+// per object. All the objects validated with the namespace of the Istio object are compared, before and after the
+// change, so the errors the change causes to other objects (e.g. a removed subset still routed to) are reported.
+func (in *IstioValidationsService) GetDryRunNewErrors(ctx context.Context, cluster, namespace, objectType string, istioConfigDetail models.IstioConfigDetails) (map[models.IstioValidationKey][]*models.IstioCheck, error) {
+	object := istioConfigDetail.IstioObject()
+	if object == nil || istioConfigDetail.ObjectType != objectType {
+		return nil, fmt.Errorf("object of type %s not found in the config details", objectType)
+	}
+	if object.GetNamespace() == "" {
+		object.SetNamespace(namespace)
+	}
+
+	current, err := in.getValidations(ctx, cluster, namespace, "", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	dryRun, err := in.getValidations(ctx, cluster, namespace, "", "", &istioConfigDetail)
+	if err != nil {
+		return nil, err
+	}
+	return dryRun.NewErrorChecks(current), nil
+}
+
+// getValidations returns the validations of GetValidations, with the Istio object of the dry-run config details,
+// if any, replacing its stored version
+func (in *IstioValidationsService) getValidations(ctx context.Context, cluster, namespace, service, workload string, dryRunObject *models.IstioConfigDetails) (models.IstioValidations, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "GetValidations",
 		observability.Attribute("package", "business"),
@@ -79,6 +114,7 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster,
 	var workloadsPerNamespace map[string]models.WorkloadList
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
+	var meshConfig kubernetes.IstioMeshConfig
 	var registryServices []*kubernetes.RegistryService
 
 	istioApiEnabled := config.Get().ExternalServices.Istio.IstioAPIEnabled
@@ -97,12 +133,12 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster,
 
 	if workload != "" {
 		// load only requested workload
-		go in.fetchWorkload(ctx, &workloadsPerNamespace, workload, namespace, errChan, &wg)
+		go in.fetchWorkload(ctx, &workloadsPerNamespace, &namespaces, workload, namespace, errChan, &wg)
 	} else {
 		go in.fetchAllWorkloads(ctx, &workloadsPerNamespace, &namespaces, errChan, &wg)
 	}
 
-	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, errChan, &wg)
+	go in.fetchMeshConfig(&mtlsDetails, &meshConfig, errChan, &wg)
 	if service != "" {
 		go in.fetchServices(ctx, &services, namespace, errChan, &wg)
 	}
@@ -119,46 +155,96 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster,
 		}
 	}
 
-	objectCheckers := in.getAllObjectCheckers(istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, namespaces, registryServices)
+	if dryRunObject != nil {
+		upsertIstioConfigDetail(&istioConfigList, &mtlsDetails, &rbacDetails, *dryRunObject)
+	}
+
+	imagePullSecrets := in.fetchImagePullSecrets(cluster, istioConfigList.WasmPlugins)
+	objectCheckers := in.getAllObjectCheckers(cluster, istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, meshConfig, in.isPolicyAllowAny(), imagePullSecrets, namespaces, registryServices)
 
 	// Get group validations for same kind istio objects
-	validations := runObjectCheckers(objectCheckers)
+	ignoredChecks := newIgnoredChecks(istioConfigList, mtlsDetails, rbacDetails, namespaces, workloadsPerNamespace)
+	validations := runObjectCheckers(objectCheckers, ignoredChecks)
 
 	if service != "" {
 		// in.businessLayer.Svc.GetServiceList(criteria) on fetchServices performs the validations on the service
 		// No need to re-fetch deployments+pods for this
 		validations.MergeValidations(services.Validations)
+		validations.StripIgnoredChecks(ignoredChecks)
 		validations = validations.FilterBySingleType("service", service)
 	} else if workload != "" {
 		workloadList := workloadsPerNamespace[namespace]
 		validations.MergeValidations(workloadList.Validations)
+		// the workload checks are annotated by the workload and its namespace too
+		validations.StripIgnoredChecks(ignoredChecks)
 		validations = validations.FilterBySingleType("workload", workload)
 	}
 
 	return validations, nil
 }
 
-func (in *IstioValidationsService) getAllObjectCheckers(istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces []models.Namespace, registryServices []*kubernetes.RegistryService) []ObjectChecker {
+// GetOfflineValidations runs the checkers of GetValidations over the given objects, without querying any cluster.
+// It is used to validate manifests before they are applied. The mesh settings (e.g. the outbound traffic policy) are
+// read from the given mesh config, and the checks that need live proxies are skipped.
+func GetOfflineValidations(istioConfigList models.IstioConfigList, namespaces models.Namespaces, workloadsPerNamespace map[string]models.WorkloadList, registryServices []*kubernetes.RegistryService, meshConfig kubernetes.IstioMeshConfig) models.IstioValidations {
+	in := IstioValidationsService{}
+
+	mtlsDetails := kubernetes.MTLSDetails{
+		DestinationRules: kubernetes.FilterAutogeneratedDestinationRules(istioConfigList.DestinationRules),
+		EnabledAutoMtls:  meshConfig.GetEnableAutoMtls(),
+	}
+	rbacDetails := kubernetes.RBACDetails{}
+	in.filterPeerAuths("", &mtlsDetails, istioConfigList.PeerAuthentications)
+	in.filterAuthPolicies("", &rbacDetails, istioConfigList.AuthorizationPolicies)
+
+	policyAllowAny := meshConfig.GetOutboundTrafficPolicyMode() == AllowAny
+	objectCheckers := in.getAllObjectCheckers("", istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, meshConfig, policyAllowAny, map[string]bool{}, namespaces, registryServices)
+	return runObjectCheckers(objectCheckers, newIgnoredChecks(istioConfigList, mtlsDetails, rbacDetails, namespaces, workloadsPerNamespace))
+}
+
+func (in *IstioValidationsService) getAllObjectCheckers(cluster string, istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, meshConfig kubernetes.IstioMeshConfig, policyAllowAny bool, imagePullSecrets map[string]bool, namespaces []models.Namespace, registryServices []*kubernetes.RegistryService) []ObjectChecker {
 	return []ObjectChecker{
-		checkers.NoServiceChecker{Namespaces: namespaces, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, AuthorizationDetails: &rbacDetails, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny()},
+		checkers.NoServiceChecker{Namespaces: namespaces, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, AuthorizationDetails: &rbacDetails, RegistryServices: registryServices, PolicyAllowAny: policyAllowAny},
 		checkers.VirtualServiceChecker{Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules},
 		checkers.DestinationRulesChecker{Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, MTLSDetails: mtlsDetails, ServiceEntries: istioConfigList.ServiceEntries},
 		checkers.GatewayChecker{Gateways: istioConfigList.Gateways, WorkloadsPerNamespace: workloadsPerNamespace, IsGatewayToNamespace: in.isGatewayToNamespace()},
 		checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace},
 		checkers.ServiceEntryChecker{ServiceEntries: istioConfigList.ServiceEntries, Namespaces: namespaces, WorkloadEntries: istioConfigList.WorkloadEntries},
-		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny()},
+		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: policyAllowAny},
 		checkers.SidecarChecker{Sidecars: istioConfigList.Sidecars, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices},
 		checkers.RequestAuthenticationChecker{RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace},
 		checkers.WorkloadChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, WorkloadsPerNamespace: workloadsPerNamespace},
 		checkers.K8sGatewayChecker{K8sGateways: istioConfigList.K8sGateways},
-		checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces},
-		checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces},
-		checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, Namespaces: namespaces, RegistryServices: registryServices},
+		checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ImagePullSecrets: imagePullSecrets},
+		checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ExtensionProviders: meshConfig.GetExtensionProviderNames()},
+		checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, RegistryServices: registryServices},
+		checkers.K8sRouteChecker{K8sGateways: istioConfigList.K8sGateways, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, K8sTLSRoutes: istioConfigList.K8sTLSRoutes, Namespaces: namespaces, RegistryServices: registryServices},
+		checkers.K8sReferenceGrantChecker{K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces},
+		// the namespace validations never make proxy round-trips, they use only the config dumps already cached
+		in.newEnvoyFilterChecker(cluster, istioConfigList.EnvoyFilters, workloadsPerNamespace, false),
+		checkers.CustomRulesChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, IstioConfigList: istioConfigList, Namespaces: namespaces, PeerAuthentications: mtlsDetails.PeerAuthentications, Rules: config.Get().KialiFeatureFlags.Validations.Rules},
 	}
 }
 
 // GetIstioObjectValidations validates a single Istio object of the given type with the given name found in the given namespace.
 func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context, cluster, namespace string, objectType string, object string) (models.IstioValidations, models.IstioReferencesMap, error) {
+	return in.getIstioObjectValidations(ctx, cluster, namespace, objectType, object, nil)
+}
+
+// GetDryRunValidations returns the validations and references of an Istio object that is not persisted yet, e.g. the
+// result of a dry-run create or update. The object replaces its stored version, if any, in the cluster config.
+func (in *IstioValidationsService) GetDryRunValidations(ctx context.Context, cluster, namespace, objectType string, istioConfigDetail models.IstioConfigDetails) (models.IstioValidations, models.IstioReferencesMap, error) {
+	object := istioConfigDetail.IstioObject()
+	if object == nil || istioConfigDetail.ObjectType != objectType {
+		return nil, models.IstioReferencesMap{}, fmt.Errorf("object of type %s not found in the config details", objectType)
+	}
+	if object.GetNamespace() == "" {
+		object.SetNamespace(namespace)
+	}
+	return in.getIstioObjectValidations(ctx, cluster, namespace, objectType, object.GetName(), &istioConfigDetail)
+}
+
+func (in *IstioValidationsService) getIstioObjectValidations(ctx context.Context, cluster, namespace string, objectType string, object string, dryRunObject *models.IstioConfigDetails) (models.IstioValidations, models.IstioReferencesMap, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "GetIstioObjectValidations",
 		observability.Attribute("package", "business"),
@@ -174,6 +260,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 	var workloadsPerNamespace map[string]models.WorkloadList
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
+	var meshConfig kubernetes.IstioMeshConfig
 	var registryServices []*kubernetes.RegistryService
 	var err error
 	var objectCheckers []ObjectChecker
@@ -204,7 +291,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 
 	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, cluster, namespace, errChan, &wg)
 	go in.fetchAllWorkloads(ctx, &workloadsPerNamespace, &namespaces, errChan, &wg)
-	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, errChan, &wg)
+	go in.fetchMeshConfig(&mtlsDetails, &meshConfig, errChan, &wg)
 
 	if istioApiEnabled {
 		go in.fetchRegistryServices(&registryServices, errChan, &wg)
@@ -212,6 +299,10 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 
 	wg.Wait()
 
+	if dryRunObject != nil {
+		upsertIstioConfigDetail(&istioConfigList, &mtlsDetails, &rbacDetails, *dryRunObject)
+	}
+
 	noServiceChecker := checkers.NoServiceChecker{Namespaces: namespaces, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, AuthorizationDetails: &rbacDetails, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny()}
 
 	switch objectType {
@@ -261,11 +352,13 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		requestAuthnChecker := checkers.RequestAuthenticationChecker{RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace}
 		objectCheckers = []ObjectChecker{requestAuthnChecker}
 	case kubernetes.EnvoyFilters:
-		// Validation on EnvoyFilters are not yet in place
+		objectCheckers = []ObjectChecker{in.newEnvoyFilterChecker(cluster, istioConfigList.EnvoyFilters, workloadsPerNamespace, true)}
 	case kubernetes.WasmPlugins:
-		// Validation on WasmPlugins is not expected
+		wasmPluginChecker := checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ImagePullSecrets: in.fetchImagePullSecrets(cluster, istioConfigList.WasmPlugins)}
+		objectCheckers = []ObjectChecker{wasmPluginChecker}
 	case kubernetes.Telemetries:
-		// Validation on Telemetries is not expected
+		telemetryChecker := checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ExtensionProviders: meshConfig.GetExtensionProviderNames()}
+		objectCheckers = []ObjectChecker{telemetryChecker}
 	case kubernetes.K8sGateways:
 		// Validations on K8sGateways
 		objectCheckers = []ObjectChecker{
@@ -273,13 +366,23 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		}
 		referenceChecker = references.K8sGatewayReferences{K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes}
 	case kubernetes.K8sHTTPRoutes:
-		httpRouteChecker := checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, Namespaces: namespaces, RegistryServices: registryServices}
+		httpRouteChecker := checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, RegistryServices: registryServices}
 		objectCheckers = []ObjectChecker{noServiceChecker, httpRouteChecker}
 		referenceChecker = references.K8sHTTPRouteReferences{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, Namespaces: namespaces}
+	case kubernetes.K8sGRPCRoutes, kubernetes.K8sTCPRoutes, kubernetes.K8sTLSRoutes:
+		routeChecker := checkers.K8sRouteChecker{K8sGateways: istioConfigList.K8sGateways, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, K8sTLSRoutes: istioConfigList.K8sTLSRoutes, Namespaces: namespaces, RegistryServices: registryServices}
+		objectCheckers = []ObjectChecker{routeChecker}
+	case kubernetes.K8sReferenceGrants:
+		referenceGrantChecker := checkers.K8sReferenceGrantChecker{K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces}
+		objectCheckers = []ObjectChecker{referenceGrantChecker}
 	default:
 		err = fmt.Errorf("object type not found: %v", objectType)
 	}
 
+	if rules := config.Get().KialiFeatureFlags.Validations.Rules; len(rules) > 0 && err == nil {
+		objectCheckers = append(objectCheckers, checkers.CustomRulesChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, IstioConfigList: istioConfigList, Namespaces: namespaces, PeerAuthentications: mtlsDetails.PeerAuthentications, Rules: rules})
+	}
+
 	close(errChan)
 	for e := range errChan {
 		if e != nil { // Check that default value wasn't returned
@@ -295,10 +398,79 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		return models.IstioValidations{}, istioReferences, err
 	}
 
-	return runObjectCheckers(objectCheckers).FilterByKey(models.ObjectTypeSingular[objectType], object), istioReferences, nil
+	ignoredChecks := newIgnoredChecks(istioConfigList, mtlsDetails, rbacDetails, namespaces, workloadsPerNamespace)
+	return runObjectCheckers(objectCheckers, ignoredChecks).FilterByKey(models.ObjectTypeSingular[objectType], object), istioReferences, nil
+}
+
+// upsertIstioConfigDetail adds the Istio object of the config details to the config fetched for the validations,
+// replacing the stored version of the object
+func upsertIstioConfigDetail(istioConfigList *models.IstioConfigList, mtlsDetails *kubernetes.MTLSDetails, rbacDetails *kubernetes.RBACDetails, istioConfigDetail models.IstioConfigDetails) {
+	switch istioConfigDetail.ObjectType {
+	case kubernetes.AuthorizationPolicies:
+		rbacDetails.AuthorizationPolicies = upsertObject(rbacDetails.AuthorizationPolicies, istioConfigDetail.AuthorizationPolicy)
+	case kubernetes.DestinationRules:
+		istioConfigList.DestinationRules = upsertObject(istioConfigList.DestinationRules, istioConfigDetail.DestinationRule)
+		mtlsDetails.DestinationRules = upsertObject(mtlsDetails.DestinationRules, istioConfigDetail.DestinationRule)
+	case kubernetes.EnvoyFilters:
+		istioConfigList.EnvoyFilters = upsertObject(istioConfigList.EnvoyFilters, istioConfigDetail.EnvoyFilter)
+	case kubernetes.Gateways:
+		istioConfigList.Gateways = upsertObject(istioConfigList.Gateways, istioConfigDetail.Gateway)
+	case kubernetes.K8sGateways:
+		istioConfigList.K8sGateways = upsertObject(istioConfigList.K8sGateways, istioConfigDetail.K8sGateway)
+	case kubernetes.K8sHTTPRoutes:
+		istioConfigList.K8sHTTPRoutes = upsertObject(istioConfigList.K8sHTTPRoutes, istioConfigDetail.K8sHTTPRoute)
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigList.K8sGRPCRoutes = upsertObject(istioConfigList.K8sGRPCRoutes, istioConfigDetail.K8sGRPCRoute)
+	case kubernetes.K8sReferenceGrants:
+		istioConfigList.K8sReferenceGrants = upsertObject(istioConfigList.K8sReferenceGrants, istioConfigDetail.K8sReferenceGrant)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigList.K8sTCPRoutes = upsertObject(istioConfigList.K8sTCPRoutes, istioConfigDetail.K8sTCPRoute)
+	case kubernetes.K8sTLSRoutes:
+		istioConfigList.K8sTLSRoutes = upsertObject(istioConfigList.K8sTLSRoutes, istioConfigDetail.K8sTLSRoute)
+	case kubernetes.PeerAuthentications:
+		if istioConfigDetail.PeerAuthentication.Namespace == config.Get().ExternalServices.Istio.RootNamespace {
+			mtlsDetails.MeshPeerAuthentications = upsertObject(mtlsDetails.MeshPeerAuthentications, istioConfigDetail.PeerAuthentication)
+		}
+		mtlsDetails.PeerAuthentications = upsertObject(mtlsDetails.PeerAuthentications, istioConfigDetail.PeerAuthentication)
+	case kubernetes.RequestAuthentications:
+		istioConfigList.RequestAuthentications = upsertObject(istioConfigList.RequestAuthentications, istioConfigDetail.RequestAuthentication)
+	case kubernetes.ServiceEntries:
+		istioConfigList.ServiceEntries = upsertObject(istioConfigList.ServiceEntries, istioConfigDetail.ServiceEntry)
+	case kubernetes.Sidecars:
+		istioConfigList.Sidecars = upsertObject(istioConfigList.Sidecars, istioConfigDetail.Sidecar)
+	case kubernetes.Telemetries:
+		istioConfigList.Telemetries = upsertObject(istioConfigList.Telemetries, istioConfigDetail.Telemetry)
+	case kubernetes.VirtualServices:
+		istioConfigList.VirtualServices = upsertObject(istioConfigList.VirtualServices, istioConfigDetail.VirtualService)
+	case kubernetes.WasmPlugins:
+		istioConfigList.WasmPlugins = upsertObject(istioConfigList.WasmPlugins, istioConfigDetail.WasmPlugin)
+	case kubernetes.WorkloadEntries:
+		istioConfigList.WorkloadEntries = upsertObject(istioConfigList.WorkloadEntries, istioConfigDetail.WorkloadEntry)
+	case kubernetes.WorkloadGroups:
+		istioConfigList.WorkloadGroups = upsertObject(istioConfigList.WorkloadGroups, istioConfigDetail.WorkloadGroup)
+	}
+}
+
+// upsertObject returns a copy of the objects with the object replacing the one with the same name and namespace,
+// or appended when there is none. The objects may be shared with the cache, so they are never modified in place.
+func upsertObject[T meta_v1.Object](objects []T, object T) []T {
+	upserted := make([]T, 0, len(objects)+1)
+	found := false
+	for _, o := range objects {
+		if o.GetName() == object.GetName() && o.GetNamespace() == object.GetNamespace() {
+			upserted = append(upserted, object)
+			found = true
+		} else {
+			upserted = append(upserted, o)
+		}
+	}
+	if !found {
+		upserted = append(upserted, object)
+	}
+	return upserted
 }
 
-func runObjectCheckers(objectCheckers []ObjectChecker) models.IstioValidations {
+func runObjectCheckers(objectCheckers []ObjectChecker, ignoredChecks models.IgnoredChecks) models.IstioValidations {
 	objectTypeValidations := models.IstioValidations{}
 
 	// Run checks for each IstioObject type
@@ -306,11 +478,31 @@ func runObjectCheckers(objectCheckers []ObjectChecker) models.IstioValidations {
 		objectTypeValidations.MergeValidations(runObjectChecker(objectChecker))
 	}
 
-	objectTypeValidations.StripIgnoredChecks()
+	objectTypeValidations.StripIgnoredChecks(ignoredChecks)
 
 	return objectTypeValidations
 }
 
+// newIgnoredChecks returns the checks ignored by the kiali.io/ignore-checks annotation of the validated objects,
+// workloads and namespaces
+func newIgnoredChecks(istioConfigList models.IstioConfigList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces models.Namespaces, workloadsPerNamespace map[string]models.WorkloadList) models.IgnoredChecks {
+	ignoredChecks := models.NewIgnoredChecks()
+	for objectType, objects := range checkers.IstioObjectsPerType(istioConfigList, rbacDetails.AuthorizationPolicies, mtlsDetails.PeerAuthentications) {
+		for _, object := range objects {
+			ignoredChecks.AddObject(models.IstioValidationKey{ObjectType: objectType, Namespace: object.GetNamespace(), Name: object.GetName()}, object.GetAnnotations())
+		}
+	}
+	for namespace, workloads := range workloadsPerNamespace {
+		for _, workload := range workloads.Workloads {
+			ignoredChecks.AddObject(models.IstioValidationKey{ObjectType: checkers.WorkloadCheckerType, Namespace: namespace, Name: workload.Name}, workload.Annotations)
+		}
+	}
+	for _, namespace := range namespaces {
+		ignoredChecks.AddNamespace(namespace.Name, namespace.Annotations)
+	}
+	return ignoredChecks
+}
+
 func runObjectChecker(objectChecker ObjectChecker) models.IstioValidations {
 	// tracking the time it takes to execute the Check
 	promtimer := internalmetrics.GetCheckerProcessingTimePrometheusTimer(fmt.Sprintf("%T", objectChecker))
@@ -374,9 +566,21 @@ func (in *IstioValidationsService) fetchAllWorkloads(ctx context.Context, rValue
 	}
 }
 
-func (in *IstioValidationsService) fetchWorkload(ctx context.Context, rValue *map[string]models.WorkloadList, workload, namespace string, errChan chan error, wg *sync.WaitGroup) {
+// fetchWorkload fetches the requested workload and all the namespaces, the checkers of the Istio objects of the
+// namespace need their labels and annotations
+func (in *IstioValidationsService) fetchWorkload(ctx context.Context, rValue *map[string]models.WorkloadList, namespaces *models.Namespaces, workload, namespace string, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) == 0 {
+		nss, err := in.businessLayer.Namespace.GetNamespaces(ctx)
+		if err != nil {
+			select {
+			case errChan <- err:
+			default:
+			}
+			return
+		}
+		*namespaces = nss
+
 		allWorkloads := map[string]models.WorkloadList{}
 		criteria := WorkloadCriteria{WorkloadName: workload, Namespace: namespace, IncludeIstioResources: true, IncludeHealth: false}
 		workloadList, err := in.businessLayer.Workload.GetWorkloadList(ctx, criteria)
@@ -411,6 +615,13 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 		IncludePeerAuthentications:    true,
 		IncludeK8sHTTPRoutes:          true,
 		IncludeK8sGateways:            true,
+		IncludeK8sGRPCRoutes:          true,
+		IncludeK8sReferenceGrants:     true,
+		IncludeK8sTCPRoutes:           true,
+		IncludeK8sTLSRoutes:           true,
+		IncludeTelemetry:              true,
+		IncludeWasmPlugins:            true,
+		IncludeEnvoyFilters:           true,
 	}
 	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigListPerCluster(ctx, criteria, cluster)
 	if err != nil {
@@ -439,6 +650,14 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 	// All K8sHTTPRoutes
 	rValue.K8sHTTPRoutes = append(rValue.K8sHTTPRoutes, istioConfigList.K8sHTTPRoutes...)
 
+	// All K8sGRPCRoutes, K8sTCPRoutes and K8sTLSRoutes
+	rValue.K8sGRPCRoutes = append(rValue.K8sGRPCRoutes, istioConfigList.K8sGRPCRoutes...)
+	rValue.K8sTCPRoutes = append(rValue.K8sTCPRoutes, istioConfigList.K8sTCPRoutes...)
+	rValue.K8sTLSRoutes = append(rValue.K8sTLSRoutes, istioConfigList.K8sTLSRoutes...)
+
+	// All K8sReferenceGrants
+	rValue.K8sReferenceGrants = append(rValue.K8sReferenceGrants, istioConfigList.K8sReferenceGrants...)
+
 	// All Sidecars
 	rValue.Sidecars = append(rValue.Sidecars, istioConfigList.Sidecars...)
 
@@ -448,6 +667,15 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 	// All WorkloadEntries
 	rValue.WorkloadEntries = append(rValue.WorkloadEntries, istioConfigList.WorkloadEntries...)
 
+	// All Telemetries
+	rValue.Telemetries = append(rValue.Telemetries, istioConfigList.Telemetries...)
+
+	// All WasmPlugins
+	rValue.WasmPlugins = append(rValue.WasmPlugins, istioConfigList.WasmPlugins...)
+
+	// All EnvoyFilters
+	rValue.EnvoyFilters = append(rValue.EnvoyFilters, istioConfigList.EnvoyFilters...)
+
 	in.filterPeerAuths(namespace, mtlsDetails, istioConfigList.PeerAuthentications)
 
 	in.filterAuthPolicies(namespace, rbacDetails, istioConfigList.AuthorizationPolicies)
@@ -539,7 +767,8 @@ func (in *IstioValidationsService) filterSEExportToNamespaces(namespace string,
 	return result
 }
 
-func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kubernetes.MTLSDetails, errChan chan error, wg *sync.WaitGroup) {
+// fetchMeshConfig reads the Istio mesh config, providing the non-local mTLS configs and the extension providers
+func (in *IstioValidationsService) fetchMeshConfig(mtlsDetails *kubernetes.MTLSDetails, meshConfig *kubernetes.IstioMeshConfig, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) > 0 {
 		return
@@ -563,9 +792,33 @@ func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kuberne
 		errChan <- err
 	} else {
 		mtlsDetails.EnabledAutoMtls = icm.GetEnableAutoMtls()
+		*meshConfig = *icm
 	}
 }
 
+// fetchImagePullSecrets looks up, through the Kiali cache, the imagePullSecrets referenced by the WasmPlugins. It
+// returns whether each secret exists, keyed by <namespace>/<name>. Secrets that can't be looked up (e.g. no RBAC
+// access) are omitted, it's unknown whether they exist.
+func (in *IstioValidationsService) fetchImagePullSecrets(cluster string, wasmPlugins []*extensions_v1alpha1.WasmPlugin) map[string]bool {
+	secrets := make(map[string]bool)
+	if kialiCache == nil {
+		return secrets
+	}
+	for _, wp := range wasmPlugins {
+		if wp.Spec.ImagePullSecret == "" {
+			continue
+		}
+		key := wp.Namespace + "/" + wp.Spec.ImagePullSecret
+		if _, found := secrets[key]; found {
+			continue
+		}
+		if exists, known := kialiCache.SecretExists(cluster, wp.Namespace, wp.Spec.ImagePullSecret); known {
+			secrets[key] = exists
+		}
+	}
+	return secrets
+}
+
 func (in *IstioValidationsService) fetchRegistryServices(rValue *[]*kubernetes.RegistryService, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	criteria := RegistryCriteria{AllNamespaces: true}
@@ -580,6 +833,122 @@ func (in *IstioValidationsService) fetchRegistryServices(rValue *[]*kubernetes.R
 	}
 }
 
+// newEnvoyFilterChecker returns the EnvoyFilterChecker, providing it with the running proxy versions and with
+// a config dump sampled for each EnvoyFilter patching listeners or clusters. The config dumps are cached, when
+// fetchDumps is set the missing ones are fetched from the proxies, at most once per cache duration.
+func (in *IstioValidationsService) newEnvoyFilterChecker(cluster string, envoyFilters []*networking_v1alpha3.EnvoyFilter, workloadsPerNamespace map[string]models.WorkloadList, fetchDumps bool) checkers.EnvoyFilterChecker {
+	envoyFilterChecker := checkers.EnvoyFilterChecker{
+		EnvoyFilters:          envoyFilters,
+		WorkloadsPerNamespace: workloadsPerNamespace,
+		ConfigDumps:           map[models.IstioValidationKey]*kubernetes.ConfigDump{},
+	}
+	if len(envoyFilters) == 0 || kialiCache == nil || in.businessLayer == nil {
+		return envoyFilterChecker
+	}
+
+	proxyStatuses := kialiCache.GetProxyStatuses(cluster)
+	versions := map[string]bool{}
+	for _, ps := range proxyStatuses {
+		version := ps.IstioVersion
+		if version == "" {
+			version = ps.ProxyVersion
+		}
+		if version != "" && !versions[version] {
+			versions[version] = true
+			envoyFilterChecker.ProxyVersions = append(envoyFilterChecker.ProxyVersions, version)
+		}
+	}
+
+	// the config dumps are sampled once per proxy
+	podDumps := map[string]*kubernetes.ConfigDump{}
+	for _, ef := range envoyFilters {
+		if !envoyfilters.HasPatchTargets(ef) {
+			continue
+		}
+		namespace, pod, found := in.sampleEnvoyFilterProxy(cluster, ef, proxyStatuses)
+		if !found {
+			continue
+		}
+		dump, sampled := podDumps[namespace+"/"+pod]
+		if !sampled {
+			dump = in.getConfigDump(cluster, namespace, pod, fetchDumps)
+			podDumps[namespace+"/"+pod] = dump
+		}
+		if dump != nil {
+			envoyFilterChecker.ConfigDumps[models.BuildKey(checkers.EnvoyFilterCheckerType, ef.Name, ef.Namespace)] = dump
+		}
+	}
+
+	return envoyFilterChecker
+}
+
+// getConfigDump returns the config dump of the proxy of a pod, nil when it can't be fetched. The dumps are fetched
+// from the proxies, only when fetch is set, and they are cached by the Kiali cache.
+func (in *IstioValidationsService) getConfigDump(cluster, namespace, pod string, fetch bool) *kubernetes.ConfigDump {
+	if dump, found := kialiCache.GetConfigDump(cluster, namespace, pod); found || !fetch {
+		return dump
+	}
+	var dump *kubernetes.ConfigDump
+	if proxyDump, err := in.businessLayer.ProxyStatus.GetConfigDump(cluster, namespace, pod); err == nil {
+		dump = proxyDump.ConfigDump
+	} else {
+		log.Debugf("Unable to fetch the config dump of pod [%s/%s]: %v", namespace, pod, err)
+	}
+	kialiCache.SetConfigDump(cluster, namespace, pod, dump)
+	return dump
+}
+
+// sampleEnvoyFilterProxy returns the namespace and pod of a proxy the EnvoyFilter applies to. Selector-less
+// EnvoyFilters in the root namespace apply to every proxy and are not sampled. The proxy pods must match the
+// workload selector of the EnvoyFilter.
+func (in *IstioValidationsService) sampleEnvoyFilterProxy(cluster string, ef *networking_v1alpha3.EnvoyFilter, proxyStatuses []*kubernetes.ProxyStatus) (string, string, bool) {
+	hasSelector := ef.Spec.WorkloadSelector != nil && len(ef.Spec.WorkloadSelector.Labels) > 0
+	isRoot := config.IsRootNamespace(ef.Namespace)
+	if isRoot && !hasSelector {
+		return "", "", false
+	}
+
+	// the names of the selected pods, per namespace
+	selectedPods := map[string]map[string]bool{}
+	for _, ps := range proxyStatuses {
+		// Expected format <pod-name>.<namespace>
+		podId := strings.Split(ps.ProxyID, ".")
+		if len(podId) != 2 || (!isRoot && podId[1] != ef.Namespace) {
+			continue
+		}
+		pod, namespace := podId[0], podId[1]
+		if !hasSelector {
+			return namespace, pod, true
+		}
+		if _, found := selectedPods[namespace]; !found {
+			selectedPods[namespace] = in.selectPods(cluster, namespace, labels.SelectorFromSet(ef.Spec.WorkloadSelector.Labels).String())
+		}
+		if selectedPods[namespace][pod] {
+			return namespace, pod, true
+		}
+	}
+	return "", "", false
+}
+
+// selectPods returns the names of the pods of the namespace matching the label selector
+func (in *IstioValidationsService) selectPods(cluster, namespace, selector string) map[string]bool {
+	var pods []core_v1.Pod
+	var err error
+	if kubeCache, cacheErr := kialiCache.GetKubeCache(cluster); cacheErr == nil && kubeCache.CheckNamespace(namespace) {
+		pods, err = kubeCache.GetPods(namespace, selector)
+	} else {
+		pods, err = in.k8s.GetPods(namespace, selector)
+	}
+	if err != nil {
+		log.Debugf("Unable to fetch the pods of namespace [%s] matching [%s]: %v", namespace, selector, err)
+	}
+	names := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		names[pod.Name] = true
+	}
+	return names
+}
+
 func (in *IstioValidationsService) isGatewayToNamespace() bool {
 	gatewayToNamespace := false
 	if in.businessLayer != nil {
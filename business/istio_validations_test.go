@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,65 @@ func TestGetAllValidations(t *testing.T) {
 	assert.True(validations[models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}].Valid)
 }
 
+func TestGetValidationsWithCustomRules(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.KialiFeatureFlags.Validations.Rules = []config.ValidationRule{
+		{Code: "ORG001", Expression: "object.spec.http.all(r, has(r.timeout))", Message: "Every route must set a timeout", ObjectType: "virtualservice", Path: "spec/http", Severity: "error"},
+	}
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(t, fakeIstioConfigList(),
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "product2.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+	key := models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}
+
+	validations, err := vs.GetValidations(context.TODO(), kubernetes.HomeClusterName, "test", "", "")
+	require.NoError(err)
+	require.NotNil(validations[key])
+	assert.False(validations[key].Valid)
+	codes := []string{}
+	for _, check := range validations[key].Checks {
+		codes = append(codes, check.Code)
+	}
+	assert.Contains(codes, "ORG001")
+
+	validations, _, err = vs.GetIstioObjectValidations(context.TODO(), kubernetes.HomeClusterName, "test", kubernetes.VirtualServices, "product-vs")
+	require.NoError(err)
+	require.NotNil(validations[key])
+	codes = []string{}
+	for _, check := range validations[key].Checks {
+		codes = append(codes, check.Code)
+	}
+	assert.Contains(codes, "ORG001")
+}
+
+func TestGetWorkloadValidationsWithCustomRules(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.KialiFeatureFlags.Validations.Rules = []config.ValidationRule{
+		{Code: "ORG001", Expression: "object.spec.http.all(r, has(r.timeout))", Message: "Every route must set a timeout", NamespaceSelector: "env=prod", ObjectType: "virtualservice", Path: "spec/http", Severity: "error"},
+		{Code: "ORG002", Expression: "has(object.spec.gateways)", Message: "Every route must be exposed", NamespaceSelector: "env=dev", ObjectType: "virtualservice", Path: "spec", Severity: "error"},
+	}
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(t, fakeIstioConfigList(),
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "product2.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+	key := models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}
+
+	// The namespace selectors of the rules are matched with the labels of the namespace of the workload
+	validations, err := vs.GetValidations(context.TODO(), kubernetes.HomeClusterName, "test", "", "details-v1")
+	require.NoError(err)
+	require.NotNil(validations[key])
+	codes := []string{}
+	for _, check := range validations[key].Checks {
+		codes = append(codes, check.Code)
+	}
+	assert.Contains(codes, "ORG001")
+	assert.NotContains(codes, "ORG002")
+}
+
 func TestGetIstioObjectValidations(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
@@ -72,6 +132,145 @@ func TestGatewayValidation(t *testing.T) {
 	assert.NotEmpty(validations)
 }
 
+func TestGetOfflineValidations(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	istioConfigList := fakeIstioConfigList()
+	namespaces := models.Namespaces{{Name: "test"}, {Name: "test2"}}
+	key := models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}
+
+	// no cluster is queried, the hosts are resolved with the given registry services only
+	registryServices := data.CreateFakeMultiRegistryServices([]string{"product2.test.svc.cluster.local"}, "test", "*")
+	validations := GetOfflineValidations(*istioConfigList, namespaces, map[string]models.WorkloadList{}, registryServices, kubernetes.IstioMeshConfig{})
+	assert.NotEmpty(validations)
+	assert.False(validations[key].Valid)
+	// the outbound traffic policy defaults to ALLOW_ANY, the unknown hosts are warnings
+	require.NotEmpty(t, validations[key].Checks)
+	assert.Equal("KIA1101", validations[key].Checks[0].Code)
+	assert.Equal(models.WarningSeverity, validations[key].Checks[0].Severity)
+
+	meshConfig := kubernetes.IstioMeshConfig{}
+	meshConfig.OutboundTrafficPolicy.Mode = "REGISTRY_ONLY"
+	validations = GetOfflineValidations(*istioConfigList, namespaces, map[string]models.WorkloadList{}, registryServices, meshConfig)
+	require.NotEmpty(t, validations[key].Checks)
+	assert.Equal("KIA1101", validations[key].Checks[0].Code)
+	assert.Equal(models.ErrorSeverity, validations[key].Checks[0].Severity)
+
+	registryServices = data.CreateFakeMultiRegistryServices([]string{"product.test.svc.cluster.local", "product2.test.svc.cluster.local"}, "test", "*")
+	validations = GetOfflineValidations(*istioConfigList, namespaces, map[string]models.WorkloadList{}, registryServices, kubernetes.IstioMeshConfig{})
+	assert.True(validations[key].Valid)
+}
+
+func TestGetValidationsIgnoreChecksAnnotation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	key := models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}
+	registryServices := data.CreateFakeMultiRegistryServices([]string{"product2.test.svc.cluster.local"}, "test", "*")
+
+	istioConfigList := fakeIstioConfigList()
+	istioConfigList.VirtualServices[0].Annotations = map[string]string{models.IgnoreChecksAnnotation: "KIA1101"}
+	validations := GetOfflineValidations(*istioConfigList, models.Namespaces{{Name: "test"}}, map[string]models.WorkloadList{}, registryServices, kubernetes.IstioMeshConfig{})
+	require.NotNil(validations[key])
+	assert.True(validations[key].Valid)
+	require.NotEmpty(validations[key].SuppressedChecks)
+	assert.Equal("KIA1101", validations[key].SuppressedChecks[0].Code)
+	assert.Equal(models.SuppressedByObject, validations[key].SuppressedChecks[0].SuppressedBy)
+
+	istioConfigList = fakeIstioConfigList()
+	namespaces := models.Namespaces{{Name: "test", Annotations: map[string]string{models.IgnoreChecksAnnotation: "KIA1101"}}}
+	validations = GetOfflineValidations(*istioConfigList, namespaces, map[string]models.WorkloadList{}, registryServices, kubernetes.IstioMeshConfig{})
+	require.NotNil(validations[key])
+	assert.True(validations[key].Valid)
+	require.NotEmpty(validations[key].SuppressedChecks)
+	assert.Equal(models.SuppressedByNamespace, validations[key].SuppressedChecks[0].SuppressedBy)
+}
+
+func TestGetWorkloadValidationsIgnoreChecksAnnotation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	namespaces := fakeNamespaces()
+	namespaces[0].Annotations = map[string]string{models.IgnoreChecksAnnotation: "KIA1107"}
+	vs := mockCombinedValidationServiceWithNamespaces(t, fakeIstioConfigList(),
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "product2.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods(), namespaces)
+	key := models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}
+
+	validations, err := vs.GetValidations(context.TODO(), kubernetes.HomeClusterName, "test", "", "details-v1")
+	require.NoError(err)
+	require.NotNil(validations[key])
+	for _, check := range validations[key].Checks {
+		assert.NotEqual("KIA1107", check.Code)
+	}
+	require.NotEmpty(validations[key].SuppressedChecks)
+	assert.Equal("KIA1107", validations[key].SuppressedChecks[0].Code)
+	assert.Equal(models.SuppressedByNamespace, validations[key].SuppressedChecks[0].SuppressedBy)
+}
+
+func TestGetDryRunValidations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(t, fakeIstioConfigList(),
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "product2.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+
+	// a new VirtualService routing to a missing service
+	reviews := models.IstioConfigDetails{
+		ObjectType:     kubernetes.VirtualServices,
+		VirtualService: data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "v1", -1), data.CreateEmptyVirtualService("reviews-vs", "", []string{"reviews"})),
+	}
+	validations, _, err := vs.GetDryRunValidations(context.TODO(), kubernetes.HomeClusterName, "test", kubernetes.VirtualServices, reviews)
+	require.NoError(err)
+	require.Len(validations, 1)
+	validation := validations[models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "reviews-vs"}]
+	require.NotNil(validation)
+	assert.False(validation.Valid)
+	assert.Equal("test", reviews.VirtualService.Namespace)
+
+	// the stored product-vs is replaced by its updated version
+	product := models.IstioConfigDetails{
+		ObjectType:     kubernetes.VirtualServices,
+		VirtualService: data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("details", "", -1), data.CreateEmptyVirtualService("product-vs", "test", []string{"details"})),
+	}
+	validations, references, err := vs.GetDryRunValidations(context.TODO(), kubernetes.HomeClusterName, "test", kubernetes.VirtualServices, product)
+	require.NoError(err)
+	validation = validations[models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}]
+	require.NotNil(validation)
+	assert.True(validation.Valid)
+	productReferences := references[models.IstioReferenceKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}]
+	require.NotNil(productReferences)
+	require.Len(productReferences.ServiceReferences, 1)
+	assert.Equal("details", productReferences.ServiceReferences[0].Name)
+
+	_, _, err = vs.GetDryRunValidations(context.TODO(), kubernetes.HomeClusterName, "test", kubernetes.Gateways, product)
+	assert.Error(err)
+}
+
+func TestUpsertObject(t *testing.T) {
+	assert := assert.New(t)
+
+	first := data.CreateEmptyVirtualService("first", "test", []string{"first"})
+	second := data.CreateEmptyVirtualService("second", "test", []string{"second"})
+	stored := []*networking_v1beta1.VirtualService{first, second}
+
+	updated := data.CreateEmptyVirtualService("first", "test", []string{"updated"})
+	upserted := upsertObject(stored, updated)
+	assert.Equal([]*networking_v1beta1.VirtualService{updated, second}, upserted)
+	// the stored objects are not modified
+	assert.Equal([]*networking_v1beta1.VirtualService{first, second}, stored)
+
+	other := data.CreateEmptyVirtualService("first", "other", []string{"first"})
+	assert.Equal([]*networking_v1beta1.VirtualService{first, second, other}, upsertObject(stored, other))
+}
+
 func TestFilterExportToNamespacesVS(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
@@ -179,6 +378,10 @@ func mockMultiNamespaceGatewaysValidationService(t *testing.T) IstioValidationsS
 }
 
 func mockCombinedValidationService(t *testing.T, istioConfigList *models.IstioConfigList, services []string, namespace string, podList *core_v1.PodList) IstioValidationsService {
+	return mockCombinedValidationServiceWithNamespaces(t, istioConfigList, services, namespace, podList, fakeNamespaces())
+}
+
+func mockCombinedValidationServiceWithNamespaces(t *testing.T, istioConfigList *models.IstioConfigList, services []string, namespace string, podList *core_v1.PodList, namespaces []core_v1.Namespace) IstioValidationsService {
 	fakeIstioObjects := []runtime.Object{
 		&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}},
 		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "wrong"}},
@@ -195,7 +398,7 @@ func mockCombinedValidationService(t *testing.T, istioConfigList *models.IstioCo
 	for _, p := range FakeDepSyncedWithRS() {
 		fakeIstioObjects = append(fakeIstioObjects, p.DeepCopyObject())
 	}
-	for _, p := range fakeNamespaces() {
+	for _, p := range namespaces {
 		fakeIstioObjects = append(fakeIstioObjects, p.DeepCopyObject())
 	}
 	for _, p := range FakeRSSyncedWithPods() {
@@ -280,7 +483,8 @@ func fakeNamespaces() []core_v1.Namespace {
 	return []core_v1.Namespace{
 		{
 			ObjectMeta: meta_v1.ObjectMeta{
-				Name: "test",
+				Name:   "test",
+				Labels: map[string]string{"env": "prod"},
 			},
 		},
 		{
@@ -356,3 +560,92 @@ func yamlFixtureLoaderFor(file string) *validations.YamlFixtureLoader {
 	path := fmt.Sprintf("../tests/data/validations/exportto/cns/%s", file)
 	return &validations.YamlFixtureLoader{Filename: path}
 }
+
+func TestSampleEnvoyFilterProxy(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	pod := func(name, version string) runtime.Object {
+		return &core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: map[string]string{"app": "reviews", "version": version}}}
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		// the pods of workloads reviews (v1) and reviews-v2 share a name prefix
+		pod("reviews-v2-6b5c7d-abcde", "v2"),
+		pod("reviews-7d8f9b-fghij", "v1"),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	vs := IstioValidationsService{k8s: k8s}
+
+	proxyStatuses := []*kubernetes.ProxyStatus{
+		{SyncStatus: kubernetes.SyncStatus{ProxyID: "details-v1-79f774-klmno.bookinfo"}},
+		{SyncStatus: kubernetes.SyncStatus{ProxyID: "reviews-v2-6b5c7d-abcde.bookinfo"}},
+		{SyncStatus: kubernetes.SyncStatus{ProxyID: "reviews-7d8f9b-fghij.bookinfo"}},
+	}
+
+	namespace, pod1, found := vs.sampleEnvoyFilterProxy(conf.KubernetesConfig.ClusterName, data.CreateEnvoyFilter("reviews-v1", "bookinfo", map[string]string{"version": "v1"}), proxyStatuses)
+	assert.True(found)
+	assert.Equal("bookinfo", namespace)
+	assert.Equal("reviews-7d8f9b-fghij", pod1)
+
+	_, _, found = vs.sampleEnvoyFilterProxy(conf.KubernetesConfig.ClusterName, data.CreateEnvoyFilter("ratings", "bookinfo", map[string]string{"app": "ratings"}), proxyStatuses)
+	assert.False(found)
+
+	// selector-less EnvoyFilters apply to every proxy of their namespace
+	_, pod1, found = vs.sampleEnvoyFilterProxy(conf.KubernetesConfig.ClusterName, data.CreateEnvoyFilter("all", "bookinfo", nil), proxyStatuses)
+	assert.True(found)
+	assert.Equal("details-v1-79f774-klmno", pod1)
+}
+
+func TestGetConfigDumpCachedOnly(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	SetupBusinessLayer(t, k8s, *conf)
+	// without a business layer, fetching a config dump from the proxy would panic
+	vs := IstioValidationsService{k8s: k8s}
+	cluster := conf.KubernetesConfig.ClusterName
+
+	assert.Nil(vs.getConfigDump(cluster, "bookinfo", "reviews-7d8f9b-fghij", false))
+
+	dump := &kubernetes.ConfigDump{}
+	kialiCache.SetConfigDump(cluster, "bookinfo", "reviews-7d8f9b-fghij", dump)
+	assert.Same(dump, vs.getConfigDump(cluster, "bookinfo", "reviews-7d8f9b-fghij", false))
+}
+
+func TestGetDryRunNewErrors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	// the external.example.com host of external-dr is only defined by external-se
+	istioConfigList := fakeIstioConfigList()
+	istioConfigList.ServiceEntries = []*networking_v1beta1.ServiceEntry{data.CreateEmptyMeshExternalServiceEntry("external-se", "test", []string{"external.example.com"})}
+	istioConfigList.DestinationRules = append(istioConfigList.DestinationRules, data.CreateEmptyDestinationRule("test", "external-dr", "external.example.com"))
+	vs := mockCombinedValidationService(t, istioConfigList,
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "product2.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+	// a host without a matching entry is an error only with the REGISTRY_ONLY outbound traffic policy
+	istioConfigMap := &core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}, Data: map[string]string{"mesh": "outboundTrafficPolicy:\n  mode: REGISTRY_ONLY\n"}}
+	_, err := vs.k8s.Kube().CoreV1().ConfigMaps("istio-system").Update(context.TODO(), istioConfigMap, meta_v1.UpdateOptions{})
+	require.NoError(err)
+	require.Eventually(func() bool { return !vs.isPolicyAllowAny() }, time.Second, 10*time.Millisecond)
+
+	// updating external-se doesn't break external-se, it breaks external-dr
+	se := models.IstioConfigDetails{ObjectType: kubernetes.ServiceEntries, ServiceEntry: data.CreateEmptyMeshExternalServiceEntry("external-se", "test", []string{"other.example.com"})}
+	newErrors, err := vs.GetDryRunNewErrors(context.TODO(), kubernetes.HomeClusterName, "test", kubernetes.ServiceEntries, se)
+	require.NoError(err)
+	require.Len(newErrors, 1)
+	drErrors := newErrors[models.IstioValidationKey{ObjectType: "destinationrule", Namespace: "test", Name: "external-dr"}]
+	require.Len(drErrors, 1)
+	assert.Equal("KIA0202", drErrors[0].Code)
+
+	// the errors already reported before the change are not new
+	se = models.IstioConfigDetails{ObjectType: kubernetes.ServiceEntries, ServiceEntry: data.CreateEmptyMeshExternalServiceEntry("external-se", "test", []string{"external.example.com", "other.example.com"})}
+	newErrors, err = vs.GetDryRunNewErrors(context.TODO(), kubernetes.HomeClusterName, "test", kubernetes.ServiceEntries, se)
+	require.NoError(err)
+	assert.Empty(newErrors)
+}
@@ -2,6 +2,7 @@ package business
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"k8s.io/client-go/tools/clientcmd/api"
@@ -19,6 +20,7 @@ import (
 // needs to be saved across layers is saved in the Kiali Cache.
 type Layer struct {
 	App              AppService
+	Authorization    AuthorizationService
 	Health           HealthService
 	IstioConfig      IstioConfigService
 	IstioStatus      IstioStatusService
@@ -32,6 +34,7 @@ type Layer struct {
 	ProxyStatus      ProxyStatusService
 	RegistryStatus   RegistryStatusService
 	RegistryStatuses map[string]RegistryStatusService // Key is the cluster name
+	SLO              SLOService
 	Svc              SvcService
 	TLS              TLSService
 	TokenReview      TokenReviewService
@@ -46,6 +49,8 @@ var (
 	kialiCache       cache.KialiCache
 	once             sync.Once
 	prometheusClient prometheus.ClientInterface
+	// prometheusLock guards the creation of the global prometheus client, shared by the user and background layers
+	prometheusLock sync.Mutex
 )
 
 // sets the global kiali cache var.
@@ -116,6 +121,20 @@ func Start() {
 	once.Do(initKialiCache)
 }
 
+// getPrometheusClient returns the global Prometheus client, created on first use
+func getPrometheusClient() (prometheus.ClientInterface, error) {
+	prometheusLock.Lock()
+	defer prometheusLock.Unlock()
+	if prometheusClient == nil {
+		prom, err := prometheus.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		prometheusClient = prom
+	}
+	return prometheusClient, nil
+}
+
 // Get the business.Layer
 func Get(authInfo *api.AuthInfo) (*Layer, error) {
 	// Creates new k8s clients based on the current users token
@@ -124,14 +143,9 @@ func Get(authInfo *api.AuthInfo) (*Layer, error) {
 		return nil, err
 	}
 
-	// Use an existing Prometheus client if it exists, otherwise create and use in the future
-	if prometheusClient == nil {
-		prom, err := prometheus.NewClient()
-		if err != nil {
-			prometheusClient = nil
-			return nil, err
-		}
-		prometheusClient = prom
+	prom, err := getPrometheusClient()
+	if err != nil {
+		return nil, err
 	}
 
 	// Create Jaeger client
@@ -147,14 +161,32 @@ func Get(authInfo *api.AuthInfo) (*Layer, error) {
 	}
 
 	kialiSAClient := clientFactory.GetSAClients()
-	return NewWithBackends(userClients, kialiSAClient, prometheusClient, jaegerLoader), nil
+	return NewWithBackends(userClients, kialiSAClient, prom, jaegerLoader), nil
+}
+
+// GetWithSAClients returns a business layer using the Kiali ServiceAccount clients as the user clients.
+// It must only be used by the background tasks that don't serve a user request, i.e. the status metrics.
+func GetWithSAClients() (*Layer, error) {
+	if clientFactory == nil {
+		return nil, fmt.Errorf("the business layer is not started")
+	}
+
+	prom, err := getPrometheusClient()
+	if err != nil {
+		return nil, err
+	}
+
+	kialiSAClients := clientFactory.GetSAClients()
+	return NewWithBackends(kialiSAClients, kialiSAClients, prom, nil), nil
 }
 
 // SetWithBackends allows for specifying the ClientFactory and Prometheus clients to be used.
 // Mock friendly. Used only with tests.
 func SetWithBackends(cf kubernetes.ClientFactory, prom prometheus.ClientInterface) {
 	clientFactory = cf
+	prometheusLock.Lock()
 	prometheusClient = prom
+	prometheusLock.Unlock()
 }
 
 // NewWithBackends creates the business layer using the passed k8sClients and prom clients.
@@ -165,6 +197,7 @@ func NewWithBackends(userClients map[string]kubernetes.ClientInterface, kialiSAC
 	homeClusterName := config.Get().KubernetesConfig.ClusterName
 	// TODO: Modify the k8s argument to other services to pass the whole k8s map if needed
 	temporaryLayer.App = AppService{prom: prom, userClients: userClients, businessLayer: temporaryLayer}
+	temporaryLayer.Authorization = AuthorizationService{userClients: userClients, kialiCache: kialiCache, businessLayer: temporaryLayer}
 	temporaryLayer.Health = HealthService{prom: prom, businessLayer: temporaryLayer, userClients: userClients}
 	temporaryLayer.IstioConfig = IstioConfigService{config: *config.Get(), userClients: userClients, kialiCache: kialiCache, businessLayer: temporaryLayer}
 	temporaryLayer.IstioStatus = IstioStatusService{k8s: userClients[homeClusterName], businessLayer: temporaryLayer}
@@ -178,6 +211,7 @@ func NewWithBackends(userClients map[string]kubernetes.ClientInterface, kialiSAC
 	// Out of order because it relies on ProxyStatus
 	temporaryLayer.ProxyLogging = ProxyLoggingService{userClients: userClients, proxyStatus: &temporaryLayer.ProxyStatus}
 	temporaryLayer.RegistryStatus = RegistryStatusService{k8s: userClients[homeClusterName], businessLayer: temporaryLayer}
+	temporaryLayer.SLO = SLOService{prom: prom, businessLayer: temporaryLayer}
 	temporaryLayer.TLS = TLSService{userClients: userClients, kialiCache: kialiCache, businessLayer: temporaryLayer}
 	temporaryLayer.Svc = SvcService{config: *config.Get(), kialiCache: kialiCache, businessLayer: temporaryLayer, prom: prom, userClients: userClients}
 	temporaryLayer.TokenReview = NewTokenReview(userClients[homeClusterName])
@@ -0,0 +1,216 @@
+package business
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// SLOService computes the service level objectives of the services from the Istio request metrics
+type SLOService struct {
+	prom          prometheus.ClientInterface
+	businessLayer *Layer
+}
+
+// GetNamespaceSLOs returns the SLO statuses of the services of the namespace with SLOs, keyed by service name
+func (in *SLOService) GetNamespaceSLOs(ctx context.Context, namespace, cluster string, queryTime time.Time) (models.NamespaceSLOs, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceSLOs",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("queryTime", queryTime),
+	)
+	defer end()
+
+	services, err := in.fetchServices(ctx, namespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return in.getSLOs(namespace, cluster, services, queryTime)
+}
+
+// GetServiceSLOs returns the SLO statuses of a service, empty when the service has no SLOs
+func (in *SLOService) GetServiceSLOs(ctx context.Context, namespace, cluster, service string, queryTime time.Time) ([]models.SLOStatus, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetServiceSLOs",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("service", service),
+		observability.Attribute("queryTime", queryTime),
+	)
+	defer end()
+
+	services, err := in.fetchServices(ctx, namespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services {
+		if svc.Name != service {
+			continue
+		}
+		slos, err := in.getSLOs(namespace, cluster, []models.ServiceOverview{svc}, queryTime)
+		if err != nil {
+			return nil, err
+		}
+		if statuses, ok := slos[service]; ok {
+			return statuses, nil
+		}
+		return []models.SLOStatus{}, nil
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{Group: "", Resource: "services"}, service)
+}
+
+func (in *SLOService) fetchServices(ctx context.Context, namespace, cluster string) ([]models.ServiceOverview, error) {
+	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+	criteria := ServiceCriteria{
+		Namespace:              namespace,
+		IncludeHealth:          false,
+		IncludeIstioResources:  false,
+		IncludeOnlyDefinitions: true,
+	}
+	services, err := in.businessLayer.Svc.GetServiceListForCluster(ctx, criteria, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return services.Services, nil
+}
+
+// getSLOs computes the SLO statuses of the services. The request rates are queried once per window, and per latency
+// threshold, for all the services of the namespace, and the SLIs are cached for the shortest window.
+func (in *SLOService) getSLOs(namespace, cluster string, services []models.ServiceOverview, queryTime time.Time) (models.NamespaceSLOs, error) {
+	allSLOs := make(models.NamespaceSLOs)
+	querySLIs := map[string]map[string]float64{}
+
+	for _, svc := range services {
+		slos := models.GetServiceSLOs(namespace, svc.Name, svc.HealthAnnotations)
+		if len(slos) == 0 {
+			continue
+		}
+		statuses := make([]models.SLOStatus, 0, len(slos))
+		for _, slo := range slos {
+			windows := append([]string{slo.Window}, models.SLOBurnRateWindows...)
+			slis := make(map[string]float64, len(windows))
+			for _, window := range windows {
+				query := slo.Type + "/" + window
+				fetch := func() (map[string]float64, error) {
+					return in.fetchAvailabilitySLIs(namespace, cluster, window, queryTime)
+				}
+				if slo.Type == models.SLOTypeLatency {
+					threshold := strconv.FormatFloat(slo.LatencyThreshold, 'f', -1, 64)
+					query = slo.Type + "/" + threshold + "/" + window
+					fetch = func() (map[string]float64, error) {
+						return in.fetchLatencySLIs(namespace, cluster, threshold, window, queryTime)
+					}
+				}
+				windowSLIs, ok := querySLIs[query]
+				if !ok {
+					var err error
+					if windowSLIs, err = in.getSLIs(namespace, cluster, query, queryTime, fetch); err != nil {
+						return nil, err
+					}
+					querySLIs[query] = windowSLIs
+				}
+				sli, ok := windowSLIs[svc.Name]
+				if !ok {
+					sli = -1
+				}
+				slis[window] = sli
+			}
+			statuses = append(statuses, models.NewSLOStatus(slo, slis[slo.Window], slis))
+		}
+		allSLOs[svc.Name] = statuses
+	}
+	return allSLOs, nil
+}
+
+// getSLIs returns the SLIs of the query from the Kiali cache or, when they are not cached, fetches and caches them
+func (in *SLOService) getSLIs(namespace, cluster, query string, queryTime time.Time, fetch func() (map[string]float64, error)) (map[string]float64, error) {
+	if kialiCache != nil {
+		if slis, found := kialiCache.GetSLIs(cluster, namespace, query, queryTime); found {
+			return slis, nil
+		}
+	}
+	slis, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if kialiCache != nil {
+		kialiCache.SetSLIs(cluster, namespace, query, queryTime, slis)
+	}
+	return slis, nil
+}
+
+// fetchAvailabilitySLIs returns the availability SLIs of the services of the namespace over the window, the services
+// without requests are not returned
+func (in *SLOService) fetchAvailabilitySLIs(namespace, cluster, window string, queryTime time.Time) (map[string]float64, error) {
+	rates, err := in.prom.GetNamespaceServicesRequestRates(namespace, cluster, window, queryTime)
+	if err != nil {
+		return nil, errors.NewServiceUnavailable(err.Error())
+	}
+	requests := map[string]*models.RequestHealth{}
+	lblDestSvc := model.LabelName("destination_service_name")
+	for _, sample := range rates {
+		service := string(sample.Metric[lblDestSvc])
+		rqHealth, ok := requests[service]
+		if !ok {
+			h := models.NewEmptyRequestHealth()
+			rqHealth = &h
+			requests[service] = rqHealth
+		}
+		rqHealth.AggregateInbound(sample)
+	}
+	slis := make(map[string]float64, len(requests))
+	for service, rqHealth := range requests {
+		rqHealth.CombineReporters()
+		if sli := rqHealth.AvailabilitySLI(); sli >= 0 {
+			slis[service] = sli
+		}
+	}
+	return slis, nil
+}
+
+// fetchLatencySLIs returns the latency SLIs of the services of the namespace over the window, the percentage of the
+// requests answered within the threshold. The services without requests, or without the histogram bucket of the
+// threshold, are not returned.
+func (in *SLOService) fetchLatencySLIs(namespace, cluster, threshold, window string, queryTime time.Time) (map[string]float64, error) {
+	within, total, err := in.prom.GetNamespaceServicesLatencyRates(namespace, cluster, threshold, window, queryTime)
+	if err != nil {
+		return nil, errors.NewServiceUnavailable(err.Error())
+	}
+	lblDestSvc := model.LabelName("destination_service_name")
+	withinRates := make(map[string]float64, len(within))
+	for _, sample := range within {
+		withinRates[string(sample.Metric[lblDestSvc])] = float64(sample.Value)
+	}
+	slis := make(map[string]float64, len(total))
+	for _, sample := range total {
+		if sample.Value <= 0 {
+			continue
+		}
+		service := string(sample.Metric[lblDestSvc])
+		withinRate, ok := withinRates[service]
+		if !ok {
+			// the threshold is not a bucket of the histogram, there is no data to compute the SLI
+			continue
+		}
+		sli := 100 * withinRate / float64(sample.Value)
+		if sli > 100 {
+			// the histogram and the counter are not scraped at the same time
+			sli = 100
+		}
+		slis[service] = sli
+	}
+	return slis, nil
+}
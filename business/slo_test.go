@@ -0,0 +1,161 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func setupSLOs(t *testing.T) (*config.Config, *prometheustest.PromClientMock, *Layer) {
+	conf := config.NewConfig()
+	conf.HealthConfig.SLOs = []config.SLO{
+		{Namespace: "tutorial", Service: "^reviews$", Type: models.SLOTypeAvailability, Target: 99, Window: "1d"},
+		{Name: "fast", Namespace: "tutorial", Service: "^httpbin$", Type: models.SLOTypeLatency, Target: 90, Window: "1d", LatencyThreshold: 250},
+	}
+	config.Set(conf)
+	reviews := kubetest.FakeService("tutorial", "reviews")
+	httpbin := kubetest.FakeService("tutorial", "httpbin")
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&reviews,
+		&httpbin,
+	)
+	k8s.OpenShift = true
+	prom := new(prometheustest.PromClientMock)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	reviewsRates := model.Vector{
+		&model.Sample{Metric: model.Metric{"destination_service_name": "reviews", "request_protocol": "http", "response_code": "200"}, Value: 98},
+		&model.Sample{Metric: model.Metric{"destination_service_name": "reviews", "request_protocol": "http", "response_code": "503"}, Value: 2},
+	}
+	prom.On("GetNamespaceServicesRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(reviewsRates, nil)
+	within := model.Vector{&model.Sample{Metric: model.Metric{"destination_service_name": "httpbin"}, Value: 95}}
+	total := model.Vector{&model.Sample{Metric: model.Metric{"destination_service_name": "httpbin"}, Value: 100}}
+	prom.On("GetNamespaceServicesLatencyRates", "tutorial", conf.KubernetesConfig.ClusterName, "250", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(within, total, nil)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return conf, prom, NewWithBackends(clients, clients, prom, nil)
+}
+
+func TestGetNamespaceSLOs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf, prom, layer := setupSLOs(t)
+
+	slos, err := layer.SLO.GetNamespaceSLOs(context.TODO(), "tutorial", conf.KubernetesConfig.ClusterName, time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(err)
+	require.Len(slos, 2)
+
+	// The rates are queried once per window
+	prom.AssertNumberOfCalls(t, "GetNamespaceServicesRequestRates", 1+len(models.SLOBurnRateWindows))
+	prom.AssertNumberOfCalls(t, "GetNamespaceServicesLatencyRates", 1+len(models.SLOBurnRateWindows))
+
+	require.Len(slos["reviews"], 1)
+	reviews := slos["reviews"][0]
+	assert.Equal("availability-1d", reviews.Name)
+	assert.InDelta(98, reviews.SLI, 0.0001)
+	assert.InDelta(-100, reviews.ErrorBudgetRemaining, 0.0001)
+	assert.True(reviews.Exhausted)
+	require.Len(reviews.BurnRates, len(models.SLOBurnRateWindows))
+	assert.InDelta(2, reviews.BurnRates[0].Rate, 0.0001)
+
+	require.Len(slos["httpbin"], 1)
+	httpbin := slos["httpbin"][0]
+	assert.Equal("fast", httpbin.Name)
+	assert.InDelta(95, httpbin.SLI, 0.0001)
+	assert.InDelta(50, httpbin.ErrorBudgetRemaining, 0.0001)
+	assert.False(httpbin.Exhausted)
+}
+
+func TestGetNamespaceSLOsCached(t *testing.T) {
+	require := require.New(t)
+
+	conf, prom, layer := setupSLOs(t)
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := layer.SLO.GetNamespaceSLOs(context.TODO(), "tutorial", conf.KubernetesConfig.ClusterName, queryTime)
+	require.NoError(err)
+	// The SLIs are not queried again during the shortest window
+	slos, err := layer.SLO.GetServiceSLOs(context.TODO(), "tutorial", conf.KubernetesConfig.ClusterName, "reviews", queryTime.Add(time.Minute))
+	require.NoError(err)
+	require.Len(slos, 1)
+	assert.InDelta(t, 98, slos[0].SLI, 0.0001)
+	prom.AssertNumberOfCalls(t, "GetNamespaceServicesRequestRates", 1+len(models.SLOBurnRateWindows))
+	prom.AssertNumberOfCalls(t, "GetNamespaceServicesLatencyRates", 1+len(models.SLOBurnRateWindows))
+
+	_, err = layer.SLO.GetNamespaceSLOs(context.TODO(), "tutorial", conf.KubernetesConfig.ClusterName, queryTime.Add(10*time.Minute))
+	require.NoError(err)
+	prom.AssertNumberOfCalls(t, "GetNamespaceServicesRequestRates", 2*(1+len(models.SLOBurnRateWindows)))
+}
+
+func TestGetLatencySLOsWithoutBucket(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf, prom, layer := setupSLOs(t)
+	// The series of the bucket is missing when the threshold is not a bucket of the histogram
+	prom.ExpectedCalls = nil
+	total := model.Vector{&model.Sample{Metric: model.Metric{"destination_service_name": "httpbin"}, Value: 100}}
+	prom.On("GetNamespaceServicesRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(model.Vector{}, nil)
+	prom.On("GetNamespaceServicesLatencyRates", "tutorial", conf.KubernetesConfig.ClusterName, "250", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(model.Vector{}, total, nil)
+
+	slos, err := layer.SLO.GetServiceSLOs(context.TODO(), "tutorial", conf.KubernetesConfig.ClusterName, "httpbin", time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(err)
+	require.Len(slos, 1)
+	assert.Equal(float64(-1), slos[0].SLI)
+	assert.Equal(float64(100), slos[0].ErrorBudgetRemaining)
+	assert.False(slos[0].Exhausted)
+}
+
+func TestGetServiceSLOs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf, _, layer := setupSLOs(t)
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	slos, err := layer.SLO.GetServiceSLOs(context.TODO(), "tutorial", conf.KubernetesConfig.ClusterName, "httpbin", queryTime)
+	require.NoError(err)
+	require.Len(slos, 1)
+	assert.Equal("fast", slos[0].Name)
+
+	_, err = layer.SLO.GetServiceSLOs(context.TODO(), "tutorial", conf.KubernetesConfig.ClusterName, "ratings", queryTime)
+	assert.Error(err)
+}
+
+func TestGetNamespaceServiceHealthWithSLOs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf, prom, layer := setupSLOs(t)
+	hs := HealthService{prom: prom, businessLayer: layer, userClients: layer.k8sClients}
+
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC), IncludeMetrics: true}
+	health, err := hs.GetNamespaceServiceHealth(context.TODO(), criteria)
+	require.NoError(err)
+
+	// The error rate of reviews is 2%, degraded by the default tolerances, and its error budget is exhausted
+	assert.Equal(models.HealthStatusFailure, health["reviews"].Status.Status)
+	require.Len(health["reviews"].Status.Reasons, 2)
+	slo := ""
+	for _, reason := range health["reviews"].Status.Reasons {
+		slo += reason.SLO
+	}
+	assert.Equal("availability-1d", slo)
+
+	assert.NotEqual(models.HealthStatusFailure, health["httpbin"].Status.Status)
+}
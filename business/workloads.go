@@ -215,7 +215,14 @@ func (in *WorkloadService) GetWorkloadList(ctx context.Context, criteria Workloa
 	allWorkloads := map[string]models.WorkloadList{}
 	allWorkloads[criteria.Namespace] = *workloadList
 	validations := in.getWorkloadValidations(authpolicies, allWorkloads)
-	validations.StripIgnoredChecks()
+	ignoredChecks := models.NewIgnoredChecks()
+	for _, w := range workloadList.Workloads {
+		ignoredChecks.AddObject(models.IstioValidationKey{ObjectType: checkers.WorkloadCheckerType, Namespace: criteria.Namespace, Name: w.Name}, w.Annotations)
+	}
+	if ns, err := in.businessLayer.Namespace.GetNamespace(ctx, criteria.Namespace); err == nil {
+		ignoredChecks.AddNamespace(ns.Name, ns.Annotations)
+	}
+	validations.StripIgnoredChecks(ignoredChecks)
 	workloadList.Validations = validations
 	return *workloadList, nil
 }
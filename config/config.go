@@ -81,8 +81,26 @@ var overrideSecretsDir = "/kiali-override-secrets"
 
 // Metrics provides metrics configuration for the Kiali server.
 type Metrics struct {
+	Enabled bool          `yaml:"enabled,omitempty"`
+	Port    int           `yaml:"port,omitempty"`
+	Status  StatusMetrics `yaml:"status,omitempty"`
+}
+
+// StatusMetrics configures the background collector that publishes the validation summaries and the health statuses
+// of the namespaces as gauges of the metrics server.
+type StatusMetrics struct {
 	Enabled bool `yaml:"enabled,omitempty"`
-	Port    int  `yaml:"port,omitempty"`
+	// Namespaces collected, all the namespaces accessible by the Kiali service account when empty
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	// Maximum number of namespaces collected, the first ones in alphabetical order
+	MaxNamespaces int `yaml:"max_namespaces,omitempty"`
+	// Maximum number of apps, services and workloads of each namespace with a health status gauge, the counts of
+	// items per health status include all of them
+	MaxItemsPerNamespace int `yaml:"max_items_per_namespace,omitempty"`
+	// Rate interval of the request rates evaluated by the health
+	RateInterval string `yaml:"rate_interval,omitempty"`
+	// Interval between two collections
+	RefreshInterval string `yaml:"refresh_interval,omitempty"`
 }
 
 // Tracing provides tracing configuration for the Kiali server.
@@ -290,6 +308,8 @@ type KubernetesConfig struct {
 	CacheEnabled bool `yaml:"-,omitempty"`
 	// Kiali can cache VirtualService,DestinationRule,Gateway and ServiceEntry Istio resources if they are present
 	// on this list of Istio types. Other Istio types are not yet supported.
+	// The experimental K8s Gateway API routes (K8sGRPCRoute, K8sTCPRoute and K8sTLSRoute) are only cached when added
+	// to this list, and the K8s Gateway API types not served by the cluster are never cached.
 	CacheIstioTypes []string `yaml:"cache_istio_types,omitempty"`
 	// List of namespaces or regex defining namespaces to include in a cache
 	CacheNamespaces []string `yaml:"cache_namespaces,omitempty"`
@@ -433,8 +453,27 @@ type UIDefaults struct {
 
 // Validations defines default settings configured for the Validations subsystem
 type Validations struct {
-	Ignore                   []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
-	SkipWildcardGatewayHosts bool     `yaml:"skip_wildcard_gateway_hosts,omitempty"`
+	Ignore []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+	// RejectOnError blocks the Istio config creates and updates that would introduce new validation errors, to the
+	// changed object or to the other objects validated with its namespace
+	RejectOnError            bool             `yaml:"reject_on_error,omitempty" json:"rejectOnError,omitempty"`
+	Rules                    []ValidationRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+	SkipWildcardGatewayHosts bool             `yaml:"skip_wildcard_gateway_hosts,omitempty"`
+}
+
+// ValidationRule defines a custom validation of the Istio objects of a type.
+// The Expression is a CEL expression over the object (e.g. "object.spec.http.all(r, has(r.timeout))") that must
+// evaluate to true for the object to be valid. Otherwise a check with the Code, Severity, Message and Path is reported.
+// The NamespaceSelector is a label selector (e.g. "env=prod") that limits the rule to the objects of the matching namespaces.
+// Kiali doesn't start with a rule whose expression doesn't compile, or whose Code is the code of a built-in check.
+type ValidationRule struct {
+	Code              string `yaml:"code" json:"code"`
+	Expression        string `yaml:"expression" json:"expression"`
+	Message           string `yaml:"message" json:"message"`
+	NamespaceSelector string `yaml:"namespace_selector,omitempty" json:"namespaceSelector,omitempty"`
+	ObjectType        string `yaml:"object_type" json:"objectType"`
+	Path              string `yaml:"path,omitempty" json:"path,omitempty"`
+	Severity          string `yaml:"severity,omitempty" json:"severity,omitempty"`
 }
 
 // CertificatesInformationIndicators defines configuration to enable the feature and to grant read permissions to a list of secrets
@@ -471,9 +510,45 @@ type Rate struct {
 	Tolerance []Tolerance `yaml:"tolerance,omitempty" json:"tolerance"`
 }
 
-// HealthConfig rates
+// LatencyTolerance config, the thresholds are the response times in milliseconds of the quantile, i.e. "0.95".
+// Thresholds set to 0 are not checked.
+type LatencyTolerance struct {
+	Quantile  string  `yaml:"quantile,omitempty" json:"quantile"`
+	Degraded  float32 `yaml:"degraded,omitempty" json:"degraded"`
+	Failure   float32 `yaml:"failure,omitempty" json:"failure"`
+	Protocol  string  `yaml:"protocol,omitempty" json:"protocol"`
+	Direction string  `yaml:"direction,omitempty" json:"direction"`
+}
+
+// Latency config
+type Latency struct {
+	Namespace string             `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Kind      string             `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Name      string             `yaml:"name,omitempty" json:"name,omitempty"`
+	Tolerance []LatencyTolerance `yaml:"tolerance,omitempty" json:"tolerance"`
+}
+
+// SLO config, a service level objective of the services matching the namespace and service expressions.
+// Type is "availability", counting the requests without server errors, or "latency", counting the requests answered
+// within the LatencyThreshold in milliseconds, that must be one of the default buckets of the Istio request duration
+// histogram: 0.5, 1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000, 600000, 1800000 or
+// 3600000.
+// Target is the percentage of good requests over the Window, i.e. 99.9 over 30d.
+type SLO struct {
+	Name             string  `yaml:"name,omitempty" json:"name"`
+	Namespace        string  `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Service          string  `yaml:"service,omitempty" json:"service,omitempty"`
+	Type             string  `yaml:"type,omitempty" json:"type"`
+	Target           float64 `yaml:"target,omitempty" json:"target"`
+	LatencyThreshold float64 `yaml:"latency_threshold,omitempty" json:"latencyThreshold,omitempty"`
+	Window           string  `yaml:"window,omitempty" json:"window"`
+}
+
+// HealthConfig rates, latencies and SLOs
 type HealthConfig struct {
-	Rate []Rate `yaml:"rate,omitempty" json:"rate,omitempty"`
+	Latency []Latency `yaml:"latency,omitempty" json:"latency,omitempty"`
+	Rate    []Rate    `yaml:"rate,omitempty" json:"rate,omitempty"`
+	SLOs    []SLO     `yaml:"slos,omitempty" json:"slos,omitempty"`
 }
 
 // Config defines full YAML configuration.
@@ -668,6 +743,10 @@ func NewConfig() (c *Config) {
 							Description: "Find: nodes with the 2 top rankings",
 							Expression:  "rank <= 2",
 						},
+						{
+							Description: "Find: anomalies (requires the anomaly appender)",
+							Expression:  "anomaly",
+						},
 					},
 					HideOptions: []GraphFindOption{
 						{
@@ -714,7 +793,7 @@ func NewConfig() (c *Config) {
 			Burst:                       200,
 			CacheDuration:               5 * 60,
 			CacheEnabled:                true,
-			CacheIstioTypes:             []string{"AuthorizationPolicy", "DestinationRule", "EnvoyFilter", "Gateway", "PeerAuthentication", "RequestAuthentication", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "WasmPlugin", "Telemetry", "K8sGateway", "K8sHTTPRoute"},
+			CacheIstioTypes:             []string{"AuthorizationPolicy", "DestinationRule", "EnvoyFilter", "Gateway", "PeerAuthentication", "RequestAuthentication", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "WasmPlugin", "Telemetry", "K8sGateway", "K8sHTTPRoute", "K8sReferenceGrant"},
 			CacheNamespaces:             []string{".*"},
 			CacheTokenNamespaceDuration: 10,
 			ClusterName:                 "",
@@ -732,6 +811,13 @@ func NewConfig() (c *Config) {
 				Metrics: Metrics{
 					Enabled: true,
 					Port:    9090,
+					Status: StatusMetrics{
+						Enabled:              false,
+						MaxNamespaces:        50,
+						MaxItemsPerNamespace: 100,
+						RateInterval:         "10m",
+						RefreshInterval:      "60s",
+					},
 				},
 				Tracing: Tracing{
 					CollectorURL: "http://jaeger-collector.istio-system:14268/api/traces",
@@ -4,6 +4,8 @@ import (
 	jaegerModels "github.com/kiali/kiali/jaeger/model/json"
 
 	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/api"
 	"github.com/kiali/kiali/graph/config/cytoscape"
 	"github.com/kiali/kiali/jaeger"
 	"github.com/kiali/kiali/kubernetes"
@@ -52,7 +54,7 @@ type AppVersionParam struct {
 	Name string `json:"version"`
 }
 
-// swagger:parameters graphAggregate graphAggregateByService graphApp graphAppVersion graphService graphWorkload
+// swagger:parameters graphAggregate graphAggregateByService graphApp graphAppVersion graphService graphWorkload graphWorkloadImpact
 type ClusterParam struct {
 	// The cluster name. If not supplied queries/results will not be constrained by cluster.
 	//
@@ -79,7 +81,7 @@ type LoggingParam struct {
 	Level ProxyLogLevel `json:"level"`
 }
 
-// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging
+// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging graphWorkloadImpact workloadAuthorization
 type NamespaceParam struct {
 	// The namespace name.
 	//
@@ -116,6 +118,16 @@ type ValidateParam struct {
 	Name string `json:"validate"`
 }
 
+// swagger:parameters namespaceValidations namespacesValidations
+type ValidationsFormatParam struct {
+	// Format of the validations: json for the validation summaries, sarif for a SARIF 2.1.0 report or junit for a
+	// JUnit XML report. When not set, the format is taken from the Accept header (application/sarif+json or application/junit+xml), json by default.
+	//
+	// in: query
+	// required: false
+	Name string `json:"format"`
+}
+
 // swagger:parameters podDetails podLogs podProxyDump podProxyResource podProxyLogging
 type PodParam struct {
 	// The pod name.
@@ -134,7 +146,7 @@ type ResourceParam struct {
 	Name string `json:"resource"`
 }
 
-// swagger:parameters serviceDetails serviceUpdate serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces
+// swagger:parameters serviceDetails serviceUpdate serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces serviceSLOs
 type ServiceParam struct {
 	// The service name.
 	//
@@ -152,6 +164,16 @@ type SinceTimeParam struct {
 	Name string `json:"sinceTime"`
 }
 
+// swagger:parameters istioConfigCreate istioConfigCreateSubtype istioConfigUpdate istioConfigUpdateSubtype
+type DryRunParam struct {
+	// When true, the object is validated together with the existing config and returned with its validation and
+	// references, without persisting it.
+	//
+	// in: query
+	// required: false
+	Name string `json:"dryRun"`
+}
+
 // swagger:parameters podLogs
 type DurationLogParam struct {
 	// Query time-range duration (Golang string duration). Duration starts on
@@ -180,7 +202,7 @@ type DashboardParam struct {
 	Name string `json:"dashboard"`
 }
 
-// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces
+// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces graphWorkloadImpact workloadAuthorization
 type WorkloadParam struct {
 	// The workload name.
 	//
@@ -194,9 +216,29 @@ type WorkloadParam struct {
 // - keep this alphabetized
 /////////////////////
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
+type AnomalyOffsetParam struct {
+	// Used only with anomaly appender. The baseline is the same time range, offset into the past by this duration (Golang string duration, or Prometheus duration like 7d).
+	//
+	// in: query
+	// required: false
+	// default: 1d
+	Name string `json:"anomalyOffset"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
+type AnomalyThresholdParam struct {
+	// Used only with anomaly appender. Traffic is anomalous when it deviates from the baseline by at least this factor (must be greater than 1).
+	//
+	// in: query
+	// required: false
+	// default: 2
+	Name string `json:"anomalyThreshold"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type AppendersParam struct {
-	// Comma-separated list of Appenders to run. Available appenders: [aggregateNode, deadNode, healthConfig, idleNode, istio, responseTime, securityPolicy, serviceEntry, sidecarsCheck, throughput].
+	// Comma-separated list of Appenders to run. Available appenders: [aggregateNode, anomaly, deadNode, healthConfig, idleNode, istio, responseTime, securityPolicy, serviceEntry, sidecarsCheck, throughput]. The anomaly appender is not run by default.
 	//
 	// in: query
 	// required: false
@@ -204,9 +246,9 @@ type AppendersParam struct {
 	Name string `json:"appenders"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphNamespacesStream graphNamespacesSnapshot graphPath
 type BoxByParam struct {
-	// Comma-separated list of desired node boxing. Available boxings: [app, cluster, namespace].
+	// Comma-separated list of desired node boxing. Available boxings: [app, cluster, namespace, label:<name>]. Boxing by label boxes nodes in the same namespace with the same value for the k8s label, only one label box is supported.
 	//
 	// in: query
 	// required: false
@@ -214,6 +256,54 @@ type BoxByParam struct {
 }
 
 // swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+type CompareDurationParam struct {
+	// Baseline query time-range duration (Golang string duration). Used only with compareTime.
+	//
+	// in: query
+	// required: false
+	// default: duration
+	Name string `json:"compareDuration"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+type CompareTimeParam struct {
+	// Unix time (seconds) for a baseline query such that the baseline time range is [compareTime-compareDuration..compareTime]. When set, a diff graph is returned, tagging each node and edge as added, changed, removed or unchanged, with rate deltas.
+	//
+	// in: query
+	// required: false
+	Name string `json:"compareTime"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphPath
+type ConfigVendorParam struct {
+	// The graph output format. One of: cytoscape (JSON) | dot (Graphviz) | graphml | mermaid.
+	//
+	// in: query
+	// required: false
+	// default: cytoscape
+	Name string `json:"configVendor"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphNamespacesSnapshot graphPath
+type DebugParam struct {
+	// Flag for adding the graph generation diagnostics (per-appender timing, queries and node/edge counts) to the response. Supported only for configVendor cytoscape.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name string `json:"debug"`
+}
+
+// swagger:parameters graphPath
+type DestParam struct {
+	// The dest node of the traffic paths, in the form [<cluster>/]<namespace>/(workloads|services)/<name>.
+	//
+	// in: query
+	// required: true
+	Name string `json:"dest"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type DurationGraphParam struct {
 	// Query time-range duration (Golang string duration).
 	//
@@ -223,7 +313,7 @@ type DurationGraphParam struct {
 	Name string `json:"duration"`
 }
 
-// swagger:parameters graphNamespaces graphService graphWorkload
+// swagger:parameters graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type GraphTypeParam struct {
 	// Graph type. Available graph types: [app, service, versionedApp, workload].
 	//
@@ -242,7 +332,7 @@ type AppGraphTypeParam struct {
 	Name string `json:"graphType"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type IncludeIdleEdges struct {
 	// Flag for including edges that have no request traffic for the time period.
 	//
@@ -252,7 +342,7 @@ type IncludeIdleEdges struct {
 	Name string `json:"includeIdleEdges"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type InjectServiceNodes struct {
 	// Flag for injecting the requested service node between source and destination nodes.
 	//
@@ -262,7 +352,7 @@ type InjectServiceNodes struct {
 	Name string `json:"injectServiceNodes"`
 }
 
-// swagger:parameters graphNamespaces
+// swagger:parameters graphNamespaces graphNamespacesStream graphNamespacesSnapshot
 type NamespacesParam struct {
 	// Comma-separated list of namespaces to include in the graph. The namespaces must be accessible to the client.
 	//
@@ -271,7 +361,25 @@ type NamespacesParam struct {
 	Name string `json:"namespaces"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphWorkloadImpact
+type ImpactNamespacesParam struct {
+	// Comma-separated list of namespaces to analyze, in addition to the workload namespace. The namespaces must be accessible to the client.
+	//
+	// in: query
+	// required: false
+	Name string `json:"namespaces"`
+}
+
+// swagger:parameters graphPath
+type PathNamespacesParam struct {
+	// Comma-separated list of namespaces for the traffic paths, in addition to the source and dest namespaces. The namespaces must be accessible to the client.
+	//
+	// in: query
+	// required: false
+	Name string `json:"namespaces"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesSnapshot graphPath
 type QueryTimeParam struct {
 	// Unix time (seconds) for query such that time range is [queryTime-duration..queryTime]. Default is now.
 	//
@@ -281,7 +389,7 @@ type QueryTimeParam struct {
 	Name string `json:"queryTime"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type RateGrpcParam struct {
 	// How to calculate gRPC traffic rate. One of: none | received (i.e. response_messages) | requests | sent (i.e. request_messages) | total (i.e. sent+received).
 	//
@@ -291,7 +399,7 @@ type RateGrpcParam struct {
 	Name string `json:"rateGrpc"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type RateHttpParam struct {
 	// How to calculate HTTP traffic rate. One of: none | requests.
 	//
@@ -301,7 +409,37 @@ type RateHttpParam struct {
 	Name string `json:"rateHttp"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
+type RateKafkaParam struct {
+	// How to calculate Kafka traffic rate, reported by the Envoy kafka_broker filter of the destination workloads. One of: none | requests.
+	//
+	// in: query
+	// required: false
+	// default: none
+	Name string `json:"rateKafka"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
+type RateMysqlParam struct {
+	// How to calculate MySQL traffic rate, reported by the Envoy mysql_proxy filter of the destination workloads. One of: none | requests (i.e. queries).
+	//
+	// in: query
+	// required: false
+	// default: none
+	Name string `json:"rateMysql"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
+type RateRedisParam struct {
+	// How to calculate Redis traffic rate, reported by the Envoy redis_proxy filter of the destination workloads. One of: none | requests (i.e. commands).
+	//
+	// in: query
+	// required: false
+	// default: none
+	Name string `json:"rateRedis"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type RateTcpParam struct {
 	// How to calculate TCP traffic rate. One of: none | received (i.e. received_bytes) | sent (i.e. sent_bytes) | total (i.e. sent+received).
 	//
@@ -311,7 +449,17 @@ type RateTcpParam struct {
 	Name string `json:"rateTcp"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphNamespacesStream
+type RefreshIntervalParam struct {
+	// The interval at which the graph is regenerated (Golang string duration, minimum 5s).
+	//
+	// in: query
+	// required: false
+	// default: 15s
+	Name string `json:"refreshInterval"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type ResponseTimeParam struct {
 	// Used only with responseTime appender. One of: avg | 50 | 95 | 99.
 	//
@@ -321,7 +469,16 @@ type ResponseTimeParam struct {
 	Name string `json:"responseTime"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphPath
+type SourceParam struct {
+	// The source node of the traffic paths, in the form [<cluster>/]<namespace>/(workloads|services)/<name>.
+	//
+	// in: query
+	// required: true
+	Name string `json:"source"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload graphWorkloadImpact graphNamespacesStream graphNamespacesSnapshot graphPath
 type ThroughputParam struct {
 	// Used only with throughput appender. One of: request | response.
 	//
@@ -474,6 +631,83 @@ type VersionParam struct {
 	Name string `json:"version"`
 }
 
+/////////////////////
+// SWAGGER PARAMETERS - AUTHORIZATION
+// - keep this alphabetized
+/////////////////////
+
+// swagger:parameters workloadAuthorization
+type AccessHostParam struct {
+	// The destination host of the request.
+	//
+	// in: query
+	// required: false
+	Name string `json:"host"`
+}
+
+// swagger:parameters workloadAuthorization
+type AccessMethodParam struct {
+	// The HTTP method of the request.
+	//
+	// in: query
+	// required: false
+	Name string `json:"method"`
+}
+
+// swagger:parameters workloadAuthorization
+type AccessPathParam struct {
+	// The HTTP path of the request.
+	//
+	// in: query
+	// required: false
+	Name string `json:"path"`
+}
+
+// swagger:parameters workloadAuthorization
+type AccessPortParam struct {
+	// The destination port of the request.
+	//
+	// in: query
+	// required: false
+	Name string `json:"port"`
+}
+
+// swagger:parameters workloadAuthorization
+type AccessRequestPrincipalParam struct {
+	// The request principal, as set by a RequestAuthentication, in the form <issuer>/<subject>.
+	//
+	// in: query
+	// required: false
+	Name string `json:"requestPrincipal"`
+}
+
+// swagger:parameters workloadAuthorization
+type AccessSourceNamespaceParam struct {
+	// The namespace of the source workload. Used to build the source principal when sourcePrincipal is not set.
+	//
+	// in: query
+	// required: false
+	Name string `json:"sourceNamespace"`
+}
+
+// swagger:parameters workloadAuthorization
+type AccessSourcePrincipalParam struct {
+	// The source principal, in the form <trust domain>/ns/<namespace>/sa/<service account>.
+	//
+	// in: query
+	// required: false
+	Name string `json:"sourcePrincipal"`
+}
+
+// swagger:parameters workloadAuthorization
+type AccessSourceServiceAccountParam struct {
+	// The service account of the source workload. Used with sourceNamespace to build the source principal, defaults to default.
+	//
+	// in: query
+	// required: false
+	Name string `json:"sourceServiceAccount"`
+}
+
 /////////////////////
 // SWAGGER RESPONSES
 /////////////////////
@@ -582,6 +816,20 @@ type GraphResponse struct {
 	Body cytoscape.Config
 }
 
+// HTTP status code 200 and Impact model in data
+// swagger:response graphImpactResponse
+type GraphImpactResponse struct {
+	// in:body
+	Body graph.Impact
+}
+
+// HTTP status code 200 and Snapshot model in data
+// swagger:response graphSnapshotResponse
+type GraphSnapshotResponse struct {
+	// in:body
+	Body api.Snapshot
+}
+
 // HTTP status code 200 and IstioConfigList model in data
 // swagger:response istioConfigList
 type IstioConfigResponse struct {
@@ -617,6 +865,27 @@ type namespaceAppHealthResponse struct {
 	Body models.NamespaceAppHealth
 }
 
+// namespaceHealthHistoryResponse is a map of app, service or workload name x health history
+// swagger:response namespaceHealthHistoryResponse
+type namespaceHealthHistoryResponse struct {
+	// in:body
+	Body models.NamespaceHealthHistory
+}
+
+// namespaceSLOsResponse is a map of service name x SLO statuses
+// swagger:response namespaceSLOsResponse
+type namespaceSLOsResponse struct {
+	// in:body
+	Body models.NamespaceSLOs
+}
+
+// serviceSLOsResponse is a list of SLO statuses of a service
+// swagger:response serviceSLOsResponse
+type serviceSLOsResponse struct {
+	// in:body
+	Body []models.SLOStatus
+}
+
 // namespaceResponse is a basic namespace
 // swagger:response namespaceResponse
 type namespaceResponse struct {
@@ -652,6 +921,13 @@ type SpansResponse struct {
 	Body []jaeger.JaegerSpan
 }
 
+// HTTP status code 200 and AccessDecision model in data
+// swagger:response accessDecisionResponse
+type AccessDecisionResponse struct {
+	// in:body
+	Body models.AccessDecision
+}
+
 // Listing all the information related to a workload
 // swagger:response workloadDetails
 type WorkloadDetailsResponse struct {
@@ -46,6 +46,7 @@ export const CyEdgeResponses = {
 export const CyNode = {
   aggregate: 'aggregate',
   aggregateValue: 'aggregateValue',
+  anomaly: 'anomaly',
   app: 'app',
   cluster: 'cluster',
   destServices: 'destServices',
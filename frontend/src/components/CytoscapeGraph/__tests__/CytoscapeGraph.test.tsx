@@ -36,6 +36,7 @@ describe('CytoscapeGraph component test', () => {
       duration: 60,
       edgeLabels: myEdgeLabelMode,
       graphType: GraphType.VERSIONED_APP,
+      includeAnomalies: false,
       includeHealth: false,
       includeLabels: false,
       injectServiceNodes: true,
@@ -62,6 +63,7 @@ describe('CytoscapeGraph component test', () => {
               duration: 60,
               edgeLabels: myEdgeLabelMode,
               graphType: GraphType.VERSIONED_APP,
+              includeAnomalies: false,
               includeHealth: false,
               includeLabels: false,
               injectServiceNodes: true,
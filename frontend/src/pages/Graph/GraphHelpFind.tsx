@@ -214,6 +214,7 @@ export default class GraphHelpFind extends React.Component<GraphHelpFindProps> {
       ['tcpin <op> <number>', 'unit: bytes per second'],
       ['tcpout <op> <number>', 'unit: bytes per second'],
       ['workload <op> <workloadName>'],
+      ['anomaly', 'incoming traffic deviates from the baseline'],
       ['circuitbreaker'],
       ['faultinjection'],
       ['healthy', 'is not degraded or failing.'],
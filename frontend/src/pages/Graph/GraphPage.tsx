@@ -396,9 +396,11 @@ export class GraphPage extends React.Component<GraphPageProps, GraphPageState> {
       (prev.edgeLabels !== curr.edgeLabels && // test for edge labels that invoke graph gen appenders
         (curr.edgeLabels.includes(EdgeLabelMode.RESPONSE_TIME_GROUP) ||
           curr.edgeLabels.includes(EdgeLabelMode.THROUGHPUT_GROUP))) ||
-      (prev.findValue !== curr.findValue && curr.findValue.includes('label:')) ||
+      (prev.findValue !== curr.findValue &&
+        (curr.findValue.includes('label:') || curr.findValue.includes('anomaly'))) ||
       prev.graphType !== curr.graphType ||
-      (prev.hideValue !== curr.hideValue && curr.hideValue.includes('label:')) ||
+      (prev.hideValue !== curr.hideValue &&
+        (curr.hideValue.includes('label:') || curr.hideValue.includes('anomaly'))) ||
       (prev.lastRefreshAt !== curr.lastRefreshAt && curr.replayQueryTime === 0) ||
       (prev.replayActive !== curr.replayActive && !curr.replayActive) ||
       prev.replayQueryTime !== curr.replayQueryTime ||
@@ -840,6 +842,7 @@ export class GraphPage extends React.Component<GraphPageProps, GraphPageState> {
       duration: this.props.duration,
       edgeLabels: this.props.edgeLabels,
       graphType: this.props.graphType,
+      includeAnomalies: this.props.findValue.includes('anomaly') || this.props.hideValue.includes('anomaly'),
       includeHealth: true,
       includeLabels: this.props.findValue.includes('label:') || this.props.hideValue.includes('label:'),
       injectServiceNodes: this.props.showServiceNodes,
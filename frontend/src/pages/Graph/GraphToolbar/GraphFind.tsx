@@ -86,6 +86,7 @@ const operands: string[] = [
   '%grpctraffic',
   '%httperr',
   '%httptraffic',
+  'anomaly',
   'app',
   'circuitbreaker',
   'cluster',
@@ -920,6 +921,8 @@ export class GraphFind extends React.Component<GraphFindProps, GraphFindState> {
       //
       // nodes...
       //
+      case 'anomaly':
+        return { target: 'node', selector: isNegation ? `[^${CyNode.anomaly}]` : `[?${CyNode.anomaly}]` };
       case 'cb':
       case 'circuitbreaker':
         return { target: 'node', selector: isNegation ? `[^${CyNode.hasCB}]` : `[?${CyNode.hasCB}]` };
@@ -59,6 +59,7 @@ export interface FetchParams {
   duration: DurationInSeconds;
   edgeLabels: EdgeLabelMode[];
   graphType: GraphType;
+  includeAnomalies: boolean;
   includeHealth: boolean;
   includeLabels: boolean;
   injectServiceNodes: boolean;
@@ -120,6 +121,7 @@ export default class GraphDataSource {
       duration: 0,
       edgeLabels: [],
       graphType: GraphType.VERSIONED_APP,
+      includeAnomalies: false,
       includeHealth: true,
       includeLabels: false,
       injectServiceNodes: true,
@@ -176,6 +178,10 @@ export default class GraphDataSource {
     // Some appenders are expensive so only specify an appender if needed.
     let appenders: AppenderString = 'deadNode,istio,serviceEntry,sidecarsCheck,workloadEntry';
 
+    if (fetchParams.includeAnomalies) {
+      appenders += ',anomaly';
+    }
+
     if (fetchParams.includeHealth) {
       appenders += ',health';
     }
@@ -423,6 +429,7 @@ export default class GraphDataSource {
       duration: duration,
       edgeLabels: [],
       graphType: GraphType.WORKLOAD,
+      includeAnomalies: false,
       includeHealth: true,
       includeLabels: false,
       injectServiceNodes: true,
@@ -17,6 +17,7 @@ const FETCH_PARAMS = {
   duration: 10,
   edgeLabels: [],
   graphType: GraphType.VERSIONED_APP,
+  includeAnomalies: false,
   includeHealth: false,
   includeLabels: false,
   injectServiceNodes: false,
@@ -353,6 +353,12 @@ export interface GraphNodeServiceHealth {
 
 export type GraphNodeHealthData = GraphNodeAppHealth | GraphNodeWorkloadHealth | GraphNodeServiceHealth | [] | null;
 
+// Anomaly score and reasons, set by the anomaly appender when the traffic deviates from the baseline
+export interface AnomalyInfo {
+  reasons: string[];
+  score: number;
+}
+
 // Node data expected from server
 export interface GraphNodeData {
   // required
@@ -364,6 +370,7 @@ export interface GraphNodeData {
   // optional
   aggregate?: string;
   aggregateValue?: string;
+  anomaly?: AnomalyInfo;
   app?: string;
   destServices?: DestService[];
   hasCB?: boolean;
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/graph"
-	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/graph/config/cytoscape" // also registers the config vendor
+	_ "github.com/kiali/kiali/graph/config/dot"
+	_ "github.com/kiali/kiali/graph/config/graphml"
+	_ "github.com/kiali/kiali/graph/config/mermaid"
+	"github.com/kiali/kiali/graph/telemetry"
 	"github.com/kiali/kiali/graph/telemetry/istio"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/observability"
@@ -22,6 +27,7 @@ func GraphNamespaces(ctx context.Context, business *business.Layer, o graph.Opti
 		observability.Attribute("package", "api"),
 	)
 	defer end()
+	checkConfigVendor(o)
 	// time how long it takes to generate this graph
 	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
 	defer promtimer.ObserveDuration()
@@ -48,11 +54,25 @@ func graphNamespacesIstio(ctx context.Context, business *business.Layer, prom *p
 	globalInfo := graph.NewAppenderGlobalInfo()
 	globalInfo.Business = business
 	globalInfo.Context = ctx
+	if o.TelemetryOptions.Debug {
+		globalInfo.Diagnostics = graph.NewDiagnostics()
+	}
 
 	trafficMap := istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+
+	// for a diff graph, build the baseline traffic map and merge it into the requested traffic map
+	if o.Compare.IsEnabled() {
+		baselineGlobalInfo := graph.NewAppenderGlobalInfo()
+		baselineGlobalInfo.Business = business
+		baselineGlobalInfo.Context = ctx
+
+		baselineTrafficMap := istio.BuildNamespacesTrafficMap(ctx, o.GetCompareTelemetryOptions(), prom, baselineGlobalInfo)
+		trafficMap = telemetry.DiffTrafficMaps(trafficMap, baselineTrafficMap)
+	}
+
 	code, config = generateGraph(trafficMap, o)
 
-	return code, config
+	return code, withDiagnostics(config, globalInfo.Diagnostics)
 }
 
 // GraphNode generates a node graph using the provided options
@@ -60,6 +80,7 @@ func GraphNode(ctx context.Context, business *business.Layer, o graph.Options) (
 	if len(o.Namespaces) != 1 {
 		graph.Error("Node graph does not support the 'namespaces' query parameter or the 'all' namespace")
 	}
+	checkConfigVendor(o)
 
 	// time how long it takes to generate this graph
 	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
@@ -86,11 +107,47 @@ func graphNodeIstio(ctx context.Context, business *business.Layer, client *prome
 	globalInfo := graph.NewAppenderGlobalInfo()
 	globalInfo.Business = business
 	globalInfo.Context = ctx
+	if o.TelemetryOptions.Debug {
+		globalInfo.Diagnostics = graph.NewDiagnostics()
+	}
 
 	trafficMap, _ := istio.BuildNodeTrafficMap(o.TelemetryOptions, client, globalInfo)
+
+	// for a diff graph, build the baseline traffic map and merge it into the requested traffic map
+	if o.Compare.IsEnabled() {
+		baselineGlobalInfo := graph.NewAppenderGlobalInfo()
+		baselineGlobalInfo.Business = business
+		baselineGlobalInfo.Context = ctx
+
+		baselineTrafficMap, _ := istio.BuildNodeTrafficMap(o.GetCompareTelemetryOptions(), client, baselineGlobalInfo)
+		trafficMap = telemetry.DiffTrafficMaps(trafficMap, baselineTrafficMap)
+	}
+
 	code, config = generateGraph(trafficMap, o)
 
-	return code, config
+	return code, withDiagnostics(config, globalInfo.Diagnostics)
+}
+
+// withDiagnostics ends the Diagnostics and adds them to the config, if they were collected
+func withDiagnostics(config interface{}, diagnostics *graph.Diagnostics) interface{} {
+	if diagnostics == nil {
+		return config
+	}
+	diagnostics.End()
+
+	if cytoscapeConfig, ok := config.(cytoscape.Config); ok {
+		cytoscapeConfig.Diagnostics = diagnostics
+		return cytoscapeConfig
+	}
+	return config
+}
+
+// checkConfigVendor rejects a ConfigVendor that is not registered. It is checked here, and not when parsing the
+// options, because this package imports the built-in vendors, so the registry is always populated.
+func checkConfigVendor(o graph.Options) {
+	if _, ok := graph.GetConfigVendor(o.ConfigVendor); !ok {
+		graph.BadRequest(fmt.Sprintf("Invalid configVendor [%s], must be one of: %s", o.ConfigVendor, strings.Join(graph.GetConfigVendorNames(), " | ")))
+	}
 }
 
 func generateGraph(trafficMap graph.TrafficMap, o graph.Options) (int, interface{}) {
@@ -99,13 +156,11 @@ func generateGraph(trafficMap graph.TrafficMap, o graph.Options) (int, interface
 	promtimer := internalmetrics.GetGraphMarshalTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
 	defer promtimer.ObserveDuration()
 
-	var vendorConfig interface{}
-	switch o.ConfigVendor {
-	case graph.VendorCytoscape:
-		vendorConfig = cytoscape.NewConfig(trafficMap, o.ConfigOptions)
-	default:
+	vendor, ok := graph.GetConfigVendor(o.ConfigVendor)
+	if !ok {
 		graph.Error(fmt.Sprintf("ConfigVendor [%s] not supported", o.ConfigVendor))
 	}
+	vendorConfig := vendor.NewConfig(trafficMap, o.ConfigOptions)
 
 	log.Tracef("Done generating config for [%s] graph", o.ConfigVendor)
 	return http.StatusOK, vendorConfig
@@ -1378,6 +1378,99 @@ func TestRatesGraphNone(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+// TestNetworkFilterGraph checks the redis edges built from the Envoy redis_proxy filter stats of the destination pods,
+// split across their incoming TCP edges
+func TestNetworkFilterGraph(t *testing.T) {
+	q0 := `round(sum((sum(rate(istio_tcp_received_bytes_total{reporter="destination",destination_workload_namespace="bookinfo"} [600s])) by (namespace,pod,source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) / on (namespace,pod) group_left sum(rate(istio_tcp_received_bytes_total{reporter="destination",destination_workload_namespace="bookinfo"} [600s])) by (namespace,pod) > 0) * on (namespace,pod) group_left sum(rate({__name__=~"envoy_redis_(.+_)?command_.+_total"} [600s])) by (namespace,pod)) by (source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) > 0,0.001)`
+	q0m0 := model.Metric{
+		"source_workload_namespace":      "bookinfo",
+		"source_workload":                "productpage-v1",
+		"source_canonical_service":       "productpage",
+		"source_canonical_revision":      "v1",
+		"destination_service_namespace":  "bookinfo",
+		"destination_service":            "redis.bookinfo.svc.cluster.local",
+		"destination_service_name":       "redis",
+		"destination_workload_namespace": "bookinfo",
+		"destination_workload":           "redis-v1",
+		"destination_canonical_service":  "redis",
+		"destination_canonical_revision": "v1"}
+	q0m1 := model.Metric{
+		"source_workload_namespace":      "bookinfo",
+		"source_workload":                "reviews-v1",
+		"source_canonical_service":       "reviews",
+		"source_canonical_revision":      "v1",
+		"destination_service_namespace":  "bookinfo",
+		"destination_service":            "redis.bookinfo.svc.cluster.local",
+		"destination_service_name":       "redis",
+		"destination_workload_namespace": "bookinfo",
+		"destination_workload":           "redis-v1",
+		"destination_canonical_service":  "redis",
+		"destination_canonical_revision": "v1"}
+	v0 := model.Vector{
+		&model.Sample{
+			Metric: q0m0,
+			Value:  10},
+		&model.Sample{
+			Metric: q0m1,
+			Value:  30}}
+
+	// same edge as reported by the incoming query, it is not counted twice
+	q1 := `round(sum((sum(rate(istio_tcp_received_bytes_total{reporter="destination",source_workload_namespace="bookinfo"} [600s])) by (namespace,pod,source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) / on (namespace,pod) group_left sum(rate(istio_tcp_received_bytes_total{reporter="destination"} [600s])) by (namespace,pod) > 0) * on (namespace,pod) group_left sum(rate({__name__=~"envoy_redis_(.+_)?command_.+_total"} [600s])) by (namespace,pod)) by (source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) > 0,0.001)`
+	v1 := model.Vector{
+		&model.Sample{
+			Metric: q0m0,
+			Value:  10}}
+
+	q2 := `round(sum((sum(rate(istio_tcp_received_bytes_total{reporter="destination",destination_workload_namespace="bookinfo"} [600s])) by (namespace,pod,source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) / on (namespace,pod) group_left sum(rate(istio_tcp_received_bytes_total{reporter="destination",destination_workload_namespace="bookinfo"} [600s])) by (namespace,pod) > 0) * on (namespace,pod) group_left sum(rate({__name__=~"envoy_redis_(.+_)?command_.+_error"} [600s])) by (namespace,pod)) by (source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) > 0,0.001)`
+	v2 := model.Vector{
+		&model.Sample{
+			Metric: q0m0,
+			Value:  1}}
+
+	q3 := `round(sum((sum(rate(istio_tcp_received_bytes_total{reporter="destination",source_workload_namespace="bookinfo"} [600s])) by (namespace,pod,source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) / on (namespace,pod) group_left sum(rate(istio_tcp_received_bytes_total{reporter="destination"} [600s])) by (namespace,pod) > 0) * on (namespace,pod) group_left sum(rate({__name__=~"envoy_redis_(.+_)?command_.+_error"} [600s])) by (namespace,pod)) by (source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision) > 0,0.001)`
+	v3 := model.Vector{}
+
+	client, xapi, _, err := setupMocked()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockQuery(xapi, q0, &v0)
+	mockQuery(xapi, q1, &v1)
+	mockQuery(xapi, q2, &v2)
+	mockQuery(xapi, q3, &v3)
+
+	var fut func(ctx context.Context, b *business.Layer, p *prometheus.Client, o graph.Options) (int, interface{})
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/graph", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			code, config := fut(context, nil, client, graph.NewOptions(r.WithContext(context)))
+			respond(w, code, config)
+		}))
+
+	ts := httptest.NewServer(mr)
+	defer ts.Close()
+
+	fut = graphNamespacesIstio
+	url := ts.URL + "/api/namespaces/graph?namespaces=bookinfo&graphType=workload&appenders&queryTime=1523364075&rateGrpc=none&rateHttp=none&rateTcp=none&rateRedis=requests"
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := io.ReadAll(resp.Body)
+	expected, _ := os.ReadFile("testdata/test_network_filter_graph.expected")
+	if runtime.GOOS == "windows" {
+		expected = bytes.Replace(expected, []byte("\r\n"), []byte("\n"), -1)
+	}
+	expected = expected[:len(expected)-1] // remove EOF byte
+
+	if !assert.Equal(t, expected, actual) {
+		fmt.Printf("\nActual:\n%v", string(actual))
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
 func TestWorkloadNodeGraph(t *testing.T) {
 	q0 := `round(sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="bookinfo",destination_workload="productpage-v1"} [600s])) by (source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol,response_code,grpc_response_status,response_flags) > 0,0.001)`
 	q0m0 := model.Metric{
@@ -3448,3 +3541,11 @@ func TestMultiClusterSourceGraph(t *testing.T) {
 	}
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+func TestGraphInvalidConfigVendor(t *testing.T) {
+	assert := assert.New(t)
+
+	o := graph.Options{ConfigVendor: "svg"}
+	expected := graph.Response{Message: "Invalid configVendor [svg], must be one of: cytoscape | dot | graphml | mermaid", Code: http.StatusBadRequest}
+	assert.PanicsWithValue(expected, func() { GraphNamespaces(context.Background(), nil, o) })
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry/istio"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// GraphWorkloadImpact generates a namespaces graph using the provided options and returns the impact analysis
+// for the workload. If the cluster option is not set the workload nodes of every cluster are analyzed.
+func GraphWorkloadImpact(ctx context.Context, business *business.Layer, o graph.Options, namespace, workload string) (code int, impact interface{}) {
+	if o.TelemetryOptions.GraphType != graph.GraphTypeWorkload && o.TelemetryOptions.GraphType != graph.GraphTypeVersionedApp {
+		graph.BadRequest(fmt.Sprintf("Impact analysis supports only graphType [%s] or [%s]", graph.GraphTypeVersionedApp, graph.GraphTypeWorkload))
+	}
+
+	switch o.TelemetryVendor {
+	case graph.VendorIstio:
+		prom, err := prometheus.NewClient()
+		graph.CheckError(err)
+		code, impact = graphWorkloadImpactIstio(ctx, business, prom, o, namespace, workload)
+	default:
+		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
+	}
+
+	return code, impact
+}
+
+// graphWorkloadImpactIstio provides a test hook that accepts mock clients
+func graphWorkloadImpactIstio(ctx context.Context, business *business.Layer, prom *prometheus.Client, o graph.Options, namespace, workload string) (code int, impact interface{}) {
+
+	// Create a 'global' object to store the business. Global only to the request.
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+
+	trafficMap := istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+
+	cluster := o.NodeOptions.Cluster
+	nodes := []*graph.Node{}
+	for _, n := range trafficMap {
+		if n.Namespace != namespace || n.Workload != workload {
+			continue
+		}
+		if n.NodeType != graph.NodeTypeWorkload && n.NodeType != graph.NodeTypeApp {
+			continue
+		}
+		if cluster != graph.Unknown && n.Cluster != cluster {
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+
+	if len(nodes) == 0 {
+		graph.NotFound(fmt.Sprintf("Workload [%s] in namespace [%s] has no traffic for the requested time period", workload, namespace))
+	}
+
+	return http.StatusOK, graph.NewImpact(trafficMap, nodes)
+}
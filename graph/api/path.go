@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry/istio"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// GraphPath generates a namespaces graph using the provided options and returns the graph reduced to the
+// traffic paths from the source to the dest node. The graph is empty if there is no path.
+func GraphPath(ctx context.Context, business *business.Layer, o graph.Options, source, dest graph.NodeRef) (code int, config interface{}) {
+	if o.TelemetryOptions.GraphType != graph.GraphTypeWorkload && o.TelemetryOptions.GraphType != graph.GraphTypeVersionedApp {
+		graph.BadRequest(fmt.Sprintf("Graph path supports only graphType [%s] or [%s]", graph.GraphTypeVersionedApp, graph.GraphTypeWorkload))
+	}
+	if o.Compare.IsEnabled() {
+		graph.BadRequest("Graph path does not support the 'compareTime' query parameter")
+	}
+
+	switch o.TelemetryVendor {
+	case graph.VendorIstio:
+		prom, err := prometheus.NewClient()
+		graph.CheckError(err)
+		code, config = graphPathIstio(ctx, business, prom, o, source, dest)
+	default:
+		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
+	}
+
+	return code, config
+}
+
+// graphPathIstio provides a test hook that accepts mock clients
+func graphPathIstio(ctx context.Context, business *business.Layer, prom *prometheus.Client, o graph.Options, source, dest graph.NodeRef) (code int, config interface{}) {
+
+	// Create a 'global' object to store the business. Global only to the request.
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+	if o.TelemetryOptions.Debug {
+		globalInfo.Diagnostics = graph.NewDiagnostics()
+	}
+
+	trafficMap := istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+
+	sources := []*graph.Node{}
+	dests := []*graph.Node{}
+	for _, n := range trafficMap {
+		if source.Matches(n) {
+			sources = append(sources, n)
+		}
+		if dest.Matches(n) {
+			dests = append(dests, n)
+		}
+	}
+
+	if len(sources) == 0 {
+		graph.NotFound(fmt.Sprintf("Source [%s] has no traffic for the requested time period", source))
+	}
+	if len(dests) == 0 {
+		graph.NotFound(fmt.Sprintf("Dest [%s] has no traffic for the requested time period", dest))
+	}
+
+	diagnostics := globalInfo.Diagnostics
+	diagnostics.StartStep("path", "", trafficMap)
+	graph.ReduceToPaths(trafficMap, sources, dests)
+	diagnostics.EndStep(trafficMap)
+
+	code, config = generateGraph(trafficMap, o)
+
+	return code, withDiagnostics(config, diagnostics)
+}
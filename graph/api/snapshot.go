@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/status"
+)
+
+// SnapshotVersion is the version of the Snapshot format. It must be incremented for incompatible changes,
+// older snapshot versions are rejected.
+const SnapshotVersion = 1
+
+// Snapshot is a namespaces graph captured for offline replay, along with the options used to generate it. The
+// accessible namespaces are specific to the requesting user and are not captured.
+type Snapshot struct {
+	Version   int               `json:"version"`
+	Created   int64             `json:"created"` // unix time in seconds
+	Options   SnapshotOptions   `json:"options"`
+	Telemetry SnapshotTelemetry `json:"telemetry"`
+	Graph     cytoscape.Config  `json:"graph"`
+}
+
+// SnapshotOptions are the graph.Options used to generate the snapshot graph. graph.Options can not be
+// serialized as-is, the CommonOptions are embedded in both the Config and Telemetry options, so they are
+// flattened here.
+type SnapshotOptions struct {
+	ConfigVendor       string                   `json:"configVendor"`
+	TelemetryVendor    string                   `json:"telemetryVendor"`
+	BoxBy              string                   `json:"boxBy"`
+	Compare            graph.CompareOptions     `json:"compare"`
+	Appenders          graph.RequestedAppenders `json:"appenders"`
+	IncludeIdleEdges   bool                     `json:"includeIdleEdges"`
+	InjectServiceNodes bool                     `json:"injectServiceNodes"`
+	Namespaces         graph.NamespaceInfoMap   `json:"namespaces"`
+	Rates              graph.RequestedRates     `json:"rates"`
+	graph.CommonOptions
+	graph.NodeOptions
+}
+
+// GraphOptions returns the graph.Options for the snapshot options. The accessible namespaces are not set.
+func (so SnapshotOptions) GraphOptions() graph.Options {
+	return graph.Options{
+		ConfigVendor:    so.ConfigVendor,
+		TelemetryVendor: so.TelemetryVendor,
+		ConfigOptions: graph.ConfigOptions{
+			BoxBy:         so.BoxBy,
+			Compare:       so.Compare,
+			CommonOptions: so.CommonOptions,
+		},
+		TelemetryOptions: graph.TelemetryOptions{
+			Appenders:          so.Appenders,
+			IncludeIdleEdges:   so.IncludeIdleEdges,
+			InjectServiceNodes: so.InjectServiceNodes,
+			Namespaces:         so.Namespaces,
+			Rates:              so.Rates,
+			CommonOptions:      so.CommonOptions,
+			NodeOptions:        so.NodeOptions,
+		},
+	}
+}
+
+func newSnapshotOptions(o graph.Options) SnapshotOptions {
+	return SnapshotOptions{
+		ConfigVendor:       o.ConfigVendor,
+		TelemetryVendor:    o.TelemetryVendor,
+		BoxBy:              o.BoxBy,
+		Compare:            o.Compare,
+		Appenders:          o.Appenders,
+		IncludeIdleEdges:   o.IncludeIdleEdges,
+		InjectServiceNodes: o.InjectServiceNodes,
+		Namespaces:         o.TelemetryOptions.Namespaces,
+		Rates:              o.Rates,
+		CommonOptions:      o.TelemetryOptions.CommonOptions,
+		NodeOptions:        o.NodeOptions,
+	}
+}
+
+// SnapshotTelemetry describes the telemetry used to generate the snapshot graph
+type SnapshotTelemetry struct {
+	KialiVersion string                `json:"kialiVersion"`
+	Namespaces   []graph.NamespaceInfo `json:"namespaces"` // with the effective query durations
+	QueryTime    int64                 `json:"queryTime"`  // unix time in seconds
+	Duration     int64                 `json:"duration"`   // requested duration in seconds
+	Nodes        int                   `json:"nodes"`
+	Edges        int                   `json:"edges"`
+}
+
+// GraphNamespacesSnapshot generates a namespaces graph using the provided options and returns it as a Snapshot
+func GraphNamespacesSnapshot(ctx context.Context, business *business.Layer, o graph.Options) (code int, snapshot interface{}) {
+	if o.ConfigVendor != graph.VendorCytoscape {
+		graph.BadRequest(fmt.Sprintf("Graph snapshot supports only configVendor [%s]", graph.VendorCytoscape))
+	}
+
+	code, config := GraphNamespaces(ctx, business, o)
+	if code != http.StatusOK {
+		return code, config
+	}
+
+	return code, NewSnapshot(o, config.(cytoscape.Config))
+}
+
+// NewSnapshot returns the Snapshot for the graph generated using the provided options
+func NewSnapshot(o graph.Options, config cytoscape.Config) Snapshot {
+	kialiVersion, _ := status.GetStatus(status.CoreVersion)
+
+	telemetry := SnapshotTelemetry{
+		KialiVersion: kialiVersion,
+		Namespaces:   []graph.NamespaceInfo{},
+		QueryTime:    o.TelemetryOptions.QueryTime,
+		Duration:     int64(o.TelemetryOptions.Duration.Seconds()),
+		Edges:        len(config.Elements.Edges),
+	}
+	for _, ns := range o.Namespaces {
+		telemetry.Namespaces = append(telemetry.Namespaces, ns)
+	}
+	sort.Slice(telemetry.Namespaces, func(i, j int) bool {
+		return telemetry.Namespaces[i].Name < telemetry.Namespaces[j].Name
+	})
+	for _, nw := range config.Elements.Nodes {
+		if nw.Data.NodeType != graph.NodeTypeBox {
+			telemetry.Nodes++
+		}
+	}
+
+	return Snapshot{
+		Version:   SnapshotVersion,
+		Created:   time.Now().Unix(),
+		Options:   newSnapshotOptions(o),
+		Telemetry: telemetry,
+		Graph:     config,
+	}
+}
+
+// ParseSnapshot returns the Snapshot for the JSON data, or an error if the data is not a supported snapshot
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("invalid graph snapshot: %v", err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported graph snapshot version [%d], expecting version [%d]", snapshot.Version, SnapshotVersion)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	o := streamOptions("graphType=app&namespaces=bookinfo,istio-system", "istio-system", "bookinfo")
+	o.TelemetryOptions.AccessibleNamespaces = map[string]time.Time{"bookinfo": {}, "istio-system": {}, "secret": {}}
+	o.TelemetryOptions.Duration = 10 * time.Minute
+	o.TelemetryOptions.QueryTime = 1000
+	o.TelemetryOptions.GraphType = graph.GraphTypeApp
+	o.ConfigOptions.CommonOptions = o.TelemetryOptions.CommonOptions
+
+	config := cytoscape.Config{
+		Timestamp: 1000,
+		Duration:  600,
+		GraphType: graph.GraphTypeApp,
+		Elements: cytoscape.Elements{
+			Nodes: []*cytoscape.NodeWrapper{
+				{Data: &cytoscape.NodeData{ID: "box", NodeType: graph.NodeTypeBox, Namespace: "bookinfo"}},
+				{Data: &cytoscape.NodeData{ID: "a", NodeType: graph.NodeTypeApp, Namespace: "bookinfo", Parent: "box"}},
+				{Data: &cytoscape.NodeData{ID: "b", NodeType: graph.NodeTypeApp, Namespace: "bookinfo", Parent: "box"}},
+			},
+			Edges: []*cytoscape.EdgeWrapper{
+				{Data: &cytoscape.EdgeData{ID: "ab", Source: "a", Target: "b"}},
+			},
+		},
+	}
+
+	snapshot := NewSnapshot(o, config)
+	assert.Equal(SnapshotVersion, snapshot.Version)
+	assert.Equal(2, snapshot.Telemetry.Nodes)
+	assert.Equal(1, snapshot.Telemetry.Edges)
+	assert.Equal(int64(600), snapshot.Telemetry.Duration)
+	assert.Equal(int64(1000), snapshot.Telemetry.QueryTime)
+	require.Len(snapshot.Telemetry.Namespaces, 2)
+	assert.Equal("bookinfo", snapshot.Telemetry.Namespaces[0].Name)
+	assert.Equal("istio-system", snapshot.Telemetry.Namespaces[1].Name)
+
+	data, err := json.Marshal(snapshot)
+	require.NoError(err)
+	assert.NotContains(string(data), "secret")
+
+	parsed, err := ParseSnapshot(data)
+	require.NoError(err)
+	assert.Equal(config, parsed.Graph)
+	parsedOptions := parsed.Options.GraphOptions()
+	assert.Equal(graph.GraphTypeApp, parsedOptions.TelemetryOptions.GraphType)
+	assert.Equal(graph.GraphTypeApp, parsedOptions.ConfigOptions.GraphType)
+	assert.Equal(10*time.Minute, parsedOptions.TelemetryOptions.Duration)
+	assert.Equal(int64(1000), parsedOptions.ConfigOptions.QueryTime)
+	assert.Equal("bookinfo,istio-system", parsedOptions.TelemetryOptions.Params.Get("namespaces"))
+	assert.Len(parsedOptions.TelemetryOptions.Namespaces, 2)
+	assert.Nil(parsedOptions.AccessibleNamespaces)
+}
+
+func TestParseSnapshotErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := ParseSnapshot([]byte("not json"))
+	assert.Error(err)
+
+	_, err = ParseSnapshot([]byte(`{"version": 0, "graph": {}}`))
+	assert.EqualError(err, "unsupported graph snapshot version [0], expecting version [1]")
+}
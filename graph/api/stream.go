@@ -0,0 +1,235 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Graph stream event types. A subscriber first receives a GraphEventGraph event holding the full
+// cytoscape.Config, followed by a GraphEventDelta event holding a cytoscape.Delta each time the graph
+// changes. A GraphEventError event is sent when a graph generation fails, the stream continues.
+const (
+	GraphEventDelta string = "delta"
+	GraphEventError string = "error"
+	GraphEventGraph string = "graph"
+)
+
+const (
+	DefaultGraphStreamInterval = 15 * time.Second
+	MinGraphStreamInterval     = 5 * time.Second
+
+	// a subscriber that falls this many events behind is dropped, it must re-subscribe to re-sync
+	graphStreamBufferSize = 10
+)
+
+// GraphEvent is pushed to graph stream subscribers
+type GraphEvent struct {
+	Type    string
+	Payload interface{}
+}
+
+// graphStream regenerates a namespaces graph every interval, on behalf of all subscribers requesting
+// identical options.
+type graphStream struct {
+	authInfo    *api.AuthInfo     // of the latest subscriber, used to get the business layer of every generation
+	config      *cytoscape.Config // the latest generated graph, nil until the first generation
+	interval    time.Duration
+	key         string
+	stop        chan struct{} // closed when the last subscriber is removed
+	subscribers map[chan GraphEvent]bool
+}
+
+var (
+	// graphStreamsLock protects graphStreams as well as the state of every graphStream
+	graphStreams     = map[string]*graphStream{}
+	graphStreamsLock sync.Mutex
+)
+
+// SubscribeGraphNamespaces subscribes to the namespaces graph for the provided options, regenerated every
+// interval. Subscribers with the same token and identical options share a single stream, the stream stops as soon
+// as it has no more subscribers. The returned func must be called to unsubscribe. The returned channel is closed if
+// the subscriber falls too far behind.
+func SubscribeGraphNamespaces(authInfo *api.AuthInfo, o graph.Options, interval time.Duration) (<-chan GraphEvent, func()) {
+	if o.ConfigVendor != graph.VendorCytoscape {
+		graph.BadRequest(fmt.Sprintf("Graph stream supports only configVendor [%s]", graph.VendorCytoscape))
+	}
+	if o.Compare.IsEnabled() {
+		graph.BadRequest("Graph stream does not support diff graphs")
+	}
+	if o.TelemetryVendor != graph.VendorIstio {
+		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
+	}
+	if interval < MinGraphStreamInterval {
+		graph.BadRequest(fmt.Sprintf("Graph stream interval must be at least [%s]", MinGraphStreamInterval))
+	}
+
+	prom, err := prometheus.NewClient()
+	graph.CheckError(err)
+
+	events := make(chan GraphEvent, graphStreamBufferSize)
+	key := graphStreamKey(authInfo.Token, o, interval)
+
+	graphStreamsLock.Lock()
+	defer graphStreamsLock.Unlock()
+
+	stream, found := graphStreams[key]
+	if !found {
+		stream = &graphStream{
+			interval:    interval,
+			key:         key,
+			stop:        make(chan struct{}),
+			subscribers: map[chan GraphEvent]bool{},
+		}
+		graphStreams[key] = stream
+		go stream.run(prom, o)
+	}
+
+	stream.authInfo = authInfo
+	stream.subscribers[events] = true
+	if stream.config != nil {
+		events <- GraphEvent{Type: GraphEventGraph, Payload: *stream.config}
+	}
+
+	return events, func() { stream.unsubscribe(events) }
+}
+
+// graphStreamKey returns the key identifying subscribers with the same token and identical options, the graph
+// depends on the namespaces accessible with the token. The token is hashed, the key is logged. The queryTime is
+// ignored, a stream always reports the current time.
+func graphStreamKey(token string, o graph.Options, interval time.Duration) string {
+	params := url.Values{}
+	for k, v := range o.TelemetryOptions.Params {
+		params[k] = v
+	}
+	params.Del("queryTime")
+
+	namespaces := make([]string, 0, len(o.Namespaces))
+	for ns := range o.Namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	params.Set("namespaces", strings.Join(namespaces, ","))
+	params.Set("refreshInterval", interval.String())
+	params.Set("user", fmt.Sprintf("%x", sha256.Sum256([]byte(token))))
+
+	return params.Encode()
+}
+
+func (s *graphStream) unsubscribe(events chan GraphEvent) {
+	graphStreamsLock.Lock()
+	defer graphStreamsLock.Unlock()
+
+	s.removeSubscriber(events)
+}
+
+// removeSubscriber removes the subscriber and stops the stream when it was the last one. graphStreamsLock must be held.
+func (s *graphStream) removeSubscriber(events chan GraphEvent) {
+	if !s.subscribers[events] {
+		return
+	}
+	delete(s.subscribers, events)
+	if len(s.subscribers) == 0 {
+		delete(graphStreams, s.key)
+		close(s.stop)
+	}
+}
+
+// run generates the graph every interval, until the stream is stopped
+func (s *graphStream) run(prom *prometheus.Client, o graph.Options) {
+	log.Debugf("Starting graph stream [%s]", s.key)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		config, errMessage := s.generate(prom, o)
+		s.publish(config, errMessage)
+
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			log.Debugf("Stopping graph stream [%s]", s.key)
+			return
+		}
+	}
+}
+
+// generate returns the current graph, or the error message if the generation fails. The business layer and the
+// options (i.e. the query time and the namespace durations) are renewed for every generation.
+func (s *graphStream) generate(prom *prometheus.Client, o graph.Options) (config *cytoscape.Config, errMessage string) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch err := r.(type) {
+			case graph.Response:
+				errMessage = err.Message
+			case error:
+				errMessage = err.Error()
+			default:
+				errMessage = fmt.Sprintf("%v", r)
+			}
+			log.Errorf("Graph stream [%s] failed to generate graph: %s", s.key, errMessage)
+		}
+	}()
+
+	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
+	defer promtimer.ObserveDuration()
+
+	graphStreamsLock.Lock()
+	authInfo := s.authInfo
+	graphStreamsLock.Unlock()
+	layer, err := business.Get(authInfo)
+	graph.CheckError(err)
+
+	o = o.GetQueryTimeOptions(time.Now().Unix())
+	_, vendorConfig := graphNamespacesIstio(context.Background(), layer, prom, o)
+	cyConfig := vendorConfig.(cytoscape.Config)
+
+	return &cyConfig, ""
+}
+
+// publish sends the event for the generated graph to every subscriber, dropping those that are too far
+// behind: the full graph the first time, a delta when the graph changes, or the error message.
+func (s *graphStream) publish(config *cytoscape.Config, errMessage string) {
+	graphStreamsLock.Lock()
+	defer graphStreamsLock.Unlock()
+
+	var event GraphEvent
+	switch {
+	case config == nil:
+		event = GraphEvent{Type: GraphEventError, Payload: errMessage}
+	case s.config == nil:
+		s.config = config
+		event = GraphEvent{Type: GraphEventGraph, Payload: *config}
+	default:
+		delta := cytoscape.NewDelta(*s.config, *config)
+		s.config = config
+		if delta.IsEmpty() {
+			return
+		}
+		event = GraphEvent{Type: GraphEventDelta, Payload: delta}
+	}
+
+	for events := range s.subscribers {
+		select {
+		case events <- event:
+		default:
+			log.Debugf("Graph stream [%s] dropping slow subscriber", s.key)
+			s.removeSubscriber(events)
+			close(events)
+		}
+	}
+}
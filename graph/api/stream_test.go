@@ -0,0 +1,118 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+func streamOptions(query string, namespaces ...string) graph.Options {
+	params, _ := url.ParseQuery(query)
+	o := graph.Options{}
+	o.TelemetryOptions.Params = params
+	o.TelemetryOptions.Namespaces = graph.NewNamespaceInfoMap()
+	for _, ns := range namespaces {
+		o.TelemetryOptions.Namespaces[ns] = graph.NamespaceInfo{Name: ns}
+	}
+	return o
+}
+
+func TestGraphStreamKey(t *testing.T) {
+	assert := assert.New(t)
+
+	key := graphStreamKey("token", streamOptions("graphType=app&namespaces=b,a&queryTime=100", "a", "b"), 15*time.Second)
+	assert.Equal(key, graphStreamKey("token", streamOptions("queryTime=200&namespaces=a,b&graphType=app", "b", "a"), 15*time.Second))
+	assert.NotEqual(key, graphStreamKey("token", streamOptions("graphType=app&namespaces=b,a&queryTime=100", "a", "b"), 30*time.Second))
+	assert.NotEqual(key, graphStreamKey("token", streamOptions("graphType=workload&namespaces=b,a", "a", "b"), 15*time.Second))
+	// streams are not shared across users
+	assert.NotEqual(key, graphStreamKey("other-token", streamOptions("graphType=app&namespaces=b,a&queryTime=100", "a", "b"), 15*time.Second))
+	assert.NotContains(key, "token")
+}
+
+func TestGraphStreamPublish(t *testing.T) {
+	assert := assert.New(t)
+
+	stream := &graphStream{key: "test", stop: make(chan struct{}), subscribers: map[chan GraphEvent]bool{}}
+	events := make(chan GraphEvent, graphStreamBufferSize)
+	stream.subscribers[events] = true
+
+	config := &cytoscape.Config{
+		Timestamp: 100,
+		Elements: cytoscape.Elements{
+			Nodes: []*cytoscape.NodeWrapper{{Data: &cytoscape.NodeData{ID: "a"}}},
+			Edges: []*cytoscape.EdgeWrapper{},
+		},
+	}
+
+	// first, the full graph
+	stream.publish(config, "")
+	event := <-events
+	assert.Equal(GraphEventGraph, event.Type)
+	assert.Equal(*config, event.Payload)
+
+	// unchanged, no event
+	stream.publish(config, "")
+	assert.Len(events, 0)
+
+	// an error does not reset the graph
+	stream.publish(nil, "prometheus unavailable")
+	event = <-events
+	assert.Equal(GraphEventError, event.Type)
+	assert.Equal("prometheus unavailable", event.Payload)
+
+	changed := &cytoscape.Config{
+		Timestamp: 115,
+		Elements: cytoscape.Elements{
+			Nodes: []*cytoscape.NodeWrapper{{Data: &cytoscape.NodeData{ID: "b"}}},
+			Edges: []*cytoscape.EdgeWrapper{},
+		},
+	}
+	stream.publish(changed, "")
+	event = <-events
+	assert.Equal(GraphEventDelta, event.Type)
+	delta := event.Payload.(cytoscape.Delta)
+	assert.Equal("b", delta.AddedNodes[0].Data.ID)
+	assert.Equal([]string{"a"}, delta.RemovedNodes)
+
+	// a subscriber that falls behind is dropped and its channel closed
+	for i := 0; i <= graphStreamBufferSize; i++ {
+		stream.publish(nil, "error")
+	}
+	assert.Len(stream.subscribers, 0)
+	for range events {
+	}
+	// and the stream stops without its last subscriber
+	_, open := <-stream.stop
+	assert.False(open)
+}
+
+func TestGraphStreamUnsubscribe(t *testing.T) {
+	assert := assert.New(t)
+
+	stream := &graphStream{key: "test-unsubscribe", stop: make(chan struct{}), subscribers: map[chan GraphEvent]bool{}}
+	first := make(chan GraphEvent, graphStreamBufferSize)
+	second := make(chan GraphEvent, graphStreamBufferSize)
+	stream.subscribers[first] = true
+	stream.subscribers[second] = true
+	graphStreams[stream.key] = stream
+
+	stream.unsubscribe(first)
+	assert.Contains(graphStreams, stream.key)
+	select {
+	case <-stream.stop:
+		assert.Fail("the stream stopped with a subscriber left")
+	default:
+	}
+
+	// the stream stops as soon as the last subscriber unsubscribes, unsubscribing twice is harmless
+	stream.unsubscribe(second)
+	stream.unsubscribe(second)
+	assert.NotContains(graphStreams, stream.key)
+	_, open := <-stream.stop
+	assert.False(open)
+}
@@ -16,6 +16,7 @@ type AppenderVendorInfo map[string]interface{}
 type AppenderGlobalInfo struct {
 	Business    *business.Layer
 	Context     context.Context
+	Diagnostics *Diagnostics // nil unless requested, see TelemetryOptions.Debug
 	HomeCluster string
 	PromClient  *prometheus.Client
 	Vendor      AppenderVendorInfo // telemetry vendor's global info
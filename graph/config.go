@@ -1,5 +1,10 @@
 package graph
 
+import (
+	"sort"
+	"sync"
+)
+
 // ConfigVendor is an interface that must be satisfied for each config vendor implementation.
 type ConfigVendor interface {
 
@@ -8,3 +13,47 @@ type ConfigVendor interface {
 	// definitions for error handling. Refer to the Cytoscape implementation as an example.
 	NewConfig(trafficMap TrafficMap, o ConfigOptions) interface{}
 }
+
+// TextConfig can be returned by a ConfigVendor producing a non-JSON config (e.g. Graphviz DOT). The
+// Text is returned as-is, using the ContentType.
+type TextConfig struct {
+	ContentType string
+	Text        string
+}
+
+var (
+	configVendors     = map[string]ConfigVendor{}
+	configVendorsLock sync.RWMutex
+)
+
+// RegisterConfigVendor makes a ConfigVendor available by name (i.e. the configVendor query param). It
+// is typically called from the init() of the vendor's package. Registering the same name twice replaces
+// the previous vendor.
+func RegisterConfigVendor(name string, vendor ConfigVendor) {
+	configVendorsLock.Lock()
+	defer configVendorsLock.Unlock()
+
+	configVendors[name] = vendor
+}
+
+// GetConfigVendor returns the ConfigVendor registered for the name, if any.
+func GetConfigVendor(name string) (ConfigVendor, bool) {
+	configVendorsLock.RLock()
+	defer configVendorsLock.RUnlock()
+
+	vendor, ok := configVendors[name]
+	return vendor, ok
+}
+
+// GetConfigVendorNames returns the sorted names of the registered ConfigVendors.
+func GetConfigVendorNames() []string {
+	configVendorsLock.RLock()
+	defer configVendorsLock.RUnlock()
+
+	names := make([]string, 0, len(configVendors))
+	for name := range configVendors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -18,6 +18,7 @@ package cytoscape
 import (
 	"crypto/md5"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
@@ -54,6 +55,7 @@ type Responses map[string]*ResponseDetail
 type ProtocolTraffic struct {
 	Protocol  string            `json:"protocol,omitempty"`  // protocol
 	Rates     map[string]string `json:"rates,omitempty"`     // map[rate]value
+	Deltas    map[string]string `json:"deltas,omitempty"`    // map[rate]delta, for diff graphs only (current-baseline)
 	Responses Responses         `json:"responses,omitempty"` // see comment above
 }
 
@@ -79,6 +81,9 @@ type VSInfo struct {
 	Hostnames []string `json:"hostnames,omitempty"`
 }
 
+// AnomalyInfo contains the anomaly score and reasons if the traffic deviates from the baseline
+type AnomalyInfo graph.AnomalyMetadata
+
 // HealthConfig maps annotations information for health
 type HealthConfig map[string]string
 
@@ -96,7 +101,9 @@ type NodeData struct {
 	Version               string              `json:"version,omitempty"`
 	Service               string              `json:"service,omitempty"`               // requested service for NodeTypeService
 	Aggregate             string              `json:"aggregate,omitempty"`             // set like "<aggregate>=<aggregateVal>"
+	Anomaly               *AnomalyInfo        `json:"anomaly,omitempty"`               // set when incoming traffic deviates from the baseline
 	DestServices          []graph.ServiceName `json:"destServices,omitempty"`          // requested services for [dest] node
+	DiffStatus            string              `json:"diffStatus,omitempty"`            // for diff graphs only: added | changed | removed | unchanged
 	Labels                map[string]string   `json:"labels,omitempty"`                // k8s labels associated with the node
 	Traffic               []ProtocolTraffic   `json:"traffic,omitempty"`               // traffic rates for all detected protocols
 	HealthData            interface{}         `json:"healthData"`                      // data to calculate health status from configurations
@@ -129,7 +136,9 @@ type EdgeData struct {
 	Target string `json:"target"` // child node ID
 
 	// App Fields (not required by Cytoscape)
+	Anomaly         *AnomalyInfo    `json:"anomaly,omitempty"`         // set when traffic deviates from the baseline
 	DestPrincipal   string          `json:"destPrincipal,omitempty"`   // principal used for the edge destination
+	DiffStatus      string          `json:"diffStatus,omitempty"`      // for diff graphs only: added | changed | removed | unchanged
 	IsMTLS          string          `json:"isMTLS,omitempty"`          // set to the percentage of traffic using a mutual TLS connection
 	ResponseTime    string          `json:"responseTime,omitempty"`    // in millis
 	SourcePrincipal string          `json:"sourcePrincipal,omitempty"` // principal used for the edge source
@@ -151,10 +160,92 @@ type Elements struct {
 }
 
 type Config struct {
-	Timestamp int64    `json:"timestamp"`
-	Duration  int64    `json:"duration"`
-	GraphType string   `json:"graphType"`
-	Elements  Elements `json:"elements"`
+	Timestamp        int64              `json:"timestamp"`
+	Duration         int64              `json:"duration"`
+	CompareTimestamp int64              `json:"compareTimestamp,omitempty"` // for diff graphs only, the baseline timestamp
+	CompareDuration  int64              `json:"compareDuration,omitempty"`  // for diff graphs only, the baseline duration
+	GraphType        string             `json:"graphType"`
+	Elements         Elements           `json:"elements"`
+	Diagnostics      *graph.Diagnostics `json:"diagnostics,omitempty"` // only when requested (debug=true)
+}
+
+// Vendor implements graph/ConfigVendor, it is registered as graph.VendorCytoscape
+type Vendor struct{}
+
+func init() {
+	graph.RegisterConfigVendor(graph.VendorCytoscape, Vendor{})
+}
+
+// NewConfig is required by the graph/ConfigVendor interface
+func (v Vendor) NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) interface{} {
+	return NewConfig(trafficMap, o)
+}
+
+// NodesByParent returns the nodes grouped by their compound (box) parent node ID. Top-level
+// nodes are keyed by "". The node order is maintained.
+func (e *Elements) NodesByParent() map[string][]*NodeData {
+	nodesByParent := make(map[string][]*NodeData)
+	for _, nw := range e.Nodes {
+		nodesByParent[nw.Data.Parent] = append(nodesByParent[nw.Data.Parent], nw.Data)
+	}
+	return nodesByParent
+}
+
+// Label returns a short, human-readable name for the node, suitable for rendering. It does not include
+// the namespace or cluster, except for the respective box nodes.
+func (nd *NodeData) Label() string {
+	switch nd.NodeType {
+	case graph.NodeTypeAggregate:
+		return nd.Aggregate
+	case graph.NodeTypeApp:
+		if nd.Version != "" {
+			return fmt.Sprintf("%s %s", nd.App, nd.Version)
+		}
+		return nd.App
+	case graph.NodeTypeBox:
+		switch {
+		case nd.IsBox == graph.BoxByApp:
+			return nd.App
+		case nd.IsBox == graph.BoxByNamespace:
+			return nd.Namespace
+		case strings.HasPrefix(nd.IsBox, graph.BoxByLabelPrefix):
+			return nd.Labels[strings.TrimPrefix(nd.IsBox, graph.BoxByLabelPrefix)]
+		default:
+			return nd.Cluster
+		}
+	case graph.NodeTypeService:
+		return nd.Service
+	case graph.NodeTypeWorkload:
+		return nd.Workload
+	default:
+		return graph.Unknown
+	}
+}
+
+// Label returns a short, human-readable description of the edge traffic, suitable for rendering. For
+// example: "http 10.00rps 5.0%err".
+func (ed *EdgeData) Label() string {
+	label := ed.Traffic.Protocol
+	for _, p := range graph.Protocols {
+		if p.Name != ed.Traffic.Protocol {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			val, ok := ed.Traffic.Rates[string(r.Name)]
+			switch {
+			case !ok:
+				continue
+			case r.IsTotal:
+				label = fmt.Sprintf("%s %s%s", label, val, p.UnitShort)
+			case r.IsPercentErr:
+				label = fmt.Sprintf("%s %s%%err", label, val)
+			}
+		}
+	}
+	if ed.DiffStatus != "" && ed.DiffStatus != graph.DiffStatusUnchanged {
+		label = fmt.Sprintf("%s (%s)", label, ed.DiffStatus)
+	}
+	return label
 }
 
 func nodeHash(id string) string {
@@ -165,7 +256,7 @@ func edgeHash(from, to, protocol string) string {
 	return fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%s.%s.%s", from, to, protocol))))
 }
 
-// NewConfig is required by the graph/ConfigVendor interface
+// NewConfig returns the Cytoscape Config for the TrafficMap
 func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) (result Config) {
 	nodes := []*NodeWrapper{}
 	edges := []*EdgeWrapper{}
@@ -173,13 +264,16 @@ func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) (result Confi
 	buildConfig(trafficMap, &nodes, &edges, o)
 
 	// Add compound nodes as needed, inner boxes first
-	if strings.Contains(o.BoxBy, graph.BoxByApp) || o.GraphType == graph.GraphTypeApp || o.GraphType == graph.GraphTypeVersionedApp {
+	if o.IsBoxBy(graph.BoxByApp) || o.GraphType == graph.GraphTypeApp || o.GraphType == graph.GraphTypeVersionedApp {
 		boxByApp(&nodes)
 	}
-	if strings.Contains(o.BoxBy, graph.BoxByNamespace) {
+	if label := o.BoxByLabel(); label != "" {
+		boxByLabel(&nodes, label)
+	}
+	if o.IsBoxBy(graph.BoxByNamespace) {
 		boxByNamespace(&nodes)
 	}
-	if strings.Contains(o.BoxBy, graph.BoxByCluster) {
+	if o.IsBoxBy(graph.BoxByCluster) {
 		boxByCluster(&nodes)
 	}
 
@@ -189,15 +283,17 @@ func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) (result Confi
 		switch {
 		case nodes[i].Data.IsBox != nodes[j].Data.IsBox:
 			rank := func(boxBy string) int {
-				switch boxBy {
-				case graph.BoxByCluster:
+				switch {
+				case boxBy == graph.BoxByCluster:
 					return 0
-				case graph.BoxByNamespace:
+				case boxBy == graph.BoxByNamespace:
 					return 1
-				case graph.BoxByApp:
+				case strings.HasPrefix(boxBy, graph.BoxByLabelPrefix):
 					return 2
-				default:
+				case boxBy == graph.BoxByApp:
 					return 3
+				default:
+					return 4
 				}
 			}
 			return rank(nodes[i].Data.IsBox) < rank(nodes[j].Data.IsBox)
@@ -234,6 +330,10 @@ func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) (result Confi
 		GraphType: o.GraphType,
 		Elements:  elements,
 	}
+	if o.Compare.IsEnabled() {
+		result.CompareDuration = int64(o.Compare.Duration.Seconds())
+		result.CompareTimestamp = o.Compare.QueryTime
+	}
 	return result
 }
 
@@ -254,6 +354,12 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 
 		addNodeTelemetry(n, nd)
 
+		// node may be tagged for a diff graph
+		if val, ok := n.Metadata[graph.DiffStatus]; ok {
+			nd.DiffStatus = val.(string)
+			addNodeDeltas(n, nd)
+		}
+
 		if val, ok := n.Metadata[graph.HealthData]; ok {
 			nd.HealthData = val
 		}
@@ -271,6 +377,11 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			nd.HasHealthConfig = val.(map[string]string)
 		}
 
+		// node may have anomalous incoming traffic
+		if val, ok := n.Metadata[graph.Anomaly]; ok {
+			nd.Anomaly = (*AnomalyInfo)(val.(*graph.AnomalyMetadata))
+		}
+
 		// node may have deployment but no pods running)
 		if val, ok := n.Metadata[graph.IsDead]; ok {
 			nd.IsDead = val.(bool)
@@ -429,6 +540,12 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			}
 			addEdgeTelemetry(e, &ed)
 
+			// edge may be tagged for a diff graph
+			if val, ok := e.Metadata[graph.DiffStatus]; ok {
+				ed.DiffStatus = val.(string)
+				addEdgeDeltas(e, &ed)
+			}
+
 			ew := EdgeWrapper{
 				Data: &ed,
 			}
@@ -458,6 +575,9 @@ func addNodeTelemetry(n *graph.Node, nd *NodeData) {
 }
 
 func addEdgeTelemetry(e *graph.Edge, ed *EdgeData) {
+	if val, ok := e.Metadata[graph.Anomaly]; ok {
+		ed.Anomaly = (*AnomalyInfo)(val.(*graph.AnomalyMetadata))
+	}
 	if val, ok := e.Metadata[graph.IsMTLS]; ok {
 		ed.IsMTLS = fmt.Sprintf("%.0f", val.(float64))
 	}
@@ -545,6 +665,57 @@ func addEdgeTelemetry(e *graph.Edge, ed *EdgeData) {
 	}
 }
 
+func addNodeDeltas(n *graph.Node, nd *NodeData) {
+	deltas, ok := n.Metadata[graph.DiffDeltas].(graph.DiffDeltasMetadata)
+	if !ok {
+		return
+	}
+
+	for _, p := range graph.Protocols {
+		var protocolTraffic *ProtocolTraffic
+		for i := range nd.Traffic {
+			if nd.Traffic[i].Protocol == p.Name {
+				protocolTraffic = &nd.Traffic[i]
+				break
+			}
+		}
+		for _, r := range p.NodeRates {
+			if delta, ok := deltas[r.Name]; ok {
+				// a removed node, or a node no longer reporting this protocol, has no current traffic
+				if protocolTraffic == nil {
+					nd.Traffic = append(nd.Traffic, ProtocolTraffic{Protocol: p.Name})
+					protocolTraffic = &nd.Traffic[len(nd.Traffic)-1]
+				}
+				if protocolTraffic.Deltas == nil {
+					protocolTraffic.Deltas = make(map[string]string)
+				}
+				protocolTraffic.Deltas[string(r.Name)] = deltaToString(r.Precision, delta)
+			}
+		}
+	}
+}
+
+func addEdgeDeltas(e *graph.Edge, ed *EdgeData) {
+	deltas, ok := e.Metadata[graph.DiffDeltas].(graph.DiffDeltasMetadata)
+	if !ok {
+		return
+	}
+
+	for _, p := range graph.Protocols {
+		if p.Name != ed.Traffic.Protocol {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			if delta, ok := deltas[r.Name]; ok {
+				if ed.Traffic.Deltas == nil {
+					ed.Traffic.Deltas = make(map[string]string)
+				}
+				ed.Traffic.Deltas[string(r.Name)] = deltaToString(r.Precision, delta)
+			}
+		}
+	}
+}
+
 func getRate(md graph.Metadata, k graph.MetadataKey) float64 {
 	if rate, ok := md[k]; ok {
 		return rate.(float64)
@@ -566,6 +737,41 @@ func boxByApp(nodes *[]*NodeWrapper) {
 	generateBoxCompoundNodes(box, nodes, graph.BoxByApp)
 }
 
+// boxByLabel adds compound nodes to box nodes in the same namespace with the same value for the label. App boxes
+// are boxed when all of their members have the same label value.
+func boxByLabel(nodes *[]*NodeWrapper, label string) {
+	// carry the label value to app boxes, when shared by all members
+	appBoxValues := make(map[string]string)
+	for _, nw := range *nodes {
+		if nw.Data.Parent == "" {
+			continue
+		}
+		value, seen := appBoxValues[nw.Data.Parent]
+		switch {
+		case !seen:
+			appBoxValues[nw.Data.Parent] = nw.Data.Labels[label]
+		case value != nw.Data.Labels[label]:
+			appBoxValues[nw.Data.Parent] = ""
+		}
+	}
+	for _, nw := range *nodes {
+		if value := appBoxValues[nw.Data.ID]; value != "" && nw.Data.IsBox == graph.BoxByApp {
+			nw.Data.Labels = map[string]string{label: value}
+		}
+	}
+
+	box := make(map[string][]*NodeData)
+	for _, nw := range *nodes {
+		// never box unknown
+		if value := nw.Data.Labels[label]; value != "" && nw.Data.Parent == "" && nw.Data.Namespace != graph.Unknown {
+			k := fmt.Sprintf("box_%s_%s_%s_%s", nw.Data.Cluster, nw.Data.Namespace, label, value)
+			box[k] = append(box[k], nw.Data)
+		}
+	}
+
+	generateBoxCompoundNodes(box, nodes, graph.BoxByLabelPrefix+label)
+}
+
 // boxByNamespace adds compound nodes to box nodes in the same namespace
 func boxByNamespace(nodes *[]*NodeWrapper) {
 	box := make(map[string][]*NodeData)
@@ -605,12 +811,17 @@ func generateBoxCompoundNodes(box map[string][]*NodeData, nodes *[]*NodeWrapper,
 			nodeID := nodeHash(k)
 			namespace := ""
 			app := ""
-			switch boxBy {
-			case graph.BoxByNamespace:
+			var labels map[string]string
+			switch {
+			case boxBy == graph.BoxByNamespace:
 				namespace = members[0].Namespace
-			case graph.BoxByApp:
+			case boxBy == graph.BoxByApp:
 				namespace = members[0].Namespace
 				app = members[0].App
+			case strings.HasPrefix(boxBy, graph.BoxByLabelPrefix):
+				label := strings.TrimPrefix(boxBy, graph.BoxByLabelPrefix)
+				namespace = members[0].Namespace
+				labels = map[string]string{label: members[0].Labels[label]}
 			}
 			nd := NodeData{
 				ID:        nodeID,
@@ -619,6 +830,7 @@ func generateBoxCompoundNodes(box map[string][]*NodeData, nodes *[]*NodeWrapper,
 				Namespace: namespace,
 				App:       app,
 				Version:   "",
+				Labels:    labels,
 				IsBox:     boxBy,
 			}
 
@@ -667,6 +879,15 @@ func rateToString(minPrecision int, rateVal float64) string {
 	return fmt.Sprintf("%.*f", precision, rateVal)
 }
 
+func deltaToString(minPrecision int, deltaVal float64) string {
+	precision := minPrecision
+	if requiredPrecision := calcPrecision(math.Abs(deltaVal), 5); requiredPrecision > minPrecision {
+		precision = requiredPrecision
+	}
+
+	return fmt.Sprintf("%+.*f", precision, deltaVal)
+}
+
 // calcPrecision returns the precision necessary to see at least one significant digit (up to max)
 func calcPrecision(val float64, max int) int {
 	if val <= 0 {
@@ -141,3 +141,138 @@ func TestHTTPToTrafficRate(t *testing.T) {
 	assert.NotNil(cytoNode.Data.Traffic)
 	assert.NotNil(cytoNode.Data.Traffic.Rates)
 }
+
+func TestDiffDeltas(t *testing.T) {
+	assert := assert.New(t)
+
+	traffic := graph.NewTrafficMap()
+
+	svc, _ := graph.NewNode("testCluster", "appNamespace", "ratings", "appNamespace", "", "ratings", "", graph.GraphTypeVersionedApp)
+	svc.Metadata[graph.DiffStatus] = graph.DiffStatusRemoved
+	svc.Metadata[graph.DiffDeltas] = graph.DiffDeltasMetadata{"httpOut": -1.5}
+	traffic[svc.ID] = svc
+
+	v1, _ := graph.NewNode("testCluster", "appNamespace", "", "appNamespace", "ratings-v1", "ratings", "v1", graph.GraphTypeVersionedApp)
+	traffic[v1.ID] = v1
+
+	e := svc.AddEdge(v1)
+	e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	e.Metadata[graph.DiffStatus] = graph.DiffStatusRemoved
+	e.Metadata[graph.DiffDeltas] = graph.DiffDeltasMetadata{"http": -1.5, "httpPercentErr": 0.05}
+
+	cytoConfig := NewConfig(traffic, graph.ConfigOptions{Compare: graph.CompareOptions{QueryTime: 1000}})
+	assert.Equal(int64(1000), cytoConfig.CompareTimestamp)
+
+	for _, n := range cytoConfig.Elements.Nodes {
+		if n.Data.Workload == "" {
+			assert.Equal(graph.DiffStatusRemoved, n.Data.DiffStatus)
+			assert.Equal(1, len(n.Data.Traffic))
+			assert.Empty(n.Data.Traffic[0].Rates)
+			assert.Equal("-1.50", n.Data.Traffic[0].Deltas["httpOut"])
+		} else {
+			assert.Empty(n.Data.DiffStatus)
+		}
+	}
+
+	cytoEdge := cytoConfig.Elements.Edges[0]
+	assert.Equal(graph.DiffStatusRemoved, cytoEdge.Data.DiffStatus)
+	assert.Equal(graph.HTTP.Name, cytoEdge.Data.Traffic.Protocol)
+	assert.Empty(cytoEdge.Data.Traffic.Rates)
+	assert.Equal("-1.50", cytoEdge.Data.Traffic.Deltas["http"])
+	assert.Equal("+0.05", cytoEdge.Data.Traffic.Deltas["httpPercentErr"])
+}
+
+func TestDelta(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeA := &NodeWrapper{Data: &NodeData{ID: "a", NodeType: graph.NodeTypeWorkload, Workload: "a"}}
+	nodeB := &NodeWrapper{Data: &NodeData{ID: "b", NodeType: graph.NodeTypeWorkload, Workload: "b"}}
+	nodeC := &NodeWrapper{Data: &NodeData{ID: "c", NodeType: graph.NodeTypeWorkload, Workload: "c"}}
+	edgeAB := &EdgeWrapper{Data: &EdgeData{ID: "ab", Source: "a", Target: "b", ResponseTime: "10"}}
+	edgeAC := &EdgeWrapper{Data: &EdgeData{ID: "ac", Source: "a", Target: "c"}}
+
+	previous := Config{
+		Timestamp: 100,
+		Elements: Elements{
+			Nodes: []*NodeWrapper{nodeA, nodeB},
+			Edges: []*EdgeWrapper{edgeAB},
+		},
+	}
+
+	delta := NewDelta(previous, previous)
+	assert.True(delta.IsEmpty())
+
+	updatedA := &NodeWrapper{Data: &NodeData{ID: "a", NodeType: graph.NodeTypeWorkload, Workload: "a", IsIdle: true}}
+	updatedAB := &EdgeWrapper{Data: &EdgeData{ID: "ab", Source: "a", Target: "b", ResponseTime: "20"}}
+	current := Config{
+		Timestamp: 115,
+		Elements: Elements{
+			Nodes: []*NodeWrapper{updatedA, nodeC},
+			Edges: []*EdgeWrapper{updatedAB, edgeAC},
+		},
+	}
+
+	delta = NewDelta(previous, current)
+	assert.False(delta.IsEmpty())
+	assert.Equal(int64(115), delta.Timestamp)
+	assert.Equal([]*NodeWrapper{nodeC}, delta.AddedNodes)
+	assert.Equal([]*NodeWrapper{updatedA}, delta.UpdatedNodes)
+	assert.Equal([]string{"b"}, delta.RemovedNodes)
+	assert.Equal([]*EdgeWrapper{edgeAC}, delta.AddedEdges)
+	assert.Equal([]*EdgeWrapper{updatedAB}, delta.UpdatedEdges)
+	assert.Equal([]string{}, delta.RemovedEdges)
+}
+
+func TestBoxByLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	traffic := graph.NewTrafficMap()
+	addNode := func(app, version, team string) *graph.Node {
+		n, _ := graph.NewNode("testCluster", "bookinfo", app, "bookinfo", app+"-"+version, app, version, graph.GraphTypeVersionedApp)
+		if team != "" {
+			n.Metadata[graph.Labels] = graph.LabelsMetadata{"team": team}
+		}
+		traffic[n.ID] = n
+		return n
+	}
+	addNode("productpage", "v1", "web")
+	addNode("reviews", "v1", "backend")
+	addNode("reviews", "v2", "backend")
+	addNode("ratings", "v1", "backend")
+	addNode("details", "v1", "")
+
+	o := graph.ConfigOptions{BoxBy: "label:team"}
+	o.GraphType = graph.GraphTypeVersionedApp
+	cytoConfig := NewConfig(traffic, o)
+
+	var labelBoxes, appBoxes []*NodeData
+	nodes := map[string]*NodeData{}
+	for _, nw := range cytoConfig.Elements.Nodes {
+		nodes[nw.Data.ID] = nw.Data
+		switch nw.Data.IsBox {
+		case "label:team":
+			labelBoxes = append(labelBoxes, nw.Data)
+		case graph.BoxByApp:
+			appBoxes = append(appBoxes, nw.Data)
+		}
+	}
+
+	// only the backend team has multiple members, the reviews app box is boxed as a whole
+	assert.Len(labelBoxes, 1)
+	assert.Len(appBoxes, 1)
+	labelBox := labelBoxes[0]
+	assert.Equal("backend", labelBox.Label())
+	assert.Equal("bookinfo", labelBox.Namespace)
+	assert.Equal(map[string]string{"team": "backend"}, appBoxes[0].Labels)
+	assert.Equal(labelBox.ID, appBoxes[0].Parent)
+	assert.Equal(labelBoxes[0], nodes[cytoConfig.Elements.Nodes[0].Data.ID], "label boxes must precede app boxes")
+
+	for _, nd := range nodes {
+		switch {
+		case nd.App == "ratings":
+			assert.Equal(labelBox.ID, nd.Parent)
+		case nd.App == "productpage" || nd.App == "details":
+			assert.Empty(nd.Parent)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cytoscape
+
+import (
+	"reflect"
+)
+
+// Delta holds the element changes between two Configs for the same options. Elements are matched
+// by ID, which is stable for a node or edge across graph generations.
+type Delta struct {
+	Timestamp    int64          `json:"timestamp"`
+	Duration     int64          `json:"duration"`
+	AddedNodes   []*NodeWrapper `json:"addedNodes"`
+	UpdatedNodes []*NodeWrapper `json:"updatedNodes"`
+	RemovedNodes []string       `json:"removedNodes"` // node IDs
+	AddedEdges   []*EdgeWrapper `json:"addedEdges"`
+	UpdatedEdges []*EdgeWrapper `json:"updatedEdges"`
+	RemovedEdges []string       `json:"removedEdges"` // edge IDs
+}
+
+// NewDelta returns the Delta that, applied to the previous Config, produces the current Config.
+func NewDelta(previous, current Config) Delta {
+	delta := Delta{
+		Timestamp:    current.Timestamp,
+		Duration:     current.Duration,
+		AddedNodes:   []*NodeWrapper{},
+		UpdatedNodes: []*NodeWrapper{},
+		RemovedNodes: []string{},
+		AddedEdges:   []*EdgeWrapper{},
+		UpdatedEdges: []*EdgeWrapper{},
+		RemovedEdges: []string{},
+	}
+
+	previousNodes := make(map[string]*NodeData, len(previous.Elements.Nodes))
+	for _, nw := range previous.Elements.Nodes {
+		previousNodes[nw.Data.ID] = nw.Data
+	}
+	for _, nw := range current.Elements.Nodes {
+		previousNode, found := previousNodes[nw.Data.ID]
+		switch {
+		case !found:
+			delta.AddedNodes = append(delta.AddedNodes, nw)
+		case !reflect.DeepEqual(previousNode, nw.Data):
+			delta.UpdatedNodes = append(delta.UpdatedNodes, nw)
+		}
+		delete(previousNodes, nw.Data.ID)
+	}
+	for _, nw := range previous.Elements.Nodes {
+		if _, removed := previousNodes[nw.Data.ID]; removed {
+			delta.RemovedNodes = append(delta.RemovedNodes, nw.Data.ID)
+		}
+	}
+
+	previousEdges := make(map[string]*EdgeData, len(previous.Elements.Edges))
+	for _, ew := range previous.Elements.Edges {
+		previousEdges[ew.Data.ID] = ew.Data
+	}
+	for _, ew := range current.Elements.Edges {
+		previousEdge, found := previousEdges[ew.Data.ID]
+		switch {
+		case !found:
+			delta.AddedEdges = append(delta.AddedEdges, ew)
+		case !reflect.DeepEqual(previousEdge, ew.Data):
+			delta.UpdatedEdges = append(delta.UpdatedEdges, ew)
+		}
+		delete(previousEdges, ew.Data.ID)
+	}
+	for _, ew := range previous.Elements.Edges {
+		if _, removed := previousEdges[ew.Data.ID]; removed {
+			delta.RemovedEdges = append(delta.RemovedEdges, ew.Data.ID)
+		}
+	}
+
+	return delta
+}
+
+// IsEmpty returns true if there are no element changes
+func (d Delta) IsEmpty() bool {
+	return len(d.AddedNodes) == 0 && len(d.UpdatedNodes) == 0 && len(d.RemovedNodes) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.UpdatedEdges) == 0 && len(d.RemovedEdges) == 0
+}
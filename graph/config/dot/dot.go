@@ -0,0 +1,115 @@
+// Package dot provides conversion from our graph to the Graphviz DOT language.
+//
+// DOT language: https://graphviz.org/doc/info/lang.html
+//
+// Algorithm: Generate the Cytoscape config, to re-use the node and edge decoration as well as the
+// requested boxing, and then render it as a DOT digraph.  Box (compound) nodes are rendered
+// as nested cluster subgraphs.
+//
+// The package provides the DOT implementation of graph/ConfigVendor.
+package dot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+const contentType = "text/vnd.graphviz; charset=utf-8"
+
+// Vendor implements graph/ConfigVendor, it is registered as graph.VendorDOT
+type Vendor struct{}
+
+func init() {
+	graph.RegisterConfigVendor(graph.VendorDOT, Vendor{})
+}
+
+// NewConfig is required by the graph/ConfigVendor interface
+func (v Vendor) NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) interface{} {
+	return NewConfig(trafficMap, o)
+}
+
+// NewConfig returns the DOT digraph for the TrafficMap
+func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) graph.TextConfig {
+	cyConfig := cytoscape.NewConfig(trafficMap, o)
+	nodesByParent := cyConfig.Elements.NodesByParent()
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "digraph %s {\n", quote(fmt.Sprintf("kiali %s graph", o.GraphType)))
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [fontname=\"Helvetica\", style=\"filled\", fillcolor=\"white\"];\n")
+	sb.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	writeNodes(sb, nodesByParent, "", 1)
+
+	for _, ew := range cyConfig.Elements.Edges {
+		ed := ew.Data
+		attrs := []string{fmt.Sprintf("label=%s", quote(ed.Label()))}
+		if ed.DiffStatus == graph.DiffStatusRemoved {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(sb, "  %s -> %s [%s];\n", quote(ed.Source), quote(ed.Target), strings.Join(attrs, ", "))
+	}
+	sb.WriteString("}\n")
+
+	return graph.TextConfig{ContentType: contentType, Text: sb.String()}
+}
+
+// writeNodes recursively writes the nodes of the parent, box nodes are written as cluster subgraphs
+func writeNodes(sb *strings.Builder, nodesByParent map[string][]*cytoscape.NodeData, parent string, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, nd := range nodesByParent[parent] {
+		if nd.NodeType == graph.NodeTypeBox {
+			fmt.Fprintf(sb, "%ssubgraph %s {\n", indent, quote("cluster_"+nd.ID))
+			fmt.Fprintf(sb, "%s  label=%s;\n", indent, quote(fmt.Sprintf("%s: %s", nd.IsBox, nd.Label())))
+			writeNodes(sb, nodesByParent, nd.ID, depth+1)
+			fmt.Fprintf(sb, "%s}\n", indent)
+			continue
+		}
+		attrs := []string{
+			fmt.Sprintf("label=%s", quote(nodeLabel(nd))),
+			fmt.Sprintf("shape=%s", shape(nd)),
+		}
+		if nd.IsDead || nd.IsIdle || nd.DiffStatus == graph.DiffStatusRemoved {
+			attrs = append(attrs, "style=\"filled,dashed\"")
+		}
+		fmt.Fprintf(sb, "%s%s [%s];\n", indent, quote(nd.ID), strings.Join(attrs, ", "))
+	}
+}
+
+// nodeLabel adds the namespace, and any diff status, to the node label
+func nodeLabel(nd *cytoscape.NodeData) string {
+	label := fmt.Sprintf("%s\n%s", nd.Label(), nd.Namespace)
+	if nd.DiffStatus != "" && nd.DiffStatus != graph.DiffStatusUnchanged {
+		label = fmt.Sprintf("%s\n(%s)", label, nd.DiffStatus)
+	}
+	return label
+}
+
+func shape(nd *cytoscape.NodeData) string {
+	switch nd.NodeType {
+	case graph.NodeTypeAggregate:
+		return "pentagon"
+	case graph.NodeTypeApp:
+		return "box"
+	case graph.NodeTypeService:
+		if nd.IsServiceEntry != nil {
+			return "diamond"
+		}
+		return "triangle"
+	case graph.NodeTypeWorkload:
+		return "ellipse"
+	default:
+		return "octagon"
+	}
+}
+
+// quote returns the DOT double-quoted string
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
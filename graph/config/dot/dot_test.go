@@ -0,0 +1,44 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func TestNewConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	traffic := graph.NewTrafficMap()
+	productpage, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	reviewsV1, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	reviewsV2, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v2", "reviews", "v2", graph.GraphTypeVersionedApp)
+	traffic[productpage.ID] = productpage
+	traffic[reviewsV1.ID] = reviewsV1
+	traffic[reviewsV2.ID] = reviewsV2
+
+	e := productpage.AddEdge(reviewsV1)
+	e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	graph.AddToMetadata(graph.HTTP.Name, 10.0, "200", "-", "reviews", productpage.Metadata, reviewsV1.Metadata, e.Metadata)
+
+	config := NewConfig(traffic, graph.ConfigOptions{BoxBy: graph.BoxByApp, CommonOptions: graph.CommonOptions{GraphType: graph.GraphTypeVersionedApp}})
+
+	assert.Equal(contentType, config.ContentType)
+	assert.True(strings.HasPrefix(config.Text, `digraph "kiali versionedApp graph" {`))
+	assert.True(strings.HasSuffix(config.Text, "}\n"))
+	// the two reviews versions are boxed, productpage has only one member and is not boxed
+	assert.Equal(1, strings.Count(config.Text, "subgraph"))
+	assert.Contains(config.Text, `label="app: reviews";`)
+	assert.Contains(config.Text, `label="reviews v2\nbookinfo", shape=box]`)
+	assert.Contains(config.Text, `[label="http 10.00rps"];`)
+	assert.Equal(1, strings.Count(config.Text, "->"))
+}
+
+func TestQuote(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`"a\"b\\c\nd"`, quote("a\"b\\c\nd"))
+}
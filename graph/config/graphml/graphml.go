@@ -0,0 +1,173 @@
+// Package graphml provides conversion from our graph to the GraphML XML format, as supported by tools
+// like Gephi and yEd.
+//
+// GraphML primer: http://graphml.graphdrawing.org/primer/graphml-primer.html
+//
+// Algorithm: Generate the Cytoscape config, to re-use the node and edge decoration as well as the
+// requested boxing, and then render it as GraphML.  Box (compound) nodes are rendered as
+// nodes holding a nested graph. Node and edge information is provided as GraphML data.
+//
+// The package provides the GraphML implementation of graph/ConfigVendor.
+package graphml
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+const (
+	contentType = "application/graphml+xml; charset=utf-8"
+	namespace   = "http://graphml.graphdrawing.org/xmlns"
+)
+
+// Key declares a GraphML data attribute
+type Key struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+// Data holds a GraphML data value for a declared Key
+type Data struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type Node struct {
+	ID    string `xml:"id,attr"`
+	Data  []Data `xml:"data"`
+	Graph *Graph `xml:"graph,omitempty"` // set for box nodes
+}
+
+type Edge struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Data   []Data `xml:"data"`
+}
+
+type Graph struct {
+	ID          string `xml:"id,attr"`
+	EdgeDefault string `xml:"edgedefault,attr"`
+	Nodes       []Node `xml:"node"`
+	Edges       []Edge `xml:"edge"`
+}
+
+type GraphML struct {
+	XMLName xml.Name `xml:"graphml"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	Keys    []Key    `xml:"key"`
+	Graph   Graph    `xml:"graph"`
+}
+
+var keys = []Key{
+	{ID: "label", For: "all", AttrName: "label", AttrType: "string"},
+	{ID: "diffStatus", For: "all", AttrName: "diffStatus", AttrType: "string"},
+	{ID: "nodeType", For: "node", AttrName: "nodeType", AttrType: "string"},
+	{ID: "cluster", For: "node", AttrName: "cluster", AttrType: "string"},
+	{ID: "namespace", For: "node", AttrName: "namespace", AttrType: "string"},
+	{ID: "workload", For: "node", AttrName: "workload", AttrType: "string"},
+	{ID: "app", For: "node", AttrName: "app", AttrType: "string"},
+	{ID: "version", For: "node", AttrName: "version", AttrType: "string"},
+	{ID: "service", For: "node", AttrName: "service", AttrType: "string"},
+	{ID: "isBox", For: "node", AttrName: "isBox", AttrType: "string"},
+	{ID: "isIdle", For: "node", AttrName: "isIdle", AttrType: "boolean"},
+	{ID: "protocol", For: "edge", AttrName: "protocol", AttrType: "string"},
+	{ID: "rate", For: "edge", AttrName: "rate", AttrType: "double"},
+	{ID: "percentErr", For: "edge", AttrName: "percentErr", AttrType: "double"},
+	{ID: "responseTime", For: "edge", AttrName: "responseTime", AttrType: "double"},
+	{ID: "isMTLS", For: "edge", AttrName: "isMTLS", AttrType: "double"},
+}
+
+// Vendor implements graph/ConfigVendor, it is registered as graph.VendorGraphML
+type Vendor struct{}
+
+func init() {
+	graph.RegisterConfigVendor(graph.VendorGraphML, Vendor{})
+}
+
+// NewConfig is required by the graph/ConfigVendor interface
+func (v Vendor) NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) interface{} {
+	return NewConfig(trafficMap, o)
+}
+
+// NewConfig returns the GraphML document for the TrafficMap
+func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) graph.TextConfig {
+	cyConfig := cytoscape.NewConfig(trafficMap, o)
+	nodesByParent := cyConfig.Elements.NodesByParent()
+
+	doc := GraphML{
+		XMLNS: namespace,
+		Keys:  keys,
+		Graph: Graph{
+			ID:          "G",
+			EdgeDefault: "directed",
+			Nodes:       buildNodes(nodesByParent, ""),
+		},
+	}
+
+	// edges are declared in the top-level graph, which is the ancestor of every node
+	for _, ew := range cyConfig.Elements.Edges {
+		ed := ew.Data
+		edge := Edge{
+			ID:     ed.ID,
+			Source: ed.Source,
+			Target: ed.Target,
+		}
+		edge.Data = appendData(edge.Data, "label", ed.Label())
+		edge.Data = appendData(edge.Data, "diffStatus", ed.DiffStatus)
+		edge.Data = appendData(edge.Data, "protocol", ed.Traffic.Protocol)
+		edge.Data = appendData(edge.Data, "rate", ed.Traffic.Rates[ed.Traffic.Protocol])
+		edge.Data = appendData(edge.Data, "percentErr", ed.Traffic.Rates[ed.Traffic.Protocol+"PercentErr"])
+		edge.Data = appendData(edge.Data, "responseTime", ed.ResponseTime)
+		edge.Data = appendData(edge.Data, "isMTLS", ed.IsMTLS)
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	graph.CheckError(err)
+
+	return graph.TextConfig{ContentType: contentType, Text: xml.Header + string(out) + "\n"}
+}
+
+// buildNodes recursively builds the nodes of the parent, box nodes hold a nested graph of their members
+func buildNodes(nodesByParent map[string][]*cytoscape.NodeData, parent string) []Node {
+	nodes := []Node{}
+	for _, nd := range nodesByParent[parent] {
+		node := Node{ID: nd.ID}
+		node.Data = appendData(node.Data, "label", nd.Label())
+		node.Data = appendData(node.Data, "diffStatus", nd.DiffStatus)
+		node.Data = appendData(node.Data, "nodeType", nd.NodeType)
+		node.Data = appendData(node.Data, "cluster", nd.Cluster)
+		node.Data = appendData(node.Data, "namespace", nd.Namespace)
+		node.Data = appendData(node.Data, "workload", nd.Workload)
+		node.Data = appendData(node.Data, "app", nd.App)
+		node.Data = appendData(node.Data, "version", nd.Version)
+		node.Data = appendData(node.Data, "service", nd.Service)
+		node.Data = appendData(node.Data, "isBox", nd.IsBox)
+		if nd.IsIdle {
+			node.Data = appendData(node.Data, "isIdle", fmt.Sprintf("%t", nd.IsIdle))
+		}
+		if nd.NodeType == graph.NodeTypeBox {
+			node.Graph = &Graph{
+				ID:          nd.ID + ":",
+				EdgeDefault: "directed",
+				Nodes:       buildNodes(nodesByParent, nd.ID),
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// appendData appends the data value, unless it is empty
+func appendData(data []Data, key, value string) []Data {
+	if value == "" {
+		return data
+	}
+	return append(data, Data{Key: key, Value: value})
+}
@@ -0,0 +1,49 @@
+package graphml
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func TestNewConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	traffic := graph.NewTrafficMap()
+	productpage, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	reviewsV1, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	reviewsV2, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v2", "reviews", "v2", graph.GraphTypeVersionedApp)
+	traffic[productpage.ID] = productpage
+	traffic[reviewsV1.ID] = reviewsV1
+	traffic[reviewsV2.ID] = reviewsV2
+
+	e := productpage.AddEdge(reviewsV1)
+	e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	graph.AddToMetadata(graph.HTTP.Name, 8.0, "200", "-", "reviews", productpage.Metadata, reviewsV1.Metadata, e.Metadata)
+	graph.AddToMetadata(graph.HTTP.Name, 2.0, "500", "-", "reviews", productpage.Metadata, reviewsV1.Metadata, e.Metadata)
+
+	config := NewConfig(traffic, graph.ConfigOptions{BoxBy: graph.BoxByApp, CommonOptions: graph.CommonOptions{GraphType: graph.GraphTypeVersionedApp}})
+	assert.Equal(contentType, config.ContentType)
+
+	doc := GraphML{}
+	assert.NoError(xml.Unmarshal([]byte(config.Text), &doc))
+	assert.Equal(len(keys), len(doc.Keys))
+
+	// top-level: productpage and the reviews app box, holding both versions
+	assert.Equal(2, len(doc.Graph.Nodes))
+	box := doc.Graph.Nodes[0]
+	assert.NotNil(box.Graph)
+	assert.Equal(2, len(box.Graph.Nodes))
+	assert.Contains(box.Data, Data{Key: "isBox", Value: graph.BoxByApp})
+	assert.Nil(doc.Graph.Nodes[1].Graph)
+
+	assert.Equal(1, len(doc.Graph.Edges))
+	edge := doc.Graph.Edges[0]
+	assert.Equal(doc.Graph.Nodes[1].ID, edge.Source)
+	assert.Contains(edge.Data, Data{Key: "protocol", Value: "http"})
+	assert.Contains(edge.Data, Data{Key: "rate", Value: "10.00"})
+	assert.Contains(edge.Data, Data{Key: "percentErr", Value: "20.0"})
+}
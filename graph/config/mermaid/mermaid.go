@@ -0,0 +1,98 @@
+// Package mermaid provides conversion from our graph to a Mermaid flowchart, which can be embedded
+// in markdown documents.
+//
+// Flowchart syntax: https://mermaid.js.org/syntax/flowchart.html
+//
+// Algorithm: Generate the Cytoscape config, to re-use the node and edge decoration as well as the
+// requested boxing, and then render it as a Mermaid flowchart.  Box (compound) nodes are
+// rendered as nested subgraphs.
+//
+// The package provides the Mermaid implementation of graph/ConfigVendor.
+package mermaid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+const contentType = "text/plain; charset=utf-8"
+
+// Vendor implements graph/ConfigVendor, it is registered as graph.VendorMermaid
+type Vendor struct{}
+
+func init() {
+	graph.RegisterConfigVendor(graph.VendorMermaid, Vendor{})
+}
+
+// NewConfig is required by the graph/ConfigVendor interface
+func (v Vendor) NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) interface{} {
+	return NewConfig(trafficMap, o)
+}
+
+// NewConfig returns the Mermaid flowchart for the TrafficMap
+func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) graph.TextConfig {
+	cyConfig := cytoscape.NewConfig(trafficMap, o)
+	nodesByParent := cyConfig.Elements.NodesByParent()
+
+	sb := &strings.Builder{}
+	sb.WriteString("flowchart LR\n")
+
+	writeNodes(sb, nodesByParent, "", 1)
+
+	for _, ew := range cyConfig.Elements.Edges {
+		ed := ew.Data
+		arrow := "-->"
+		if ed.DiffStatus == graph.DiffStatusRemoved {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(sb, "  %s %s|%s| %s\n", id(ed.Source), arrow, quote(ed.Label()), id(ed.Target))
+	}
+
+	return graph.TextConfig{ContentType: contentType, Text: sb.String()}
+}
+
+// writeNodes recursively writes the nodes of the parent, box nodes are written as subgraphs
+func writeNodes(sb *strings.Builder, nodesByParent map[string][]*cytoscape.NodeData, parent string, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, nd := range nodesByParent[parent] {
+		if nd.NodeType == graph.NodeTypeBox {
+			fmt.Fprintf(sb, "%ssubgraph %s[%s]\n", indent, id(nd.ID), quote(fmt.Sprintf("%s: %s", nd.IsBox, nd.Label())))
+			writeNodes(sb, nodesByParent, nd.ID, depth+1)
+			fmt.Fprintf(sb, "%send\n", indent)
+			continue
+		}
+		label := quote(nodeLabel(nd))
+		switch nd.NodeType {
+		case graph.NodeTypeApp:
+			fmt.Fprintf(sb, "%s%s[%s]\n", indent, id(nd.ID), label)
+		case graph.NodeTypeService:
+			fmt.Fprintf(sb, "%s%s{{%s}}\n", indent, id(nd.ID), label)
+		case graph.NodeTypeWorkload:
+			fmt.Fprintf(sb, "%s%s([%s])\n", indent, id(nd.ID), label)
+		default:
+			fmt.Fprintf(sb, "%s%s{%s}\n", indent, id(nd.ID), label)
+		}
+	}
+}
+
+// nodeLabel adds the namespace, and any diff status, to the node label
+func nodeLabel(nd *cytoscape.NodeData) string {
+	label := fmt.Sprintf("%s<br/>%s", nd.Label(), nd.Namespace)
+	if nd.DiffStatus != "" && nd.DiffStatus != graph.DiffStatusUnchanged {
+		label = fmt.Sprintf("%s<br/>(%s)", label, nd.DiffStatus)
+	}
+	return label
+}
+
+// id returns a valid Mermaid id for the cytoscape node id (a hash that may start with a digit)
+func id(cyID string) string {
+	return "n" + cyID
+}
+
+// quote returns the Mermaid quoted string, using the entity code for embedded quotes
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,41 @@
+package mermaid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func TestNewConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	traffic := graph.NewTrafficMap()
+	productpage, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+	reviews, _ := graph.NewNode("east", "bookinfo", "reviews", "", "", "", "", graph.GraphTypeWorkload)
+	traffic[productpage.ID] = productpage
+	traffic[reviews.ID] = reviews
+
+	e := productpage.AddEdge(reviews)
+	e.Metadata[graph.ProtocolKey] = graph.TCP.Name
+	e.Metadata[graph.DiffStatus] = graph.DiffStatusRemoved
+
+	config := NewConfig(traffic, graph.ConfigOptions{CommonOptions: graph.CommonOptions{GraphType: graph.GraphTypeWorkload}})
+
+	assert.Equal(contentType, config.ContentType)
+	lines := strings.Split(strings.TrimSpace(config.Text), "\n")
+	assert.Equal(4, len(lines))
+	assert.Equal("flowchart LR", lines[0])
+	assert.True(strings.HasSuffix(lines[1], `{{"reviews<br/>bookinfo"}}`))
+	assert.True(strings.HasSuffix(lines[2], `(["productpage-v1<br/>bookinfo"])`))
+	assert.True(strings.HasPrefix(lines[3], "  n"))
+	assert.Contains(lines[3], ` -.->|"tcp (removed)"| n`)
+}
+
+func TestQuote(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`"say #quot;hi#quot;"`, quote(`say "hi"`))
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"sync"
+	"time"
+)
+
+// Diagnostics collects the steps performed to generate a graph, for tuning purposes. It is collected only
+// when requested (debug=true). A nil Diagnostics is valid and collects nothing.
+type Diagnostics struct {
+	Duration float64            `json:"duration"` // total millis, set by End
+	Steps    []*DiagnosticsStep `json:"steps"`
+
+	current *DiagnosticsStep
+	mutex   sync.Mutex
+	start   time.Time
+}
+
+// DiagnosticsStep is a single step of the graph generation: the traffic map build for a namespace, or an
+// appender run.
+type DiagnosticsStep struct {
+	Name        string             `json:"name"`
+	Namespace   string             `json:"namespace,omitempty"` // not set for finalizers
+	Duration    float64            `json:"duration"`            // millis
+	Queries     []DiagnosticsQuery `json:"queries"`
+	NodesBefore int                `json:"nodesBefore"`
+	NodesAfter  int                `json:"nodesAfter"`
+	EdgesBefore int                `json:"edgesBefore"`
+	EdgesAfter  int                `json:"edgesAfter"`
+
+	start time.Time
+}
+
+// DiagnosticsQuery is a single Prometheus query issued by a step
+type DiagnosticsQuery struct {
+	Query    string  `json:"query"`
+	Duration float64 `json:"duration"` // millis
+	Results  int     `json:"results"`  // number of returned samples
+	Error    string  `json:"error,omitempty"`
+}
+
+// DiagnosticsStepTraffic is the name of the step building the traffic map, before running the appenders
+const DiagnosticsStepTraffic = "traffic"
+
+// NewDiagnostics returns a Diagnostics, with the total duration starting now
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{
+		Steps: []*DiagnosticsStep{},
+		start: time.Now(),
+	}
+}
+
+// StartStep starts a step, the queries are recorded for the step until EndStep
+func (d *Diagnostics) StartStep(name, namespace string, trafficMap TrafficMap) {
+	if d == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.current = &DiagnosticsStep{
+		Name:        name,
+		Namespace:   namespace,
+		Queries:     []DiagnosticsQuery{},
+		NodesBefore: len(trafficMap),
+		EdgesBefore: len(trafficMap.Edges()),
+		start:       time.Now(),
+	}
+	d.Steps = append(d.Steps, d.current)
+}
+
+// EndStep ends the current step, the trafficMap is the result of the step
+func (d *Diagnostics) EndStep(trafficMap TrafficMap) {
+	if d == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.current == nil {
+		return
+	}
+	d.current.Duration = millis(time.Since(d.current.start))
+	d.current.NodesAfter = len(trafficMap)
+	d.current.EdgesAfter = len(trafficMap.Edges())
+	d.current = nil
+}
+
+// AddQuery records a query for the current step. Queries issued outside of a step are ignored.
+func (d *Diagnostics) AddQuery(query string, duration time.Duration, results int, err error) {
+	if d == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.current == nil {
+		return
+	}
+	q := DiagnosticsQuery{
+		Query:    query,
+		Duration: millis(duration),
+		Results:  results,
+	}
+	if err != nil {
+		q.Error = err.Error()
+	}
+	d.current.Queries = append(d.current.Queries, q)
+}
+
+// End sets the total duration
+func (d *Diagnostics) End() {
+	if d == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.Duration = millis(time.Since(d.start))
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000.0
+}
@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	trafficMap := NewTrafficMap()
+	d := NewDiagnostics()
+
+	// queries outside of a step are ignored
+	d.AddQuery("ignored", time.Millisecond, 1, nil)
+
+	d.StartStep(DiagnosticsStepTraffic, "bookinfo", trafficMap)
+	d.AddQuery("query1", 1500*time.Microsecond, 2, nil)
+	d.AddQuery("query2", time.Millisecond, 0, errors.New("timeout"))
+	source, err := NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", GraphTypeVersionedApp)
+	require.NoError(err)
+	dest, err := NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", GraphTypeVersionedApp)
+	require.NoError(err)
+	source.AddEdge(dest)
+	trafficMap[source.ID] = source
+	trafficMap[dest.ID] = dest
+	d.EndStep(trafficMap)
+
+	d.StartStep("finalizer", "", trafficMap)
+	delete(trafficMap, dest.ID)
+	source.Edges = []*Edge{}
+	d.EndStep(trafficMap)
+	d.End()
+
+	require.Len(d.Steps, 2)
+	step := d.Steps[0]
+	assert.Equal(DiagnosticsStepTraffic, step.Name)
+	assert.Equal("bookinfo", step.Namespace)
+	assert.Equal(0, step.NodesBefore)
+	assert.Equal(2, step.NodesAfter)
+	assert.Equal(0, step.EdgesBefore)
+	assert.Equal(1, step.EdgesAfter)
+	require.Len(step.Queries, 2)
+	assert.Equal(DiagnosticsQuery{Query: "query1", Duration: 1.5, Results: 2}, step.Queries[0])
+	assert.Equal("timeout", step.Queries[1].Error)
+
+	step = d.Steps[1]
+	assert.Equal("finalizer", step.Name)
+	assert.Empty(step.Queries)
+	assert.Equal(2, step.NodesBefore)
+	assert.Equal(1, step.NodesAfter)
+	assert.Equal(1, step.EdgesBefore)
+	assert.Equal(0, step.EdgesAfter)
+	assert.True(d.Duration >= step.Duration)
+}
+
+func TestNilDiagnostics(t *testing.T) {
+	var d *Diagnostics
+
+	assert.NotPanics(t, func() {
+		d.StartStep(DiagnosticsStepTraffic, "bookinfo", NewTrafficMap())
+		d.AddQuery("query", time.Millisecond, 1, nil)
+		d.EndStep(NewTrafficMap())
+		d.End()
+	})
+}
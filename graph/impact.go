@@ -0,0 +1,231 @@
+package graph
+
+import (
+	"sort"
+)
+
+// ImpactNode describes a node reached when walking the TrafficMap from the analyzed node(s)
+type ImpactNode struct {
+	ID        string `json:"id"`
+	NodeType  string `json:"nodeType"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload,omitempty"`
+	App       string `json:"app,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Service   string `json:"service,omitempty"`
+	// Depth is the minimum number of hops from the analyzed node(s)
+	Depth int `json:"depth"`
+	// RequestRate is the aggregated request rate (rps) of the edges connecting the node to the rest of the
+	// upstream (or downstream) path(s). Only request traffic (http, grpc) is considered.
+	RequestRate float64 `json:"requestRate"`
+	// ErrorRate is the aggregated error rate (rps) of the same edges
+	ErrorRate float64 `json:"errorRate"`
+}
+
+// ImpactHop is a single edge of the Impact CriticalPath
+type ImpactHop struct {
+	Source       string  `json:"source"` // node ID
+	Dest         string  `json:"dest"`   // node ID
+	Protocol     string  `json:"protocol"`
+	ResponseTime float64 `json:"responseTime"` // in millis
+	RequestRate  float64 `json:"requestRate"`
+	ErrorRate    float64 `json:"errorRate"`
+}
+
+// Impact is the result of an impact analysis for one or more nodes of a TrafficMap
+type Impact struct {
+	// Nodes are the analyzed nodes
+	Nodes []ImpactNode `json:"nodes"`
+	// Upstream are the transitive callers of the analyzed nodes, i.e. the blast radius
+	Upstream []ImpactNode `json:"upstream"`
+	// Downstream are the transitive dependencies of the analyzed nodes
+	Downstream []ImpactNode `json:"downstream"`
+	// CriticalPath is the downstream path with the highest sum of response times, empty if responseTime is not available
+	CriticalPath []ImpactHop `json:"criticalPath"`
+}
+
+// NewImpact walks the TrafficMap edges, starting at the provided nodes, and returns the upstream callers
+// and downstream dependencies, transitively, along with the critical (highest-latency) downstream path.
+func NewImpact(trafficMap TrafficMap, nodes []*Node) Impact {
+	impact := Impact{
+		Nodes:        []ImpactNode{},
+		Upstream:     []ImpactNode{},
+		Downstream:   []ImpactNode{},
+		CriticalPath: []ImpactHop{},
+	}
+
+	// index the incoming edges, to walk upstream
+	incoming := make(map[string][]*Edge)
+	for _, e := range trafficMap.Edges() {
+		incoming[e.Dest.ID] = append(incoming[e.Dest.ID], e)
+	}
+
+	isAnalyzed := make(map[string]bool)
+	for _, n := range nodes {
+		isAnalyzed[n.ID] = true
+		impact.Nodes = append(impact.Nodes, newImpactNode(n, 0))
+	}
+
+	outgoing := func(n *Node) []*Edge { return n.Edges }
+	downstreamDest := func(e *Edge) *Node { return e.Dest }
+	impact.Downstream = walkImpact(nodes, isAnalyzed, outgoing, downstreamDest)
+
+	upstream := func(n *Node) []*Edge { return incoming[n.ID] }
+	upstreamDest := func(e *Edge) *Node { return e.Source }
+	impact.Upstream = walkImpact(nodes, isAnalyzed, upstream, upstreamDest)
+
+	impact.CriticalPath = criticalPath(nodes)
+
+	return impact
+}
+
+// walkImpact performs a breadth-first walk from the analyzed nodes, using the edges and neighbor functions to
+// determine direction. The rates for a reached node are aggregated from every edge connecting it to a node
+// of the walk.
+func walkImpact(nodes []*Node, isAnalyzed map[string]bool, edges func(*Node) []*Edge, neighbor func(*Edge) *Node) []ImpactNode {
+	reached := make(map[string]*ImpactNode)
+	var visitOrder []*Node
+
+	frontier := nodes
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []*Node
+		for _, n := range frontier {
+			visitOrder = append(visitOrder, n)
+			for _, e := range edges(n) {
+				nn := neighbor(e)
+				if _, ok := reached[nn.ID]; ok || isAnalyzed[nn.ID] {
+					continue
+				}
+				in := newImpactNode(nn, depth)
+				reached[nn.ID] = &in
+				next = append(next, nn)
+			}
+		}
+		frontier = next
+	}
+
+	for _, n := range visitOrder {
+		for _, e := range edges(n) {
+			if in, ok := reached[neighbor(e).ID]; ok {
+				rate, errRate := EdgeRequestRates(e)
+				in.RequestRate += rate
+				in.ErrorRate += errRate
+			}
+		}
+	}
+
+	result := make([]ImpactNode, 0, len(reached))
+	for _, in := range reached {
+		result = append(result, *in)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Depth != result[j].Depth {
+			return result[i].Depth < result[j].Depth
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// criticalPath returns the highest-latency downstream path, the path with the highest sum of edge response times.
+// It is the longest path of the DAG of the downstream traffic: the edges back to the analyzed nodes are ignored, and
+// the cycles are broken by ignoring the edges back to a node of the current walk, walking the slowest edges first.
+func criticalPath(nodes []*Node) []ImpactHop {
+	w := criticalPathWalk{
+		analyzed: make(map[string]bool, len(nodes)),
+		onPath:   make(map[string]bool),
+		done:     make(map[string]bool),
+		latency:  make(map[string]float64),
+		next:     make(map[string]*Edge),
+	}
+	for _, n := range nodes {
+		w.analyzed[n.ID] = true
+	}
+
+	var start *Node
+	for _, n := range nodes {
+		w.walk(n)
+		if w.next[n.ID] != nil && (start == nil || w.latency[n.ID] > w.latency[start.ID]) {
+			start = n
+		}
+	}
+
+	path := []ImpactHop{}
+	if start == nil {
+		return path
+	}
+	for e := w.next[start.ID]; e != nil; e = w.next[e.Dest.ID] {
+		rate, errRate := EdgeRequestRates(e)
+		protocol, _ := e.Metadata[ProtocolKey].(string)
+		path = append(path, ImpactHop{
+			Source:       e.Source.ID,
+			Dest:         e.Dest.ID,
+			Protocol:     protocol,
+			ResponseTime: e.Metadata[ResponseTime].(float64),
+			RequestRate:  rate,
+			ErrorRate:    errRate,
+		})
+	}
+	return path
+}
+
+// criticalPathWalk is a depth-first walk computing, for every node, the highest latency of the paths starting at the
+// node and the first edge of that path
+type criticalPathWalk struct {
+	analyzed map[string]bool
+	onPath   map[string]bool
+	done     map[string]bool
+	latency  map[string]float64
+	next     map[string]*Edge
+}
+
+func (w *criticalPathWalk) walk(n *Node) {
+	if w.done[n.ID] {
+		return
+	}
+	w.onPath[n.ID] = true
+
+	edges := []*Edge{}
+	for _, e := range n.Edges {
+		if _, ok := e.Metadata[ResponseTime].(float64); ok {
+			edges = append(edges, e)
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		rti, rtj := edges[i].Metadata[ResponseTime].(float64), edges[j].Metadata[ResponseTime].(float64)
+		if rti != rtj {
+			return rti > rtj
+		}
+		return edges[i].Dest.ID < edges[j].Dest.ID
+	})
+
+	for _, e := range edges {
+		if w.analyzed[e.Dest.ID] || w.onPath[e.Dest.ID] {
+			continue
+		}
+		w.walk(e.Dest)
+		latency := e.Metadata[ResponseTime].(float64) + w.latency[e.Dest.ID]
+		if w.next[n.ID] == nil || latency > w.latency[n.ID] {
+			w.latency[n.ID] = latency
+			w.next[n.ID] = e
+		}
+	}
+
+	delete(w.onPath, n.ID)
+	w.done[n.ID] = true
+}
+
+func newImpactNode(n *Node, depth int) ImpactNode {
+	return ImpactNode{
+		ID:        n.ID,
+		NodeType:  n.NodeType,
+		Cluster:   n.Cluster,
+		Namespace: n.Namespace,
+		Workload:  n.Workload,
+		App:       n.App,
+		Version:   n.Version,
+		Service:   n.Service,
+		Depth:     depth,
+	}
+}
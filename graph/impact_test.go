@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func impactTrafficMap() (TrafficMap, map[string]*Node) {
+	trafficMap := NewTrafficMap()
+	nodes := make(map[string]*Node)
+	for _, name := range []string{"ingress", "productpage", "details", "reviews", "ratings", "db"} {
+		n, _ := NewNode("east", "bookinfo", "", "bookinfo", name, name, "v1", GraphTypeWorkload)
+		trafficMap[n.ID] = n
+		nodes[name] = n
+	}
+
+	addEdge := func(source, dest string, rate, errRate, responseTime float64) {
+		e := nodes[source].AddEdge(nodes[dest])
+		e.Metadata[ProtocolKey] = HTTP.Name
+		e.Metadata[http] = rate
+		e.Metadata[http5xx] = errRate
+		e.Metadata[ResponseTime] = responseTime
+	}
+	addEdge("ingress", "productpage", 10.0, 1.0, 500.0)
+	addEdge("productpage", "details", 5.0, 0.0, 20.0)
+	addEdge("productpage", "reviews", 5.0, 1.0, 300.0)
+	addEdge("details", "ratings", 2.0, 0.0, 10.0)
+	addEdge("reviews", "ratings", 4.0, 0.5, 200.0)
+	addEdge("ratings", "db", 6.0, 0.0, 50.0)
+	// a cycle back to the analyzed node must not be walked twice
+	addEdge("ratings", "reviews", 1.0, 0.0, 400.0)
+
+	return trafficMap, nodes
+}
+
+func TestImpact(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap, nodes := impactTrafficMap()
+	impact := NewImpact(trafficMap, []*Node{nodes["reviews"]})
+
+	assert.Len(impact.Nodes, 1)
+	assert.Equal(nodes["reviews"].ID, impact.Nodes[0].ID)
+
+	// downstream: ratings (1 hop), db (2 hops)
+	assert.Len(impact.Downstream, 2)
+	assert.Equal(nodes["ratings"].ID, impact.Downstream[0].ID)
+	assert.Equal(1, impact.Downstream[0].Depth)
+	assert.Equal(4.0, impact.Downstream[0].RequestRate)
+	assert.Equal(0.5, impact.Downstream[0].ErrorRate)
+	assert.Equal(nodes["db"].ID, impact.Downstream[1].ID)
+	assert.Equal(2, impact.Downstream[1].Depth)
+	assert.Equal(6.0, impact.Downstream[1].RequestRate)
+
+	// upstream: productpage and ratings (1 hop), details and ingress (2 hops). Rates are aggregated from every
+	// edge into the upstream path, so productpage includes its calls to details.
+	assert.Len(impact.Upstream, 4)
+	assert.Equal(1, impact.Upstream[0].Depth)
+	assert.Equal(1, impact.Upstream[1].Depth)
+	assert.Equal(2, impact.Upstream[2].Depth)
+	assert.Equal(2, impact.Upstream[3].Depth)
+	for _, in := range impact.Upstream {
+		switch in.Workload {
+		case "productpage":
+			assert.Equal(10.0, in.RequestRate)
+			assert.Equal(1.0, in.ErrorRate)
+		case "ratings":
+			assert.Equal(1.0, in.RequestRate)
+		case "details":
+			assert.Equal(2.0, in.RequestRate)
+		case "ingress":
+			assert.Equal(10.0, in.RequestRate)
+		default:
+			assert.Fail("unexpected upstream node", in.ID)
+		}
+	}
+
+	// critical path: reviews -> ratings -> db, the slower ratings -> reviews edge returns to a visited node
+	assert.Len(impact.CriticalPath, 2)
+	assert.Equal(nodes["reviews"].ID, impact.CriticalPath[0].Source)
+	assert.Equal(nodes["ratings"].ID, impact.CriticalPath[0].Dest)
+	assert.Equal(200.0, impact.CriticalPath[0].ResponseTime)
+	assert.Equal(nodes["ratings"].ID, impact.CriticalPath[1].Source)
+	assert.Equal(nodes["db"].ID, impact.CriticalPath[1].Dest)
+	assert.Equal(HTTP.Name, impact.CriticalPath[1].Protocol)
+}
+
+func TestImpactCriticalPathFromRoot(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap, nodes := impactTrafficMap()
+	impact := NewImpact(trafficMap, []*Node{nodes["ingress"]})
+
+	assert.Len(impact.Upstream, 0)
+	assert.Len(impact.Downstream, 5)
+
+	path := []string{}
+	for _, hop := range impact.CriticalPath {
+		path = append(path, hop.Dest)
+	}
+	assert.Equal([]string{nodes["productpage"].ID, nodes["reviews"].ID, nodes["ratings"].ID, nodes["db"].ID}, path)
+}
+
+func TestImpactCriticalPathIsTheLongestPath(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap := NewTrafficMap()
+	nodes := make(map[string]*Node)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		n, _ := NewNode("east", "bookinfo", "", "bookinfo", name, name, "v1", GraphTypeWorkload)
+		trafficMap[n.ID] = n
+		nodes[name] = n
+	}
+	addEdge := func(source, dest string, responseTime float64) {
+		e := nodes[source].AddEdge(nodes[dest])
+		e.Metadata[ResponseTime] = responseTime
+	}
+	// the slowest first hop (a -> b) is not on the highest-latency path, and the edges have no protocol
+	addEdge("a", "b", 100.0)
+	addEdge("a", "c", 60.0)
+	addEdge("c", "d", 80.0)
+
+	impact := NewImpact(trafficMap, []*Node{nodes["a"]})
+
+	path := []string{}
+	for _, hop := range impact.CriticalPath {
+		path = append(path, hop.Dest)
+		assert.Empty(hop.Protocol)
+	}
+	assert.Equal([]string{nodes["c"].ID, nodes["d"].ID}, path)
+}
@@ -15,8 +15,11 @@ func NewMetadata() Metadata {
 const (
 	Aggregate             MetadataKey = "aggregate" // the prom attribute used for aggregation
 	AggregateValue        MetadataKey = "aggregateValue"
+	Anomaly               MetadataKey = "anomaly" // set when traffic deviates from the baseline
 	DestPrincipal         MetadataKey = "destPrincipal"
 	DestServices          MetadataKey = "destServices"
+	DiffDeltas            MetadataKey = "diffDeltas" // rate deltas (current - baseline) for diff graphs
+	DiffStatus            MetadataKey = "diffStatus" // added | changed | removed | unchanged, for diff graphs
 	HealthData            MetadataKey = "healthData"
 	HealthDataApp         MetadataKey = "healthDataApp" // for storing app health on versioned app nodes
 	HasCB                 MetadataKey = "hasCB"
@@ -48,6 +51,13 @@ const (
 	Throughput            MetadataKey = "throughput"
 )
 
+// AnomalyMetadata describes a deviation from the baseline traffic. The Score is the largest deviation
+// factor (e.g. 3.0 for a p95 response time three times the baseline).
+type AnomalyMetadata struct {
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
 // DestServicesMetadata key=Service.Key()
 type DestServicesMetadata map[string]ServiceName
 
@@ -62,6 +72,9 @@ func (dsm DestServicesMetadata) Add(key string, service ServiceName) DestService
 	return dsm
 }
 
+// DiffDeltasMetadata maps a rate key to its delta (current - baseline)
+type DiffDeltasMetadata map[MetadataKey]float64
+
 type GatewaysMetadata map[string][]string
 type LabelsMetadata map[string]string
 type VirtualServicesMetadata map[string][]string
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/kiali/kiali/business"
@@ -24,7 +25,10 @@ import (
 // The supported vendors
 const (
 	VendorCytoscape        string = "cytoscape"
+	VendorDOT              string = "dot"
+	VendorGraphML          string = "graphml"
 	VendorIstio            string = "istio"
+	VendorMermaid          string = "mermaid"
 	defaultConfigVendor    string = VendorCytoscape
 	defaultTelemetryVendor string = VendorIstio
 )
@@ -32,6 +36,7 @@ const (
 const (
 	BoxByApp                  string = "app"
 	BoxByCluster              string = "cluster"
+	BoxByLabelPrefix          string = "label:" // boxBy=label:<name> boxes by the value of a k8s label
 	BoxByNamespace            string = "namespace"
 	BoxByNone                 string = "none"
 	RateNone                  string = "none"
@@ -39,6 +44,10 @@ const (
 	RateRequests              string = "requests" // request count
 	RateSent                  string = "sent"     // tcp bytes sent, grpc request messages, etc
 	RateTotal                 string = "total"    // Sent+Received
+	DiffStatusAdded           string = "added"
+	DiffStatusChanged         string = "changed"
+	DiffStatusRemoved         string = "removed"
+	DiffStatusUnchanged       string = "unchanged"
 	defaultBoxBy              string = BoxByNone
 	defaultDuration           string = "10m"
 	defaultGraphType          string = GraphTypeWorkload
@@ -46,6 +55,9 @@ const (
 	defaultInjectServiceNodes bool   = false
 	defaultRateGrpc           string = RateRequests
 	defaultRateHttp           string = RateRequests
+	defaultRateKafka          string = RateNone
+	defaultRateMysql          string = RateNone
+	defaultRateRedis          string = RateNone
 	defaultRateTcp            string = RateSent
 )
 
@@ -74,27 +86,69 @@ type CommonOptions struct {
 	QueryTime int64      // unix time in seconds
 }
 
+// CompareOptions are those that apply only to diff graphs, comparing the requested time window to
+// an earlier (baseline) time window.
+type CompareOptions struct {
+	Duration  time.Duration
+	QueryTime int64 // unix time in seconds, 0 when no comparison is requested
+}
+
+// IsEnabled returns true if a diff graph was requested
+func (o CompareOptions) IsEnabled() bool {
+	return o.QueryTime != 0
+}
+
 // ConfigOptions are those supplied to Config Vendors
 type ConfigOptions struct {
-	BoxBy string
+	BoxBy   string
+	Compare CompareOptions
 	CommonOptions
 }
 
+// IsBoxBy returns true if the requested boxBy includes the box (e.g. BoxByApp)
+func (o ConfigOptions) IsBoxBy(box string) bool {
+	for _, b := range strings.Split(o.BoxBy, ",") {
+		if strings.TrimSpace(b) == box {
+			return true
+		}
+	}
+	return false
+}
+
+// BoxByLabel returns the label name for a requested boxBy=label:<name>, or "" if boxing by label is not requested
+func (o ConfigOptions) BoxByLabel() string {
+	return BoxByLabel(o.BoxBy)
+}
+
+// BoxByLabel returns the label name for a boxBy=label:<name> in the boxBy param value, or "" if not present
+func BoxByLabel(boxBy string) string {
+	for _, b := range strings.Split(boxBy, ",") {
+		if b = strings.TrimSpace(b); strings.HasPrefix(b, BoxByLabelPrefix) {
+			return strings.TrimPrefix(b, BoxByLabelPrefix)
+		}
+	}
+	return ""
+}
+
 type RequestedAppenders struct {
 	All           bool
 	AppenderNames []string
 }
 
 type RequestedRates struct {
-	Grpc string
-	Http string
-	Tcp  string
+	Grpc  string
+	Http  string
+	Kafka string
+	Mysql string
+	Redis string
+	Tcp   string
 }
 
 // TelemetryOptions are those supplied to Telemetry Vendors
 type TelemetryOptions struct {
 	AccessibleNamespaces map[string]time.Time
 	Appenders            RequestedAppenders // requested appenders, nil if param not supplied
+	Debug                bool               // collect the graph generation Diagnostics
 	IncludeIdleEdges     bool               // include edges with request rates of 0
 	InjectServiceNodes   bool               // inject destination service nodes between source and destination nodes.
 	Namespaces           NamespaceInfoMap
@@ -124,6 +178,9 @@ func NewOptions(r *net_http.Request) Options {
 
 	// query params
 	params := r.URL.Query()
+	var compareDuration model.Duration
+	var compareTime int64
+	var debug bool
 	var duration model.Duration
 	var includeIdleEdges bool
 	var injectServiceNodes bool
@@ -131,7 +188,10 @@ func NewOptions(r *net_http.Request) Options {
 	appenders := RequestedAppenders{All: true}
 	boxBy := params.Get("boxBy")
 	cluster := params.Get("cluster")
+	compareDurationString := params.Get("compareDuration")
+	compareTimeString := params.Get("compareTime")
 	configVendor := params.Get("configVendor")
+	debugString := params.Get("debug")
 	durationString := params.Get("duration")
 	graphType := params.Get("graphType")
 	includeIdleEdgesString := params.Get("includeIdleEdges")
@@ -140,6 +200,9 @@ func NewOptions(r *net_http.Request) Options {
 	queryTimeString := params.Get("queryTime")
 	rateGrpc := params.Get("rateGrpc")
 	rateHttp := params.Get("rateHttp")
+	rateKafka := params.Get("rateKafka")
+	rateMysql := params.Get("rateMysql")
+	rateRedis := params.Get("rateRedis")
 	rateTcp := params.Get("rateTcp")
 	telemetryVendor := params.Get("telemetryVendor")
 
@@ -155,8 +218,6 @@ func NewOptions(r *net_http.Request) Options {
 	}
 	if configVendor == "" {
 		configVendor = defaultConfigVendor
-	} else if configVendor != VendorCytoscape {
-		BadRequest(fmt.Sprintf("Invalid configVendor [%s]", configVendor))
 	}
 	if durationString == "" {
 		duration, _ = model.ParseDuration(defaultDuration)
@@ -180,18 +241,44 @@ func NewOptions(r *net_http.Request) Options {
 	if boxBy == "" {
 		boxBy = defaultBoxBy
 	} else {
+		labelBoxes := 0
 		for _, box := range strings.Split(boxBy, ",") {
-			switch strings.TrimSpace(box) {
-			case BoxByApp:
+			box = strings.TrimSpace(box)
+			switch {
+			case box == BoxByApp:
 				continue
-			case BoxByCluster:
+			case box == BoxByCluster:
 				continue
-			case BoxByNamespace:
+			case box == BoxByNamespace:
 				continue
+			case strings.HasPrefix(box, BoxByLabelPrefix):
+				labelBoxes++
+				label := strings.TrimPrefix(box, BoxByLabelPrefix)
+				if errs := validation.IsQualifiedName(label); len(errs) > 0 {
+					BadRequest(fmt.Sprintf("Invalid boxBy [%s], invalid label name: %s", boxBy, strings.Join(errs, "; ")))
+				}
+				// the app and version labels are not carried to the node labels, box by app instead
+				istioLabels := config.Get().IstioLabels
+				if label == istioLabels.AppLabelName || label == istioLabels.VersionLabelName {
+					BadRequest(fmt.Sprintf("Invalid boxBy [%s], use boxBy=%s to box by the app label", boxBy, BoxByApp))
+				}
 			default:
 				BadRequest(fmt.Sprintf("Invalid boxBy [%s]", boxBy))
 			}
 		}
+		if labelBoxes > 1 {
+			BadRequest(fmt.Sprintf("Invalid boxBy [%s], only one label box is supported", boxBy))
+		}
+	}
+	if debugString != "" {
+		var debugErr error
+		debug, debugErr = strconv.ParseBool(debugString)
+		if debugErr != nil {
+			BadRequest(fmt.Sprintf("Invalid debug [%s]", debugString))
+		}
+		if debug && configVendor != VendorCytoscape {
+			BadRequest(fmt.Sprintf("Invalid debug [%s], supported only for configVendor [%s]", debugString, VendorCytoscape))
+		}
 	}
 	if includeIdleEdgesString == "" {
 		includeIdleEdges = defaultIncludeIdleEdges
@@ -220,6 +307,28 @@ func NewOptions(r *net_http.Request) Options {
 			BadRequest(fmt.Sprintf("Invalid queryTime [%s]", queryTimeString))
 		}
 	}
+	if compareTimeString != "" {
+		var compareTimeErr error
+		compareTime, compareTimeErr = strconv.ParseInt(compareTimeString, 10, 64)
+		if compareTimeErr != nil || compareTime <= 0 {
+			BadRequest(fmt.Sprintf("Invalid compareTime [%s]", compareTimeString))
+		}
+		if compareTime == queryTime {
+			BadRequest(fmt.Sprintf("Invalid compareTime [%s], must differ from queryTime", compareTimeString))
+		}
+	}
+	if compareDurationString == "" {
+		compareDuration = duration
+	} else {
+		if compareTimeString == "" {
+			BadRequest("Invalid compareDuration, compareTime must also be specified")
+		}
+		var compareDurationErr error
+		compareDuration, compareDurationErr = model.ParseDuration(compareDurationString)
+		if compareDurationErr != nil {
+			BadRequest(fmt.Sprintf("Invalid compareDuration [%s]", compareDurationString))
+		}
+	}
 	if telemetryVendor == "" {
 		telemetryVendor = defaultTelemetryVendor
 	} else if telemetryVendor != VendorIstio {
@@ -271,9 +380,12 @@ func NewOptions(r *net_http.Request) Options {
 	// Process Rate Options
 
 	rates := RequestedRates{
-		Grpc: defaultRateGrpc,
-		Http: defaultRateHttp,
-		Tcp:  defaultRateTcp,
+		Grpc:  defaultRateGrpc,
+		Http:  defaultRateHttp,
+		Kafka: defaultRateKafka,
+		Mysql: defaultRateMysql,
+		Redis: defaultRateRedis,
+		Tcp:   defaultRateTcp,
 	}
 
 	if rateGrpc != "" {
@@ -304,6 +416,39 @@ func NewOptions(r *net_http.Request) Options {
 		}
 	}
 
+	if rateKafka != "" {
+		switch rateKafka {
+		case RateNone:
+			rates.Kafka = RateNone
+		case RateRequests:
+			rates.Kafka = RateRequests
+		default:
+			BadRequest(fmt.Sprintf("Invalid Kafka Rate [%s]", rateKafka))
+		}
+	}
+
+	if rateMysql != "" {
+		switch rateMysql {
+		case RateNone:
+			rates.Mysql = RateNone
+		case RateRequests:
+			rates.Mysql = RateRequests
+		default:
+			BadRequest(fmt.Sprintf("Invalid MySQL Rate [%s]", rateMysql))
+		}
+	}
+
+	if rateRedis != "" {
+		switch rateRedis {
+		case RateNone:
+			rates.Redis = RateNone
+		case RateRequests:
+			rates.Redis = RateRequests
+		default:
+			BadRequest(fmt.Sprintf("Invalid Redis Rate [%s]", rateRedis))
+		}
+	}
+
 	if rateTcp != "" {
 		switch rateTcp {
 		case RateNone:
@@ -329,6 +474,10 @@ func NewOptions(r *net_http.Request) Options {
 		TelemetryVendor: telemetryVendor,
 		ConfigOptions: ConfigOptions{
 			BoxBy: boxBy,
+			Compare: CompareOptions{
+				Duration:  time.Duration(compareDuration),
+				QueryTime: compareTime,
+			},
 			CommonOptions: CommonOptions{
 				Duration:  time.Duration(duration),
 				GraphType: graphType,
@@ -339,6 +488,7 @@ func NewOptions(r *net_http.Request) Options {
 		TelemetryOptions: TelemetryOptions{
 			AccessibleNamespaces: accessibleNamespaces,
 			Appenders:            appenders,
+			Debug:                debug,
 			IncludeIdleEdges:     includeIdleEdges,
 			InjectServiceNodes:   injectServiceNodes,
 			Namespaces:           namespaceMap,
@@ -376,6 +526,39 @@ func (o *TelemetryOptions) GetGraphKind() string {
 	return graphKindNamespace
 }
 
+// GetCompareTelemetryOptions returns a copy of the TelemetryOptions adjusted to the compare (baseline)
+// time window. The namespace durations are re-calculated to ensure the namespaces existed for the entire
+// baseline time range.
+func (o *Options) GetCompareTelemetryOptions() TelemetryOptions {
+	to := o.TelemetryOptions
+	to.Duration = o.Compare.Duration
+	to.QueryTime = o.Compare.QueryTime
+
+	to.Namespaces = NewNamespaceInfoMap()
+	for name, ns := range o.TelemetryOptions.Namespaces {
+		ns.Duration = getSafeNamespaceDuration(name, o.AccessibleNamespaces[name], o.Compare.Duration, o.Compare.QueryTime)
+		to.Namespaces[name] = ns
+	}
+
+	return to
+}
+
+// GetQueryTimeOptions returns a copy of the Options for another queryTime (e.g. when regenerating a graph). The
+// namespace durations are re-calculated to ensure the namespaces existed for the entire time range.
+func (o *Options) GetQueryTimeOptions(queryTime int64) Options {
+	qo := *o
+	qo.ConfigOptions.QueryTime = queryTime
+	qo.TelemetryOptions.QueryTime = queryTime
+
+	qo.TelemetryOptions.Namespaces = NewNamespaceInfoMap()
+	for name, ns := range o.TelemetryOptions.Namespaces {
+		ns.Duration = getSafeNamespaceDuration(name, o.AccessibleNamespaces[name], o.TelemetryOptions.Duration, queryTime)
+		qo.TelemetryOptions.Namespaces[name] = ns
+	}
+
+	return qo
+}
+
 // getAccessibleNamespaces returns a Set of all namespaces accessible to the user.
 // The Set is implemented using the map convention. Each map entry is set to the
 // creation timestamp of the namespace, to be used to ensure valid time ranges for
@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQueryTimeOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	created := time.Unix(1000, 0)
+	o := Options{}
+	o.TelemetryOptions.AccessibleNamespaces = map[string]time.Time{"bookinfo": created}
+	o.TelemetryOptions.Duration = 10 * time.Minute
+	o.TelemetryOptions.Namespaces = NamespaceInfoMap{"bookinfo": {Name: "bookinfo", Duration: 2 * time.Minute}}
+	o.TelemetryOptions.QueryTime = created.Add(2 * time.Minute).Unix()
+
+	// the namespace duration grows with the queryTime, up to the requested duration
+	qo := o.GetQueryTimeOptions(created.Add(5 * time.Minute).Unix())
+	assert.Equal(created.Add(5*time.Minute).Unix(), qo.TelemetryOptions.QueryTime)
+	assert.Equal(created.Add(5*time.Minute).Unix(), qo.ConfigOptions.QueryTime)
+	assert.Equal(5*time.Minute, qo.TelemetryOptions.Namespaces["bookinfo"].Duration)
+
+	qo = o.GetQueryTimeOptions(created.Add(time.Hour).Unix())
+	assert.Equal(10*time.Minute, qo.TelemetryOptions.Namespaces["bookinfo"].Duration)
+
+	// the original options are unchanged
+	assert.Equal(2*time.Minute, o.TelemetryOptions.Namespaces["bookinfo"].Duration)
+}
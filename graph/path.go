@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NodeRef references a workload or a service, in a namespace and optionally in a cluster
+type NodeRef struct {
+	Cluster   string // empty for any cluster
+	Namespace string
+	Service   string
+	Workload  string
+}
+
+// ParseNodeRef parses a node reference of the form [<cluster>/]<namespace>/(workloads|services)/<name>
+func ParseNodeRef(ref string) (NodeRef, error) {
+	nodeRef := NodeRef{}
+	tokens := strings.Split(ref, "/")
+	if len(tokens) == 4 {
+		nodeRef.Cluster = tokens[0]
+		tokens = tokens[1:]
+	}
+	if len(tokens) != 3 || tokens[0] == "" || tokens[2] == "" {
+		return nodeRef, fmt.Errorf("invalid node reference [%s], expecting [<cluster>/]<namespace>/(workloads|services)/<name>", ref)
+	}
+	nodeRef.Namespace = tokens[0]
+	switch tokens[1] {
+	case "workloads":
+		nodeRef.Workload = tokens[2]
+	case "services":
+		nodeRef.Service = tokens[2]
+	default:
+		return nodeRef, fmt.Errorf("invalid node reference [%s], expecting [<cluster>/]<namespace>/(workloads|services)/<name>", ref)
+	}
+	return nodeRef, nil
+}
+
+// Matches returns true if the node is the referenced workload or service. For a workload reference this
+// includes versioned app nodes for the workload.
+func (r NodeRef) Matches(n *Node) bool {
+	if n.Namespace != r.Namespace || (r.Cluster != "" && n.Cluster != r.Cluster) {
+		return false
+	}
+	if r.Workload != "" {
+		return n.Workload == r.Workload && (n.NodeType == NodeTypeWorkload || n.NodeType == NodeTypeApp)
+	}
+	return n.Service == r.Service && n.NodeType == NodeTypeService
+}
+
+// String returns the node reference in the form parsed by ParseNodeRef
+func (r NodeRef) String() string {
+	ref := fmt.Sprintf("%s/workloads/%s", r.Namespace, r.Workload)
+	if r.Service != "" {
+		ref = fmt.Sprintf("%s/services/%s", r.Namespace, r.Service)
+	}
+	if r.Cluster != "" {
+		ref = fmt.Sprintf("%s/%s", r.Cluster, ref)
+	}
+	return ref
+}
+
+// ReduceToPaths removes from the TrafficMap every node and edge that is not on a traffic path from a source
+// node to a dest node. An edge is on a path when its source is reachable from a source node, and a dest node
+// is reachable from its dest.
+func ReduceToPaths(trafficMap TrafficMap, sources, dests []*Node) {
+	// index the incoming edges, to walk upstream
+	incoming := make(map[string][]*Edge)
+	for _, e := range trafficMap.Edges() {
+		incoming[e.Dest.ID] = append(incoming[e.Dest.ID], e)
+	}
+
+	fromSource := reachable(sources, func(n *Node) []*Edge { return n.Edges }, func(e *Edge) *Node { return e.Dest })
+	toDest := reachable(dests, func(n *Node) []*Edge { return incoming[n.ID] }, func(e *Edge) *Node { return e.Source })
+
+	for id, n := range trafficMap {
+		if !fromSource[id] || !toDest[id] {
+			delete(trafficMap, id)
+			continue
+		}
+		edges := []*Edge{}
+		for _, e := range n.Edges {
+			if toDest[e.Dest.ID] {
+				edges = append(edges, e)
+			}
+		}
+		n.Edges = edges
+	}
+}
+
+// reachable returns the IDs of the nodes reachable from the start nodes, including the start nodes, using
+// the edges and neighbor functions to determine direction.
+func reachable(start []*Node, edges func(*Node) []*Edge, neighbor func(*Edge) *Node) map[string]bool {
+	visited := make(map[string]bool)
+	frontier := []*Node{}
+	for _, n := range start {
+		if !visited[n.ID] {
+			visited[n.ID] = true
+			frontier = append(frontier, n)
+		}
+	}
+	for len(frontier) > 0 {
+		n := frontier[0]
+		frontier = frontier[1:]
+		for _, e := range edges(n) {
+			if nn := neighbor(e); !visited[nn.ID] {
+				visited[nn.ID] = true
+				frontier = append(frontier, nn)
+			}
+		}
+	}
+	return visited
+}
@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeRef(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ref, err := ParseNodeRef("bookinfo/workloads/productpage-v1")
+	require.NoError(err)
+	assert.Equal(NodeRef{Namespace: "bookinfo", Workload: "productpage-v1"}, ref)
+	assert.Equal("bookinfo/workloads/productpage-v1", ref.String())
+
+	ref, err = ParseNodeRef("east/bookinfo/services/reviews")
+	require.NoError(err)
+	assert.Equal(NodeRef{Cluster: "east", Namespace: "bookinfo", Service: "reviews"}, ref)
+	assert.Equal("east/bookinfo/services/reviews", ref.String())
+
+	for _, invalid := range []string{"", "bookinfo", "bookinfo/apps/reviews", "bookinfo/workloads/", "a/b/c/d/e"} {
+		_, err = ParseNodeRef(invalid)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestReduceToPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap, nodes := impactTrafficMap()
+	ReduceToPaths(trafficMap, []*Node{nodes["productpage"]}, []*Node{nodes["ratings"]})
+
+	// ingress is upstream and db is downstream, the cycle from ratings back to reviews is on a path
+	assert.Len(trafficMap, 4)
+	for _, name := range []string{"productpage", "details", "reviews", "ratings"} {
+		_, ok := trafficMap[nodes[name].ID]
+		assert.True(ok, name)
+	}
+	assert.Len(trafficMap.Edges(), 5)
+	assert.Len(nodes["ratings"].Edges, 1)
+	assert.Equal(nodes["reviews"].ID, nodes["ratings"].Edges[0].Dest.ID)
+}
+
+func TestReduceToPathsNoPath(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap, nodes := impactTrafficMap()
+	ReduceToPaths(trafficMap, []*Node{nodes["db"]}, []*Node{nodes["ingress"]})
+
+	assert.Empty(trafficMap)
+}
+
+func TestNodeRefMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	workload, _ := NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", GraphTypeVersionedApp)
+	service, _ := NewNode("east", "bookinfo", "reviews", "", "", "", "", GraphTypeVersionedApp)
+
+	assert.True(NodeRef{Namespace: "bookinfo", Workload: "reviews-v1"}.Matches(workload))
+	assert.True(NodeRef{Cluster: "east", Namespace: "bookinfo", Workload: "reviews-v1"}.Matches(workload))
+	assert.False(NodeRef{Cluster: "west", Namespace: "bookinfo", Workload: "reviews-v1"}.Matches(workload))
+	assert.False(NodeRef{Namespace: "bookinfo", Service: "reviews"}.Matches(workload))
+	assert.True(NodeRef{Namespace: "bookinfo", Service: "reviews"}.Matches(service))
+	assert.False(NodeRef{Namespace: "bookinfo", Workload: "reviews-v1"}.Matches(service))
+}
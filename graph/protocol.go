@@ -138,8 +138,113 @@ var TCP = Protocol{
 	UnitShort: bps,
 }
 
+// The following protocols are reported by Envoy network filters (kafka_broker, mysql_proxy, redis_proxy), the
+// filter stats provide a request (or query, or command) count and an error count. Note that these edges are in
+// addition to the TCP edge reporting the bytes for the same traffic.
+
+// NetworkFilterErrCode is the code for the error count of a network filter protocol, any other code is handled
+// as the request count.
+const NetworkFilterErrCode = "err"
+
+// Kafka Protocol
+const (
+	kafka           = "kafka"
+	kafkaErr        = "kafkaErr"
+	kafkaPercentErr = "kafkaPercentErr"
+	kafkaPercentReq = "kafkaPercentReq"
+	kafkaResponses  = "kafkaResponses"
+	kafkaIn         = "kafkaIn"
+	kafkaInErr      = "kafkaInErr"
+	kafkaOut        = "kafkaOut"
+)
+
+var Kafka = Protocol{
+	Name: kafka,
+	EdgeRates: []Rate{
+		{Name: kafka, IsTotal: true, Precision: 2},
+		{Name: kafkaErr, IsErr: true, Precision: 2},
+		{Name: kafkaPercentErr, IsPercentErr: true, Precision: 1},
+		{Name: kafkaPercentReq, IsPercentReq: true, Precision: 1},
+	},
+	EdgeResponses: kafkaResponses,
+	NodeRates: []Rate{
+		{Name: kafkaIn, IsIn: true, Precision: 2},
+		{Name: kafkaInErr, IsErr: true, Precision: 2},
+		{Name: kafkaOut, IsOut: true, Precision: 2},
+	},
+	Unit:      requestsPerSecond,
+	UnitShort: rps,
+}
+
+// MySQL Protocol
+const (
+	mysql            = "mysql"
+	mysqlErr         = "mysqlErr"
+	mysqlPercentErr  = "mysqlPercentErr"
+	mysqlPercentReq  = "mysqlPercentReq"
+	mysqlResponses   = "mysqlResponses"
+	mysqlIn          = "mysqlIn"
+	mysqlInErr       = "mysqlInErr"
+	mysqlOut         = "mysqlOut"
+	queriesPerSecond = "queries per second"
+	qps              = "qps"
+)
+
+var MySQL = Protocol{
+	Name: mysql,
+	EdgeRates: []Rate{
+		{Name: mysql, IsTotal: true, Precision: 2},
+		{Name: mysqlErr, IsErr: true, Precision: 2},
+		{Name: mysqlPercentErr, IsPercentErr: true, Precision: 1},
+		{Name: mysqlPercentReq, IsPercentReq: true, Precision: 1},
+	},
+	EdgeResponses: mysqlResponses,
+	NodeRates: []Rate{
+		{Name: mysqlIn, IsIn: true, Precision: 2},
+		{Name: mysqlInErr, IsErr: true, Precision: 2},
+		{Name: mysqlOut, IsOut: true, Precision: 2},
+	},
+	Unit:      queriesPerSecond,
+	UnitShort: qps,
+}
+
+// Redis Protocol
+const (
+	redis             = "redis"
+	redisErr          = "redisErr"
+	redisPercentErr   = "redisPercentErr"
+	redisPercentReq   = "redisPercentReq"
+	redisResponses    = "redisResponses"
+	redisIn           = "redisIn"
+	redisInErr        = "redisInErr"
+	redisOut          = "redisOut"
+	commandsPerSecond = "commands per second"
+	cps               = "cps"
+)
+
+var Redis = Protocol{
+	Name: redis,
+	EdgeRates: []Rate{
+		{Name: redis, IsTotal: true, Precision: 2},
+		{Name: redisErr, IsErr: true, Precision: 2},
+		{Name: redisPercentErr, IsPercentErr: true, Precision: 1},
+		{Name: redisPercentReq, IsPercentReq: true, Precision: 1},
+	},
+	EdgeResponses: redisResponses,
+	NodeRates: []Rate{
+		{Name: redisIn, IsIn: true, Precision: 2},
+		{Name: redisInErr, IsErr: true, Precision: 2},
+		{Name: redisOut, IsOut: true, Precision: 2},
+	},
+	Unit:      commandsPerSecond,
+	UnitShort: cps,
+}
+
 // Protocols defines the supported protocols to be handled by the vendor code.
-var Protocols = []Protocol{GRPC, HTTP, TCP}
+var Protocols = []Protocol{GRPC, HTTP, TCP, Kafka, MySQL, Redis}
+
+// NetworkFilterProtocols are the supported protocols reported by Envoy network filters.
+var NetworkFilterProtocols = []Protocol{Kafka, MySQL, Redis}
 
 // AddToMetadata takes a single traffic value and adds it appropriately as source, dest and edge traffic
 func AddToMetadata(protocol string, val float64, code, flags, host string, sourceMetadata, destMetadata, edgeMetadata Metadata) {
@@ -154,6 +259,12 @@ func AddToMetadata(protocol string, val float64, code, flags, host string, sourc
 		addToMetadataHTTP(val, code, flags, host, sourceMetadata, destMetadata, edgeMetadata)
 	case tcp:
 		addToMetadataTCP(val, flags, host, sourceMetadata, destMetadata, edgeMetadata)
+	case kafka:
+		addToMetadataNetworkFilter(Kafka, val, code, flags, host, sourceMetadata, destMetadata, edgeMetadata)
+	case mysql:
+		addToMetadataNetworkFilter(MySQL, val, code, flags, host, sourceMetadata, destMetadata, edgeMetadata)
+	case redis:
+		addToMetadataNetworkFilter(Redis, val, code, flags, host, sourceMetadata, destMetadata, edgeMetadata)
 	default:
 		log.Tracef("Ignore unhandled metadata protocol [%s]", protocol)
 	}
@@ -216,6 +327,30 @@ func addToMetadataTCP(val float64, flags, host string, sourceMetadata, destMetad
 	addToMetadataResponses(edgeMetadata, tcpResponses, "-", flags, host, val)
 }
 
+// addToMetadataNetworkFilter handles the network filter protocols, which share the same rates. The error count
+// is reported separately from the request count, so it is only added to the error rates.
+func addToMetadataNetworkFilter(p Protocol, val float64, code, flags, host string, sourceMetadata, destMetadata, edgeMetadata Metadata) {
+	isErr := code == NetworkFilterErrCode
+	for _, r := range p.NodeRates {
+		switch {
+		case isErr && r.IsErr:
+			addToMetadataValue(destMetadata, r.Name, val)
+		case !isErr && r.IsIn:
+			addToMetadataValue(destMetadata, r.Name, val)
+		case !isErr && r.IsOut:
+			addToMetadataValue(sourceMetadata, r.Name, val)
+		}
+	}
+	for _, r := range p.EdgeRates {
+		if (isErr && r.IsErr) || (!isErr && r.IsTotal) {
+			addToMetadataValue(edgeMetadata, r.Name, val)
+		}
+	}
+	if !isErr {
+		addToMetadataResponses(edgeMetadata, p.EdgeResponses, "-", flags, host, val)
+	}
+}
+
 // IsHTTPErr return true if code is 4xx or 5xx
 func IsHTTPErr(code string) bool {
 	return strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5")
@@ -226,6 +361,29 @@ func IsGRPCErr(code string) bool {
 	return code != "0" && code != ""
 }
 
+// EdgeRequestRates returns the total and error request rates for an http or grpc edge, 0 for other protocols.
+func EdgeRequestRates(e *Edge) (rate, errRate float64) {
+	protocol, _ := e.Metadata[ProtocolKey].(string)
+	for _, p := range []Protocol{GRPC, HTTP} {
+		if p.Name != protocol {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			val, ok := e.Metadata[r.Name].(float64)
+			if !ok {
+				continue
+			}
+			switch {
+			case r.IsTotal:
+				rate += val
+			case r.IsErr:
+				errRate += val
+			}
+		}
+	}
+	return rate, errRate
+}
+
 // AddOutgoingEdgeToMetadata updates the source node's outgoing traffic with the outgoing edge traffic value
 func AddOutgoingEdgeToMetadata(sourceMetadata, edgeMetadata Metadata) {
 	if val, valOk := edgeMetadata[grpc]; valOk {
@@ -237,6 +395,15 @@ func AddOutgoingEdgeToMetadata(sourceMetadata, edgeMetadata Metadata) {
 	if val, valOk := edgeMetadata[tcp]; valOk {
 		addToMetadataValue(sourceMetadata, tcpOut, val.(float64))
 	}
+	if val, valOk := edgeMetadata[kafka]; valOk {
+		addToMetadataValue(sourceMetadata, kafkaOut, val.(float64))
+	}
+	if val, valOk := edgeMetadata[mysql]; valOk {
+		addToMetadataValue(sourceMetadata, mysqlOut, val.(float64))
+	}
+	if val, valOk := edgeMetadata[redis]; valOk {
+		addToMetadataValue(sourceMetadata, redisOut, val.(float64))
+	}
 }
 
 // ResetOutgoingMetadata sets outgoing traffic to zero. This is useful for some graph type manipulations.
@@ -244,6 +411,9 @@ func ResetOutgoingMetadata(sourceMetadata Metadata) {
 	delete(sourceMetadata, grpcOut)
 	delete(sourceMetadata, httpOut)
 	delete(sourceMetadata, tcpOut)
+	delete(sourceMetadata, kafkaOut)
+	delete(sourceMetadata, mysqlOut)
+	delete(sourceMetadata, redisOut)
 }
 
 // AggregateNodeTraffic adds all <nodeMetadata> values (for all protocols) into aggregateNodeMetadata.
@@ -299,6 +469,12 @@ func AggregateEdgeTraffic(edge, aggregateEdge *Edge) {
 		if responses, ok := edge.Metadata[tcpResponses]; ok {
 			addToResponses(aggregateEdge.Metadata, tcpResponses, responses.(Responses))
 		}
+	case kafka:
+		aggregateNetworkFilterEdgeTraffic(Kafka, edge, aggregateEdge)
+	case mysql:
+		aggregateNetworkFilterEdgeTraffic(MySQL, edge, aggregateEdge)
+	case redis:
+		aggregateNetworkFilterEdgeTraffic(Redis, edge, aggregateEdge)
 
 	default:
 		Error(fmt.Sprintf("Unexpected edge protocol [%v] for edge [%+v]", protocol, aggregateEdge))
@@ -309,6 +485,20 @@ func AggregateEdgeTraffic(edge, aggregateEdge *Edge) {
 	// we can't average quantiles (kiali-2297).
 }
 
+func aggregateNetworkFilterEdgeTraffic(p Protocol, edge, aggregateEdge *Edge) {
+	for _, r := range p.EdgeRates {
+		if !r.IsTotal && !r.IsErr {
+			continue
+		}
+		if val, ok := edge.Metadata[r.Name]; ok {
+			addToMetadataValue(aggregateEdge.Metadata, r.Name, val.(float64))
+		}
+	}
+	if responses, ok := edge.Metadata[p.EdgeResponses]; ok {
+		addToResponses(aggregateEdge.Metadata, p.EdgeResponses, responses.(Responses))
+	}
+}
+
 func addToMetadataValue(md Metadata, k MetadataKey, v float64) {
 	if v <= 0 || md == nil {
 		return
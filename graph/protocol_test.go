@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddToMetadataNetworkFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	source, _ := NewNode("east", "bookinfo", "", "bookinfo", "orders-v1", "orders", "v1", GraphTypeWorkload)
+	dest, _ := NewNode("east", "kafka", "broker", "kafka", "broker-v1", "broker", "v1", GraphTypeWorkload)
+	e := source.AddEdge(dest)
+	e.Metadata[ProtocolKey] = Kafka.Name
+
+	AddToMetadata(Kafka.Name, 10.0, "", "", "broker.kafka.svc.cluster.local", source.Metadata, dest.Metadata, e.Metadata)
+	AddToMetadata(Kafka.Name, 2.0, NetworkFilterErrCode, "", "broker.kafka.svc.cluster.local", source.Metadata, dest.Metadata, e.Metadata)
+
+	// the errors are a subset of the requests
+	assert.Equal(10.0, source.Metadata[kafkaOut])
+	assert.Equal(10.0, dest.Metadata[kafkaIn])
+	assert.Equal(2.0, dest.Metadata[kafkaInErr])
+	assert.Equal(10.0, e.Metadata[kafka])
+	assert.Equal(2.0, e.Metadata[kafkaErr])
+	responses := e.Metadata[kafkaResponses].(Responses)
+	assert.Len(responses, 1)
+	assert.Equal(10.0, responses["-"].Hosts["broker.kafka.svc.cluster.local"])
+
+	// other protocols are untouched
+	_, ok := e.Metadata[tcp]
+	assert.False(ok)
+
+	// aggregate, e.g. for a service graph
+	aggregate := source.AddEdge(dest)
+	aggregate.Metadata[ProtocolKey] = Kafka.Name
+	AggregateEdgeTraffic(e, aggregate)
+	AggregateEdgeTraffic(e, aggregate)
+	assert.Equal(20.0, aggregate.Metadata[kafka])
+	assert.Equal(4.0, aggregate.Metadata[kafkaErr])
+	assert.Equal(20.0, aggregate.Metadata[kafkaResponses].(Responses)["-"].Hosts["broker.kafka.svc.cluster.local"])
+
+	ResetOutgoingMetadata(source.Metadata)
+	_, ok = source.Metadata[kafkaOut]
+	assert.False(ok)
+	AddOutgoingEdgeToMetadata(source.Metadata, aggregate.Metadata)
+	assert.Equal(20.0, source.Metadata[kafkaOut])
+}
@@ -0,0 +1,182 @@
+package telemetry
+
+import (
+	"math"
+
+	"github.com/kiali/kiali/graph"
+)
+
+// DiffTrafficMaps compares trafficMap (the requested time window) to baselineTrafficMap (the compare
+// time window) and updates trafficMap to represent the union of the two. Each node and edge is tagged with
+// a DiffStatus (added | changed | removed | unchanged) and with DiffDeltas, the rate changes calculated
+// as current-baseline. Nodes and edges present only in the baseline are added without traffic, and
+// are tagged as removed. Note that edges are matched on source, dest and protocol.
+func DiffTrafficMaps(trafficMap, baselineTrafficMap graph.TrafficMap) graph.TrafficMap {
+	// add the removed nodes first, so that removed edges always have valid source and dest nodes
+	for id, baselineNode := range baselineTrafficMap {
+		if _, found := trafficMap[id]; !found {
+			trafficMap[id] = newRemovedNode(baselineNode)
+		}
+	}
+	for id, baselineNode := range baselineTrafficMap {
+		n := trafficMap[id]
+		for _, baselineEdge := range baselineNode.Edges {
+			if findEdge(n, baselineEdge.Dest.ID, baselineEdge.Metadata[graph.ProtocolKey]) == nil {
+				n.Edges = append(n.Edges, newRemovedEdge(n, trafficMap[baselineEdge.Dest.ID], baselineEdge))
+			}
+		}
+	}
+
+	for id, n := range trafficMap {
+		baselineNode, inBaseline := baselineTrafficMap[id]
+		baselineMetadata := graph.NewMetadata()
+		if inBaseline {
+			baselineMetadata = baselineNode.Metadata
+		}
+		deltas := graph.DiffDeltasMetadata{}
+		for _, p := range graph.Protocols {
+			for _, r := range p.NodeRates {
+				addDelta(deltas, r.Name, getRate(n.Metadata, r.Name)-getRate(baselineMetadata, r.Name))
+			}
+		}
+		setDiff(n.Metadata, inBaseline, deltas)
+
+		for _, e := range n.Edges {
+			var baselineEdge *graph.Edge
+			if inBaseline {
+				baselineEdge = findEdge(baselineNode, e.Dest.ID, e.Metadata[graph.ProtocolKey])
+			}
+			baselineMetadata := graph.NewMetadata()
+			if baselineEdge != nil {
+				baselineMetadata = baselineEdge.Metadata
+			}
+			setDiff(e.Metadata, baselineEdge != nil, diffEdgeRates(e.Metadata, baselineMetadata))
+		}
+	}
+
+	return trafficMap
+}
+
+// diffEdgeRates returns the rate deltas for the edge protocol, including the error percentage delta.
+func diffEdgeRates(md, baselineMd graph.Metadata) graph.DiffDeltasMetadata {
+	deltas := graph.DiffDeltasMetadata{}
+	for _, p := range graph.Protocols {
+		if p.Name != md[graph.ProtocolKey] {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			switch {
+			case r.IsPercentReq:
+				continue
+			case r.IsPercentErr:
+				addDelta(deltas, r.Name, percentErr(p, md)-percentErr(p, baselineMd))
+			default:
+				addDelta(deltas, r.Name, getRate(md, r.Name)-getRate(baselineMd, r.Name))
+			}
+		}
+	}
+	return deltas
+}
+
+func percentErr(p graph.Protocol, md graph.Metadata) float64 {
+	total := 0.0
+	err := 0.0
+	for _, r := range p.EdgeRates {
+		switch {
+		case r.IsTotal:
+			total = getRate(md, r.Name)
+		case r.IsErr:
+			err += getRate(md, r.Name)
+		}
+	}
+	if total == 0.0 {
+		return 0.0
+	}
+	return err / total * 100.0
+}
+
+// addDelta records a non-zero delta. Telemetry values are rounded to 0.001, round the delta
+// in the same way to avoid reporting floating point noise as a change.
+func addDelta(deltas graph.DiffDeltasMetadata, k graph.MetadataKey, delta float64) {
+	if delta = math.Round(delta*1000) / 1000; delta != 0.0 {
+		deltas[k] = delta
+	}
+}
+
+func setDiff(md graph.Metadata, inBaseline bool, deltas graph.DiffDeltasMetadata) {
+	if len(deltas) > 0 {
+		md[graph.DiffDeltas] = deltas
+	}
+	switch {
+	case md[graph.DiffStatus] == graph.DiffStatusRemoved:
+		return
+	case !inBaseline:
+		md[graph.DiffStatus] = graph.DiffStatusAdded
+	case len(deltas) > 0:
+		md[graph.DiffStatus] = graph.DiffStatusChanged
+	default:
+		md[graph.DiffStatus] = graph.DiffStatusUnchanged
+	}
+}
+
+func findEdge(n *graph.Node, destID string, protocol interface{}) *graph.Edge {
+	for _, e := range n.Edges {
+		if e.Dest.ID == destID && e.Metadata[graph.ProtocolKey] == protocol {
+			return e
+		}
+	}
+	return nil
+}
+
+// newRemovedNode returns a copy of the baseline node, without edges and without traffic
+func newRemovedNode(baselineNode *graph.Node) *graph.Node {
+	n := *baselineNode
+	n.Edges = []*graph.Edge{}
+	n.Metadata = graph.NewMetadata()
+
+	isRate := map[graph.MetadataKey]bool{}
+	for _, p := range graph.Protocols {
+		for _, r := range p.NodeRates {
+			isRate[r.Name] = true
+		}
+	}
+	for k, v := range baselineNode.Metadata {
+		if !isRate[k] {
+			n.Metadata[k] = v
+		}
+	}
+	n.Metadata[graph.DiffStatus] = graph.DiffStatusRemoved
+
+	return &n
+}
+
+// newRemovedEdge returns a copy of the baseline edge, without traffic or traffic-based appender data
+func newRemovedEdge(source, dest *graph.Node, baselineEdge *graph.Edge) *graph.Edge {
+	e := graph.NewEdge(source, dest)
+
+	isTraffic := map[graph.MetadataKey]bool{
+		graph.ResponseTime: true,
+		graph.Throughput:   true,
+	}
+	for _, p := range graph.Protocols {
+		for _, r := range p.EdgeRates {
+			isTraffic[r.Name] = true
+		}
+		isTraffic[p.EdgeResponses] = true
+	}
+	for k, v := range baselineEdge.Metadata {
+		if !isTraffic[k] {
+			e.Metadata[k] = v
+		}
+	}
+	e.Metadata[graph.DiffStatus] = graph.DiffStatusRemoved
+
+	return &e
+}
+
+func getRate(md graph.Metadata, k graph.MetadataKey) float64 {
+	if rate, ok := md[k].(float64); ok {
+		return rate
+	}
+	return 0.0
+}
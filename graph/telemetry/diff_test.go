@@ -0,0 +1,105 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func addTestTraffic(trafficMap graph.TrafficMap, source, dest *graph.Node, code string, val float64) {
+	if _, ok := trafficMap[source.ID]; !ok {
+		trafficMap[source.ID] = source
+	}
+	if _, ok := trafficMap[dest.ID]; !ok {
+		trafficMap[dest.ID] = dest
+	}
+	var edge *graph.Edge
+	for _, e := range source.Edges {
+		if e.Dest.ID == dest.ID {
+			edge = e
+		}
+	}
+	if edge == nil {
+		edge = source.AddEdge(dest)
+		edge.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	}
+	graph.AddToMetadata(graph.HTTP.Name, val, code, "-", dest.Service, source.Metadata, dest.Metadata, edge.Metadata)
+}
+
+func newTestNode(workload string) *graph.Node {
+	n, _ := graph.NewNode("east", "bookinfo", "", "bookinfo", workload, workload, "v1", graph.GraphTypeWorkload)
+	return n
+}
+
+func TestDiffTrafficMaps(t *testing.T) {
+	assert := assert.New(t)
+
+	// baseline: productpage -> reviews -> ratings, productpage -> details
+	baseline := graph.NewTrafficMap()
+	bProductpage := newTestNode("productpage")
+	bReviews := newTestNode("reviews")
+	bRatings := newTestNode("ratings")
+	bDetails := newTestNode("details")
+	addTestTraffic(baseline, bProductpage, bReviews, "200", 10.0)
+	addTestTraffic(baseline, bReviews, bRatings, "200", 5.0)
+	addTestTraffic(baseline, bProductpage, bDetails, "200", 2.0)
+
+	// current: productpage -> reviews (with errors), reviews -> ratings (unchanged), productpage -> mysql (new)
+	current := graph.NewTrafficMap()
+	productpage := newTestNode("productpage")
+	reviews := newTestNode("reviews")
+	ratings := newTestNode("ratings")
+	mysql := newTestNode("mysql")
+	addTestTraffic(current, productpage, reviews, "200", 8.0)
+	addTestTraffic(current, productpage, reviews, "500", 2.0)
+	addTestTraffic(current, reviews, ratings, "200", 5.0)
+	addTestTraffic(current, productpage, mysql, "200", 1.0)
+
+	trafficMap := DiffTrafficMaps(current, baseline)
+	assert.Equal(5, len(trafficMap))
+
+	assert.Equal(graph.DiffStatusChanged, trafficMap[productpage.ID].Metadata[graph.DiffStatus])
+	assert.Equal(graph.DiffStatusChanged, trafficMap[reviews.ID].Metadata[graph.DiffStatus])
+	assert.Equal(graph.DiffStatusUnchanged, trafficMap[ratings.ID].Metadata[graph.DiffStatus])
+	assert.Equal(graph.DiffStatusAdded, trafficMap[mysql.ID].Metadata[graph.DiffStatus])
+	assert.Equal(graph.DiffStatusRemoved, trafficMap[bDetails.ID].Metadata[graph.DiffStatus])
+
+	// the removed node is present without traffic
+	details := trafficMap[bDetails.ID]
+	_, hasTraffic := details.Metadata[graph.MetadataKey("httpIn")]
+	assert.False(hasTraffic)
+	assert.Equal(-2.0, details.Metadata[graph.DiffDeltas].(graph.DiffDeltasMetadata)["httpIn"])
+
+	edges := map[string]*graph.Edge{}
+	for _, e := range trafficMap.Edges() {
+		edges[e.Source.Workload+"->"+e.Dest.Workload] = e
+	}
+	assert.Equal(4, len(edges))
+
+	e := edges["productpage->reviews"]
+	assert.Equal(graph.DiffStatusChanged, e.Metadata[graph.DiffStatus])
+	deltas := e.Metadata[graph.DiffDeltas].(graph.DiffDeltasMetadata)
+	_, totalChanged := deltas["http"]
+	assert.False(totalChanged)
+	assert.Equal(2.0, deltas["http5xx"])
+	assert.Equal(20.0, deltas["httpPercentErr"])
+
+	e = edges["reviews->ratings"]
+	assert.Equal(graph.DiffStatusUnchanged, e.Metadata[graph.DiffStatus])
+	_, hasDeltas := e.Metadata[graph.DiffDeltas]
+	assert.False(hasDeltas)
+
+	e = edges["productpage->mysql"]
+	assert.Equal(graph.DiffStatusAdded, e.Metadata[graph.DiffStatus])
+	assert.Equal(1.0, e.Metadata[graph.DiffDeltas].(graph.DiffDeltasMetadata)["http"])
+
+	e = edges["productpage->details"]
+	assert.Equal(graph.DiffStatusRemoved, e.Metadata[graph.DiffStatus])
+	assert.Equal(graph.HTTP.Name, e.Metadata[graph.ProtocolKey])
+	assert.Equal(-2.0, e.Metadata[graph.DiffDeltas].(graph.DiffDeltasMetadata)["http"])
+	_, hasResponses := e.Metadata[graph.HTTP.EdgeResponses]
+	assert.False(hasResponses)
+	assert.Equal(details, e.Dest)
+}
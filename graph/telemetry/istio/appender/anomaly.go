@@ -0,0 +1,382 @@
+package appender
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry/istio/util"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus"
+)
+
+const (
+	// AnomalyAppenderName uniquely identifies the appender: anomaly
+	AnomalyAppenderName = "anomaly"
+
+	defaultAnomalyOffset    = 24 * time.Hour
+	defaultAnomalyThreshold = 2.0
+
+	// floors applied to current and baseline values, to avoid flagging large deviations of negligible values
+	anomalyMinErrorRatio   = 0.01 // 1%
+	anomalyMinRate         = 0.1  // rps
+	anomalyMinResponseTime = 10.0 // ms
+)
+
+// AnomalyAppender is responsible for flagging request edges whose traffic deviates from a baseline. The
+// baseline is the same time window, offset into the past (by default one day). The request rate, error
+// ratio and p95 response time of each edge are compared to the baseline. An increase or decrease of the
+// request rate is an anomaly, only increases of the error ratio and response time are anomalies. The
+// anomaly score is the largest deviation factor, an edge is flagged when the score reaches the threshold.
+// The destination node of a flagged edge is also flagged, with the highest score of its incoming edges.
+// Edges without baseline traffic are not flagged.
+// Name: anomaly
+type AnomalyAppender struct {
+	GraphType          string
+	InjectServiceNodes bool
+	Namespaces         graph.NamespaceInfoMap
+	Offset             time.Duration
+	QueryTime          int64 // unix time in seconds
+	Rates              graph.RequestedRates
+	Threshold          float64
+}
+
+// anomalyTraffic holds the request traffic of an edge
+type anomalyTraffic struct {
+	hasRates     bool
+	rate         float64
+	errRate      float64
+	responseTime float64 // p95 in millis, 0 if unknown
+}
+
+// anomalyTrafficMap key is "sourceID destID protocol", like the responseTime appender
+type anomalyTrafficMap map[string]*anomalyTraffic
+
+func (m anomalyTrafficMap) get(key string) *anomalyTraffic {
+	t, ok := m[key]
+	if !ok {
+		t = &anomalyTraffic{}
+		m[key] = t
+	}
+	return t
+}
+
+// merge adds the traffic not already reported. For edges within the namespace the traffic may be reported
+// by both the incoming and outgoing queries, the first reported traffic is preferred (i.e. defer to query order).
+func (m anomalyTrafficMap) merge(from anomalyTrafficMap) {
+	for key, ft := range from {
+		t := m.get(key)
+		if ft.hasRates && !t.hasRates {
+			t.hasRates = true
+			t.rate = ft.rate
+			t.errRate = ft.errRate
+		}
+		if ft.responseTime > 0 && t.responseTime == 0 {
+			t.responseTime = ft.responseTime
+		}
+	}
+}
+
+// Name implements Appender
+func (a AnomalyAppender) Name() string {
+	return AnomalyAppenderName
+}
+
+// IsFinalizer implements Appender
+func (a AnomalyAppender) IsFinalizer() bool {
+	return false
+}
+
+// AppendGraph implements Appender
+func (a AnomalyAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(trafficMap) == 0 {
+		return
+	}
+
+	// Anomalies only apply to request traffic (not TCP or gRPC-message traffic)
+	if a.Rates.Grpc != graph.RateRequests && a.Rates.Http != graph.RateRequests {
+		return
+	}
+
+	if globalInfo.PromClient == nil {
+		var err error
+		globalInfo.PromClient, err = prometheus.NewClient()
+		graph.CheckError(err)
+	}
+
+	a.appendGraph(trafficMap, namespaceInfo.Namespace, globalInfo.PromClient)
+}
+
+func (a AnomalyAppender) appendGraph(trafficMap graph.TrafficMap, namespace string, client *prometheus.Client) {
+	duration := a.Namespaces[namespace].Duration
+	queryTime := time.Unix(a.QueryTime, 0)
+	offset := fmt.Sprintf("offset %vs", int(a.Offset.Seconds()))
+
+	log.Tracef("Generating anomalies using baseline offset [%v]; namespace = %v", a.Offset, namespace)
+
+	groupBy := "source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol"
+
+	// query the baseline and current p95 response time, the current rates are already in the traffic map
+	baseline := anomalyTrafficMap{}
+	current := anomalyTrafficMap{}
+
+	// 1) Incoming: query destination telemetry to capture namespace services' incoming traffic
+	// 2) Outgoing: query source telemetry to capture namespace workloads' outgoing traffic
+	// note - the query order is important as both queries may have overlapping results for edges within
+	//        the namespace.  The destination proxy query must come first.
+	for _, selector := range []string{
+		fmt.Sprintf(`reporter="destination",destination_service_namespace="%s"`, namespace),
+		fmt.Sprintf(`reporter="source",source_workload_namespace="%s"`, namespace),
+	} {
+		query := fmt.Sprintf(`sum(rate(%s{%s}[%vs] %s)) by (%s,response_code,grpc_response_status) > 0`,
+			"istio_requests_total",
+			selector,
+			int(duration.Seconds()), // range duration for the query
+			offset,
+			groupBy)
+		vector := promQuery(query, queryTime, client.GetContext(), client.API(), a)
+		baseline.merge(a.populateAnomalyTrafficMap(&vector, false))
+
+		query = fmt.Sprintf(`histogram_quantile(0.95, sum(rate(%s{%s}[%vs] %s)) by (le,%s)) > 0`,
+			"istio_request_duration_milliseconds_bucket",
+			selector,
+			int(duration.Seconds()), // range duration for the query
+			offset,
+			groupBy)
+		vector = promQuery(query, queryTime, client.GetContext(), client.API(), a)
+		baseline.merge(a.populateAnomalyTrafficMap(&vector, true))
+
+		query = fmt.Sprintf(`histogram_quantile(0.95, sum(rate(%s{%s}[%vs])) by (le,%s)) > 0`,
+			"istio_request_duration_milliseconds_bucket",
+			selector,
+			int(duration.Seconds()), // range duration for the query
+			groupBy)
+		vector = promQuery(query, queryTime, client.GetContext(), client.API(), a)
+		current.merge(a.populateAnomalyTrafficMap(&vector, true))
+	}
+
+	a.applyAnomalies(trafficMap, baseline, current)
+}
+
+// applyAnomalies compares the current edge traffic to the baseline and flags anomalous edges and their
+// destination nodes.
+func (a AnomalyAppender) applyAnomalies(trafficMap graph.TrafficMap, baseline, current anomalyTrafficMap) {
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			protocol := e.Metadata[graph.ProtocolKey].(string)
+			if (protocol != graph.HTTP.Name || a.Rates.Http != graph.RateRequests) && (protocol != graph.GRPC.Name || a.Rates.Grpc != graph.RateRequests) {
+				continue
+			}
+
+			key := fmt.Sprintf("%s %s %s", e.Source.ID, e.Dest.ID, protocol)
+			b, ok := baseline[key]
+			if !ok || !b.hasRates {
+				continue
+			}
+
+			c := anomalyTraffic{hasRates: true}
+			c.rate, c.errRate = graph.EdgeRequestRates(e)
+			if t, ok := current[key]; ok {
+				c.responseTime = t.responseTime
+			}
+
+			anomaly := a.getAnomaly(c, *b)
+			if anomaly == nil {
+				continue
+			}
+			e.Metadata[graph.Anomaly] = anomaly
+			addNodeAnomaly(e.Dest, anomaly)
+		}
+	}
+}
+
+// getAnomaly returns the anomaly for the current edge traffic, or nil if it does not deviate from the baseline
+func (a AnomalyAppender) getAnomaly(current, baseline anomalyTraffic) *graph.AnomalyMetadata {
+	anomaly := &graph.AnomalyMetadata{Reasons: []string{}}
+	flag := func(score float64, reason string) {
+		if score < a.Threshold {
+			return
+		}
+		anomaly.Score = math.Max(anomaly.Score, math.Round(score*100)/100)
+		anomaly.Reasons = append(anomaly.Reasons, reason)
+	}
+
+	currentRate := math.Max(current.rate, anomalyMinRate)
+	baselineRate := math.Max(baseline.rate, anomalyMinRate)
+	if currentRate >= baselineRate {
+		flag(currentRate/baselineRate, fmt.Sprintf("request rate increased to %.2frps from %.2frps", current.rate, baseline.rate))
+	} else {
+		flag(baselineRate/currentRate, fmt.Sprintf("request rate decreased to %.2frps from %.2frps", current.rate, baseline.rate))
+	}
+
+	if current.rate > 0 && baseline.rate > 0 {
+		currentRatio := math.Max(current.errRate/current.rate, anomalyMinErrorRatio)
+		baselineRatio := math.Max(baseline.errRate/baseline.rate, anomalyMinErrorRatio)
+		if currentRatio > baselineRatio {
+			flag(currentRatio/baselineRatio, fmt.Sprintf("error ratio increased to %.1f%% from %.1f%%", 100*current.errRate/current.rate, 100*baseline.errRate/baseline.rate))
+		}
+	}
+
+	if current.responseTime > 0 && baseline.responseTime > 0 {
+		currentResponseTime := math.Max(current.responseTime, anomalyMinResponseTime)
+		baselineResponseTime := math.Max(baseline.responseTime, anomalyMinResponseTime)
+		if currentResponseTime > baselineResponseTime {
+			flag(currentResponseTime/baselineResponseTime, fmt.Sprintf("p95 response time increased to %.0fms from %.0fms", current.responseTime, baseline.responseTime))
+		}
+	}
+
+	if len(anomaly.Reasons) == 0 {
+		return nil
+	}
+	return anomaly
+}
+
+// addNodeAnomaly flags the node with the highest score of its anomalous edges, and all of their reasons
+func addNodeAnomaly(n *graph.Node, edgeAnomaly *graph.AnomalyMetadata) {
+	nodeAnomaly, ok := n.Metadata[graph.Anomaly].(*graph.AnomalyMetadata)
+	if !ok {
+		nodeAnomaly = &graph.AnomalyMetadata{Reasons: []string{}}
+		n.Metadata[graph.Anomaly] = nodeAnomaly
+	}
+	nodeAnomaly.Score = math.Max(nodeAnomaly.Score, edgeAnomaly.Score)
+
+	for _, reason := range edgeAnomaly.Reasons {
+		found := false
+		for _, r := range nodeAnomaly.Reasons {
+			if r == reason {
+				found = true
+				break
+			}
+		}
+		if !found {
+			nodeAnomaly.Reasons = append(nodeAnomaly.Reasons, reason)
+		}
+	}
+}
+
+// populateAnomalyTrafficMap returns the traffic reported by the vector, request rates or p95 response times
+func (a AnomalyAppender) populateAnomalyTrafficMap(vector *model.Vector, isResponseTime bool) anomalyTrafficMap {
+	skipRequestsGrpc := a.Rates.Grpc != graph.RateRequests
+	skipRequestsHttp := a.Rates.Http != graph.RateRequests
+	trafficMap := anomalyTrafficMap{}
+
+	for _, s := range *vector {
+		m := s.Metric
+		lSourceCluster, sourceClusterOk := m["source_cluster"]
+		lSourceWlNs, sourceWlNsOk := m["source_workload_namespace"]
+		lSourceWl, sourceWlOk := m["source_workload"]
+		lSourceApp, sourceAppOk := m["source_canonical_service"]
+		lSourceVer, sourceVerOk := m["source_canonical_revision"]
+		lDestCluster, destClusterOk := m["destination_cluster"]
+		lDestSvcNs, destSvcNsOk := m["destination_service_namespace"]
+		lDestSvc, destSvcOk := m["destination_service"]
+		lDestSvcName, destSvcNameOk := m["destination_service_name"]
+		lDestWlNs, destWlNsOk := m["destination_workload_namespace"]
+		lDestWl, destWlOk := m["destination_workload"]
+		lDestApp, destAppOk := m["destination_canonical_service"]
+		lDestVer, destVerOk := m["destination_canonical_revision"]
+		lProtocol, protocolOk := m["request_protocol"]
+
+		if !sourceWlNsOk || !sourceWlOk || !sourceAppOk || !sourceVerOk || !destSvcNsOk || !destSvcNameOk || !destSvcOk || !destWlNsOk || !destWlOk || !destAppOk || !destVerOk || !protocolOk {
+			log.Warningf("populateAnomalyTrafficMap: Skipping %s, missing expected labels", m.String())
+			continue
+		}
+
+		sourceWlNs := string(lSourceWlNs)
+		sourceWl := string(lSourceWl)
+		sourceApp := string(lSourceApp)
+		sourceVer := string(lSourceVer)
+		destSvc := string(lDestSvc)
+		protocol := string(lProtocol)
+
+		if (skipRequestsHttp && protocol == graph.HTTP.Name) || (skipRequestsGrpc && protocol == graph.GRPC.Name) {
+			continue
+		}
+
+		isErr := false
+		if !isResponseTime {
+			lCode, codeOk := m["response_code"]
+			lGrpc, grpcOk := m["grpc_response_status"]
+			if !codeOk {
+				log.Warningf("populateAnomalyTrafficMap: Skipping %s, missing expected HTTP/GRPC labels", m.String())
+				continue
+			}
+			code := util.HandleResponseCode(protocol, string(lCode), grpcOk, string(lGrpc))
+			isErr = (protocol == graph.HTTP.Name && graph.IsHTTPErr(code)) || (protocol == graph.GRPC.Name && graph.IsGRPCErr(code))
+		}
+
+		// handle clusters
+		sourceCluster, destCluster := util.HandleClusters(lSourceCluster, sourceClusterOk, lDestCluster, destClusterOk)
+
+		if util.IsBadSourceTelemetry(sourceCluster, sourceClusterOk, sourceWlNs, sourceWl, sourceApp) {
+			continue
+		}
+
+		val := float64(s.Value)
+
+		// handle unusual destinations
+		destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, _ := util.HandleDestination(sourceCluster, sourceWlNs, sourceWl, destCluster, string(lDestSvcNs), string(lDestSvc), string(lDestSvcName), string(lDestWlNs), string(lDestWl), string(lDestApp), string(lDestVer))
+
+		if util.IsBadDestTelemetry(destCluster, destClusterOk, destSvcNs, destSvc, destSvcName, destWl) {
+			continue
+		}
+
+		// Should not happen but if NaN for any reason, Just skip it
+		if math.IsNaN(val) {
+			continue
+		}
+
+		// don't inject a service node if any of:
+		// - destSvcName is not set
+		// - destSvcName is PassthroughCluster (see https://github.com/kiali/kiali/issues/4488)
+		// - dest node is already a service node
+		inject := false
+		if a.InjectServiceNodes && graph.IsOK(destSvcName) && destSvcName != graph.PassthroughCluster {
+			_, destNodeType, err := graph.Id(destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, a.GraphType)
+			if err != nil {
+				log.Warningf("Skipping (anomaly) %s, %s", m.String(), err)
+				continue
+			}
+			inject = (graph.NodeTypeService != destNodeType)
+		}
+
+		if inject {
+			// Rates apply to both edges. Only set response time on the outgoing edge, like the responseTime appender.
+			if !isResponseTime {
+				a.addAnomalyTraffic(trafficMap, val, isErr, isResponseTime, protocol, sourceCluster, sourceWlNs, "", sourceWl, sourceApp, sourceVer, destCluster, destSvcNs, destSvcName, "", "", "", "")
+			}
+			a.addAnomalyTraffic(trafficMap, val, isErr, isResponseTime, protocol, destCluster, destSvcNs, destSvcName, "", "", "", destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer)
+		} else {
+			a.addAnomalyTraffic(trafficMap, val, isErr, isResponseTime, protocol, sourceCluster, sourceWlNs, "", sourceWl, sourceApp, sourceVer, destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer)
+		}
+	}
+
+	return trafficMap
+}
+
+func (a AnomalyAppender) addAnomalyTraffic(trafficMap anomalyTrafficMap, val float64, isErr, isResponseTime bool, protocol, sourceCluster, sourceNs, sourceSvc, sourceWl, sourceApp, sourceVer, destCluster, destSvcNs, destSvc, destWlNs, destWl, destApp, destVer string) {
+	sourceID, _, err := graph.Id(sourceCluster, sourceNs, sourceSvc, sourceNs, sourceWl, sourceApp, sourceVer, a.GraphType)
+	if err != nil {
+		log.Warningf("Skipping addAnomalyTraffic (source), %s", err)
+		return
+	}
+	destID, _, err := graph.Id(destCluster, destSvcNs, destSvc, destWlNs, destWl, destApp, destVer, a.GraphType)
+	if err != nil {
+		log.Warningf("Skipping addAnomalyTraffic (dest), %s", err)
+		return
+	}
+
+	t := trafficMap.get(fmt.Sprintf("%s %s %s", sourceID, destID, protocol))
+	if isResponseTime {
+		t.responseTime = val
+		return
+	}
+	t.hasRates = true
+	t.rate += val
+	if isErr {
+		t.errRate += val
+	}
+}
@@ -0,0 +1,128 @@
+package appender
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/graph"
+)
+
+func anomalyTestMetric(sourceWl, sourceApp, destWl, destApp, code string) model.Metric {
+	return model.Metric{
+		"source_cluster":                 business.DefaultClusterID,
+		"source_workload_namespace":      "bookinfo",
+		"source_workload":                model.LabelValue(sourceWl),
+		"source_canonical_service":       model.LabelValue(sourceApp),
+		"source_canonical_revision":      "v1",
+		"destination_cluster":            business.DefaultClusterID,
+		"destination_service_namespace":  "bookinfo",
+		"destination_service":            model.LabelValue(destApp + ".bookinfo.svc.cluster.local"),
+		"destination_service_name":       model.LabelValue(destApp),
+		"destination_workload_namespace": "bookinfo",
+		"destination_workload":           model.LabelValue(destWl),
+		"destination_canonical_service":  model.LabelValue(destApp),
+		"destination_canonical_revision": "v1",
+		"request_protocol":               "http",
+		"response_code":                  model.LabelValue(code),
+	}
+}
+
+func anomalyTestTraffic() (graph.TrafficMap, *graph.Node, *graph.Node, *graph.Node) {
+	trafficMap := graph.NewTrafficMap()
+	productpage, _ := graph.NewNode(business.DefaultClusterID, "bookinfo", "productpage", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	reviews, _ := graph.NewNode(business.DefaultClusterID, "bookinfo", "reviews", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	details, _ := graph.NewNode(business.DefaultClusterID, "bookinfo", "details", "bookinfo", "details-v1", "details", "v1", graph.GraphTypeVersionedApp)
+	trafficMap[productpage.ID] = productpage
+	trafficMap[reviews.ID] = reviews
+	trafficMap[details.ID] = details
+
+	e := productpage.AddEdge(reviews)
+	e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	graph.AddToMetadata(graph.HTTP.Name, 8.0, "200", "-", "reviews", productpage.Metadata, reviews.Metadata, e.Metadata)
+	graph.AddToMetadata(graph.HTTP.Name, 2.0, "500", "-", "reviews", productpage.Metadata, reviews.Metadata, e.Metadata)
+
+	e = productpage.AddEdge(details)
+	e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	graph.AddToMetadata(graph.HTTP.Name, 10.0, "200", "-", "details", productpage.Metadata, details.Metadata, e.Metadata)
+
+	return trafficMap, productpage, reviews, details
+}
+
+func TestAnomalies(t *testing.T) {
+	assert := assert.New(t)
+
+	a := AnomalyAppender{
+		GraphType: graph.GraphTypeVersionedApp,
+		Rates:     graph.RequestedRates{Grpc: graph.RateRequests, Http: graph.RateRequests},
+		Threshold: defaultAnomalyThreshold,
+	}
+
+	// baseline: reviews had the same rate with no errors, details had a similar rate
+	rates := model.Vector{
+		&model.Sample{Metric: anomalyTestMetric("productpage-v1", "productpage", "reviews-v1", "reviews", "200"), Value: 10.0},
+		&model.Sample{Metric: anomalyTestMetric("productpage-v1", "productpage", "details-v1", "details", "200"), Value: 9.0},
+		&model.Sample{Metric: anomalyTestMetric("productpage-v1", "productpage", "details-v1", "details", "503"), Value: 0.1},
+	}
+	baseline := a.populateAnomalyTrafficMap(&rates, false)
+
+	// baseline response times: details was much faster
+	responseTimes := model.Vector{
+		&model.Sample{Metric: anomalyTestMetric("productpage-v1", "productpage", "reviews-v1", "reviews", ""), Value: 100.0},
+		&model.Sample{Metric: anomalyTestMetric("productpage-v1", "productpage", "details-v1", "details", ""), Value: 20.0},
+	}
+	baseline.merge(a.populateAnomalyTrafficMap(&responseTimes, true))
+
+	responseTimes = model.Vector{
+		&model.Sample{Metric: anomalyTestMetric("productpage-v1", "productpage", "reviews-v1", "reviews", ""), Value: 120.0},
+		&model.Sample{Metric: anomalyTestMetric("productpage-v1", "productpage", "details-v1", "details", ""), Value: 90.0},
+	}
+	current := a.populateAnomalyTrafficMap(&responseTimes, true)
+
+	trafficMap, productpage, reviews, details := anomalyTestTraffic()
+	a.applyAnomalies(trafficMap, baseline, current)
+
+	_, ok := productpage.Metadata[graph.Anomaly]
+	assert.False(ok)
+
+	// reviews error ratio increased from 0% (1% floor) to 20%
+	edgeAnomaly, ok := productpage.Edges[0].Metadata[graph.Anomaly].(*graph.AnomalyMetadata)
+	assert.True(ok)
+	assert.Equal(20.0, edgeAnomaly.Score)
+	assert.Equal([]string{"error ratio increased to 20.0% from 0.0%"}, edgeAnomaly.Reasons)
+	nodeAnomaly, ok := reviews.Metadata[graph.Anomaly].(*graph.AnomalyMetadata)
+	assert.True(ok)
+	assert.Equal(edgeAnomaly.Score, nodeAnomaly.Score)
+
+	// details p95 response time increased 4.5x
+	edgeAnomaly, ok = productpage.Edges[1].Metadata[graph.Anomaly].(*graph.AnomalyMetadata)
+	assert.True(ok)
+	assert.Equal(4.5, edgeAnomaly.Score)
+	assert.Equal([]string{"p95 response time increased to 90ms from 20ms"}, edgeAnomaly.Reasons)
+	_, ok = details.Metadata[graph.Anomaly]
+	assert.True(ok)
+}
+
+func TestAnomalyScore(t *testing.T) {
+	assert := assert.New(t)
+
+	a := AnomalyAppender{Threshold: 3.0}
+
+	// within the threshold
+	assert.Nil(a.getAnomaly(anomalyTraffic{rate: 10.0}, anomalyTraffic{rate: 5.0}))
+	// negligible rates are not compared
+	assert.Nil(a.getAnomaly(anomalyTraffic{rate: 0.01}, anomalyTraffic{rate: 0.1}))
+	// a decrease of the response time is not an anomaly
+	assert.Nil(a.getAnomaly(anomalyTraffic{rate: 1.0, responseTime: 10.0}, anomalyTraffic{rate: 1.0, responseTime: 100.0}))
+
+	anomaly := a.getAnomaly(anomalyTraffic{rate: 1.0}, anomalyTraffic{rate: 4.0})
+	assert.Equal(4.0, anomaly.Score)
+	assert.Equal([]string{"request rate decreased to 1.00rps from 4.00rps"}, anomaly.Reasons)
+
+	// multiple reasons, the score is the largest deviation
+	anomaly = a.getAnomaly(anomalyTraffic{rate: 12.0, errRate: 6.0, responseTime: 400.0}, anomalyTraffic{rate: 3.0, responseTime: 100.0})
+	assert.Equal(50.0, anomaly.Score)
+	assert.Len(anomaly.Reasons, 3)
+}
@@ -3,6 +3,10 @@ package appender
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
@@ -29,6 +33,8 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 			// namespace appenders
 			case AggregateNodeAppenderName:
 				requestedAppenders[AggregateNodeAppenderName] = true
+			case AnomalyAppenderName:
+				requestedAppenders[AnomalyAppenderName] = true
 			case DeadNodeAppenderName:
 				requestedAppenders[DeadNodeAppenderName] = true
 			case IdleNodeAppenderName:
@@ -67,6 +73,11 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 		}
 	}
 
+	// boxing by label requires the node labels
+	if graph.BoxByLabel(o.Params.Get("boxBy")) != "" {
+		requestedFinalizers[LabelerAppenderName] = true
+	}
+
 	// The appender order is important
 	// To pre-process service nodes run service_entry appender first
 	// To reduce processing, filter dead nodes next
@@ -147,6 +158,34 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 		}
 		appenders = append(appenders, a)
 	}
+	// the anomaly appender is expensive (it queries the baseline), run it only if explicitly requested
+	if _, ok := requestedAppenders[AnomalyAppenderName]; ok {
+		offset := defaultAnomalyOffset
+		if offsetString := o.Params.Get("anomalyOffset"); offsetString != "" {
+			parsedOffset, err := model.ParseDuration(offsetString)
+			if err != nil || parsedOffset <= 0 {
+				graph.BadRequest(fmt.Sprintf("Invalid anomalyOffset [%s]", offsetString))
+			}
+			offset = time.Duration(parsedOffset)
+		}
+		threshold := defaultAnomalyThreshold
+		if thresholdString := o.Params.Get("anomalyThreshold"); thresholdString != "" {
+			var err error
+			if threshold, err = strconv.ParseFloat(thresholdString, 64); err != nil || threshold <= 1.0 {
+				graph.BadRequest(fmt.Sprintf("Invalid anomalyThreshold, expecting a number greater than 1. [%s]", thresholdString))
+			}
+		}
+		a := AnomalyAppender{
+			GraphType:          o.GraphType,
+			InjectServiceNodes: o.InjectServiceNodes,
+			Namespaces:         o.Namespaces,
+			Offset:             offset,
+			QueryTime:          o.QueryTime,
+			Rates:              o.Rates,
+			Threshold:          threshold,
+		}
+		appenders = append(appenders, a)
+	}
 	if _, ok := requestedAppenders[AggregateNodeAppenderName]; ok || o.Appenders.All {
 		aggregate := o.NodeOptions.Aggregate
 		if aggregate == "" {
@@ -2,6 +2,7 @@ package appender
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -13,8 +14,8 @@ import (
 const HealthAppenderName = "health"
 
 // HealthAppender is responsible for adding the information needed to perform client-side health calculations. This
-// includes both health configuration, and health data, to the graph.  TODO: replace this with server-side
-// health calculation, and report only the health results.
+// includes both health configuration, and health data, to the graph. The status of the health, including the
+// latency-driven status, is also evaluated server-side.
 // Name: health
 type HealthAppender struct {
 	Namespaces        graph.NamespaceInfoMap
@@ -221,6 +222,10 @@ func (a *HealthAppender) attachHealth(trafficMap graph.TrafficMap, globalInfo *g
 	ctx, cancel = context.WithTimeout(ctx, maxRequestDuration)
 	defer cancel()
 
+	// The request rates are taken from the graph edges, only the response times of the latency tolerances are fetched
+	rateInterval := fmt.Sprintf("%ds", int(a.RequestedDuration.Seconds()))
+	queryTime := time.Unix(a.QueryTime, 0)
+
 	type result struct {
 		namespace        string
 		cluster          string
@@ -239,7 +244,7 @@ func (a *HealthAppender) attachHealth(trafficMap graph.TrafficMap, globalInfo *g
 				wg.Add(1)
 				go func(ctx context.Context, namespace, cluster string) {
 					defer wg.Done()
-					h, err := bs.Health.GetNamespaceAppHealth(ctx, business.NamespaceHealthCriteria{Namespace: namespace, Cluster: cluster, IncludeMetrics: false})
+					h, err := bs.Health.GetNamespaceAppHealth(ctx, business.NamespaceHealthCriteria{Namespace: namespace, Cluster: cluster, IncludeMetrics: false, IncludeLatency: true, RateInterval: rateInterval, QueryTime: queryTime})
 					resultsCh <- result{appNSHealth: h, namespace: namespace, err: err, cluster: cluster}
 				}(ctx, namespace, req.cluster)
 			}
@@ -248,7 +253,7 @@ func (a *HealthAppender) attachHealth(trafficMap graph.TrafficMap, globalInfo *g
 				wg.Add(1)
 				go func(ctx context.Context, namespace, cluster string) {
 					defer wg.Done()
-					h, err := bs.Health.GetNamespaceWorkloadHealth(ctx, business.NamespaceHealthCriteria{Namespace: namespace, Cluster: cluster, IncludeMetrics: false})
+					h, err := bs.Health.GetNamespaceWorkloadHealth(ctx, business.NamespaceHealthCriteria{Namespace: namespace, Cluster: cluster, IncludeMetrics: false, IncludeLatency: true, RateInterval: rateInterval, QueryTime: queryTime})
 					resultsCh <- result{workloadNSHealth: h, namespace: namespace, err: err, cluster: cluster}
 				}(ctx, namespace, req.cluster)
 			}
@@ -257,7 +262,7 @@ func (a *HealthAppender) attachHealth(trafficMap graph.TrafficMap, globalInfo *g
 				wg.Add(1)
 				go func(ctx context.Context, namespace, cluster string) {
 					defer wg.Done()
-					s, err := bs.Health.GetNamespaceServiceHealth(ctx, business.NamespaceHealthCriteria{Namespace: namespace, Cluster: cluster, IncludeMetrics: false})
+					s, err := bs.Health.GetNamespaceServiceHealth(ctx, business.NamespaceHealthCriteria{Namespace: namespace, Cluster: cluster, IncludeMetrics: false, IncludeLatency: true, RateInterval: rateInterval, QueryTime: queryTime})
 					resultsCh <- result{serviceNSHealth: s, namespace: namespace, err: err, cluster: cluster}
 				}(ctx, namespace, req.cluster)
 			}
@@ -323,7 +328,10 @@ func (a *HealthAppender) attachHealth(trafficMap graph.TrafficMap, globalInfo *g
 			if h, found := appHealth[n.App+n.Namespace+n.Cluster]; found {
 				health.WorkloadStatuses = h.WorkloadStatuses
 				health.Requests.HealthAnnotations = h.Requests.HealthAnnotations
+				health.Requests.InboundLatency = h.Requests.InboundLatency
+				health.Requests.OutboundLatency = h.Requests.OutboundLatency
 			}
+			health.EvaluateStatus(n.Namespace, n.App)
 			n.Metadata[key] = health
 		case graph.NodeTypeService:
 			var health *models.ServiceHealth
@@ -335,7 +343,9 @@ func (a *HealthAppender) attachHealth(trafficMap graph.TrafficMap, globalInfo *g
 
 			if h, found := serviceHealth[n.Service+n.Namespace+n.Cluster]; found {
 				health.Requests.HealthAnnotations = h.Requests.HealthAnnotations
+				health.Requests.InboundLatency = h.Requests.InboundLatency
 			}
+			health.EvaluateStatus(n.Namespace, n.Service)
 			n.Metadata[graph.HealthData] = health
 		case graph.NodeTypeWorkload:
 			var health *models.WorkloadHealth
@@ -348,7 +358,10 @@ func (a *HealthAppender) attachHealth(trafficMap graph.TrafficMap, globalInfo *g
 			if h, found := workloadHealth[n.Workload+n.Namespace+n.Cluster]; found {
 				health.WorkloadStatus = h.WorkloadStatus
 				health.Requests.HealthAnnotations = h.Requests.HealthAnnotations
+				health.Requests.InboundLatency = h.Requests.InboundLatency
+				health.Requests.OutboundLatency = h.Requests.OutboundLatency
 			}
+			health.EvaluateStatus(n.Namespace, n.Workload)
 			n.Metadata[graph.HealthData] = health
 		}
 	}
@@ -0,0 +1,46 @@
+package istio
+
+import (
+	"context"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// diagnosticsAPI wraps the Prometheus API to record the graph queries in the Diagnostics
+type diagnosticsAPI struct {
+	prom_v1.API
+	diagnostics *graph.Diagnostics
+}
+
+// Query implements prom_v1.API
+func (a diagnosticsAPI) Query(ctx context.Context, query string, ts time.Time) (model.Value, prom_v1.Warnings, error) {
+	start := time.Now()
+	value, warnings, err := a.API.Query(ctx, query, ts)
+
+	results := 0
+	if vector, ok := value.(model.Vector); ok {
+		results = len(vector)
+	}
+	a.diagnostics.AddQuery(query, time.Since(start), results, err)
+
+	return value, warnings, err
+}
+
+// withDiagnostics returns a client recording its queries in the Diagnostics, and sets it as the appenders'
+// client. It returns the provided client if Diagnostics are not being collected.
+func withDiagnostics(client *prometheus.Client, globalInfo *graph.AppenderGlobalInfo) *prometheus.Client {
+	if globalInfo.Diagnostics == nil {
+		return client
+	}
+
+	diagnosticsClient := *client
+	diagnosticsClient.Inject(diagnosticsAPI{API: client.API(), diagnostics: globalInfo.Diagnostics})
+	globalInfo.PromClient = &diagnosticsClient
+
+	return &diagnosticsClient
+}
@@ -51,6 +51,94 @@ const (
 
 var grpcMetric = regexp.MustCompile(`istio_.*_messages`)
 
+// networkFilterMetric describes the Envoy network filter stats for a protocol, as regular expressions of the stat names
+// because the names include the stat_prefix of the filter. The errors are a subset of the requests.
+type networkFilterMetric struct {
+	protocol string
+	requests string
+	errors   string
+}
+
+var networkFilterMetrics = []networkFilterMetric{
+	{protocol: graph.Kafka.Name, requests: "envoy_kafka_(.+_)?request_(.+_request|unknown|failure)", errors: "envoy_kafka_(.+_)?request_failure"},
+	{protocol: graph.MySQL.Name, requests: "envoy_mysql_(.+_)?queries_(parsed|parse_error)", errors: "envoy_mysql_(.+_)?queries_parse_error"},
+	{protocol: graph.Redis.Name, requests: "envoy_redis_(.+_)?command_.+_total", errors: "envoy_redis_(.+_)?command_.+_error"},
+}
+
+// networkFilterPodLabels are the target labels set by Prometheus on the metrics scraped from a pod (as in the Istio
+// sample Prometheus config). The network filter stats carry none of the Istio source and destination labels, so they
+// are joined with the Istio TCP metrics reported by the same pod.
+const networkFilterPodLabels = "namespace,pod"
+
+// getNetworkFilterMetrics returns the network filter metrics to query for the requested rates
+func getNetworkFilterMetrics(rates graph.RequestedRates) []networkFilterMetric {
+	metrics := []networkFilterMetric{}
+	for _, nfm := range networkFilterMetrics {
+		rate := graph.RateNone
+		switch nfm.protocol {
+		case graph.Kafka.Name:
+			rate = rates.Kafka
+		case graph.MySQL.Name:
+			rate = rates.Mysql
+		case graph.Redis.Name:
+			rate = rates.Redis
+		}
+		if rate == graph.RateRequests {
+			metrics = append(metrics, nfm)
+		}
+	}
+	return metrics
+}
+
+// getNetworkFilterProtocol returns the protocol and code for a network filter metric, ok is false for other metrics
+func getNetworkFilterProtocol(metric string) (protocol, code string, ok bool) {
+	for _, nfm := range networkFilterMetrics {
+		switch metric {
+		case nfm.requests:
+			return nfm.protocol, "", true
+		case nfm.errors:
+			return nfm.protocol, graph.NetworkFilterErrCode, true
+		}
+	}
+	return "", "", false
+}
+
+// networkFilterQuery returns the query for the network filter traffic into the destination workloads, for the TCP
+// edges matching the selector. The filter must run on the inbound listener of the destination workloads, the stats
+// of each pod are split across the pod's incoming TCP edges in proportion to their received bytes. destNamespace
+// narrows the pods when all of the destination workloads are in the same namespace.
+func networkFilterQuery(metric, selector, destNamespace string, duration time.Duration, idleCondition string) string {
+	groupBy := "source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision"
+	podSelector := `reporter="destination"`
+	if destNamespace != "" {
+		podSelector = fmt.Sprintf(`%s,destination_workload_namespace="%s"`, podSelector, destNamespace)
+	}
+
+	edges := fmt.Sprintf(`sum(rate(istio_tcp_received_bytes_total{reporter="destination",%s} [%vs])) by (%s,%s)`,
+		selector,
+		int(duration.Seconds()), // range duration for the query
+		networkFilterPodLabels,
+		groupBy)
+	pods := fmt.Sprintf(`sum(rate(istio_tcp_received_bytes_total{%s} [%vs])) by (%s)`,
+		podSelector,
+		int(duration.Seconds()), // range duration for the query
+		networkFilterPodLabels)
+	stats := fmt.Sprintf(`sum(rate({__name__=~"%s"} [%vs])) by (%s)`,
+		metric,
+		int(duration.Seconds()), // range duration for the query
+		networkFilterPodLabels)
+
+	// the "> 0" drops the edges without received bytes, and the pods without any (NaN share)
+	return fmt.Sprintf(`sum((%s / on (%s) group_left %s > 0) * on (%s) group_left %s) by (%s) %s`,
+		edges,
+		networkFilterPodLabels,
+		pods,
+		networkFilterPodLabels,
+		stats,
+		groupBy,
+		idleCondition)
+}
+
 // BuildNamespacesTrafficMap is required by the graph/TelemetryVendor interface
 func BuildNamespacesTrafficMap(ctx context.Context, o graph.TelemetryOptions, client *prometheus.Client, globalInfo *graph.AppenderGlobalInfo) graph.TrafficMap {
 	var end observability.EndFunc
@@ -63,10 +151,14 @@ func BuildNamespacesTrafficMap(ctx context.Context, o graph.TelemetryOptions, cl
 
 	appenders, finalizers := appender.ParseAppenders(o)
 	trafficMap := graph.NewTrafficMap()
+	diagnostics := globalInfo.Diagnostics
+	client = withDiagnostics(client, globalInfo)
 
 	for _, namespace := range o.Namespaces {
 		log.Tracef("Build traffic map for namespace [%v]", namespace)
+		diagnostics.StartStep(graph.DiagnosticsStepTraffic, namespace.Name, graph.NewTrafficMap())
 		namespaceTrafficMap := buildNamespaceTrafficMap(ctx, namespace.Name, o, client)
+		diagnostics.EndStep(namespaceTrafficMap)
 
 		// The appenders can add/remove/alter nodes for the namespace
 		namespaceInfo := graph.NewAppenderNamespaceInfo(namespace.Name)
@@ -77,7 +169,9 @@ func BuildNamespacesTrafficMap(ctx context.Context, o graph.TelemetryOptions, cl
 				observability.Attribute("namespace", namespace.Name),
 			)
 			appenderTimer := internalmetrics.GetGraphAppenderTimePrometheusTimer(a.Name())
+			diagnostics.StartStep(a.Name(), namespace.Name, namespaceTrafficMap)
 			a.AppendGraph(namespaceTrafficMap, globalInfo, namespaceInfo)
+			diagnostics.EndStep(namespaceTrafficMap)
 			appenderTimer.ObserveDuration()
 			appenderEnd()
 		}
@@ -88,7 +182,9 @@ func BuildNamespacesTrafficMap(ctx context.Context, o graph.TelemetryOptions, cl
 
 	// The finalizers can perform final manipulations on the complete graph
 	for _, f := range finalizers {
+		diagnostics.StartStep(f.Name(), "", trafficMap)
 		f.AppendGraph(trafficMap, globalInfo, nil)
+		diagnostics.EndStep(trafficMap)
 	}
 
 	if graph.GraphTypeService == o.GraphType {
@@ -252,12 +348,28 @@ func buildNamespaceTrafficMap(ctx context.Context, namespace string, o graph.Tel
 		}
 	}
 
+	// Envoy network filter (kafka, mysql, redis) traffic
+	for _, nfm := range getNetworkFilterMetrics(o.Rates) {
+		for _, metric := range []string{nfm.requests, nfm.errors} {
+			// 1) Incoming: query the filter stats of the namespace workloads
+			query := networkFilterQuery(metric, fmt.Sprintf(`destination_workload_namespace="%s"`, namespace), namespace, duration, idleCondition)
+			incomingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+			populateTrafficMap(trafficMap, &incomingVector, metric, o)
+
+			// 2) Outgoing: query the filter stats of the workloads receiving traffic from the namespace workloads
+			query = networkFilterQuery(metric, fmt.Sprintf(`source_workload_namespace="%s"`, namespace), "", duration, idleCondition)
+			outgoingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+			populateTrafficMap(trafficMap, &outgoingVector, metric, o)
+		}
+	}
+
 	return trafficMap
 }
 
 func populateTrafficMap(trafficMap graph.TrafficMap, vector *model.Vector, metric string, o graph.TelemetryOptions) {
 	isRequests := true
 	protocol := ""
+	networkFilterProtocol, networkFilterCode, isNetworkFilter := getNetworkFilterProtocol(metric)
 	switch {
 	case grpcMetric.MatchString(metric):
 		isRequests = false
@@ -265,6 +377,9 @@ func populateTrafficMap(trafficMap graph.TrafficMap, vector *model.Vector, metri
 	case strings.HasPrefix(metric, "istio_tcp"):
 		isRequests = false
 		protocol = graph.TCP.Name
+	case isNetworkFilter:
+		isRequests = false
+		protocol = networkFilterProtocol
 	}
 	skipRequestsGrpc := isRequests && o.Rates.Grpc != graph.RateRequests
 	skipRequestsHttp := isRequests && o.Rates.Http != graph.RateRequests
@@ -322,7 +437,7 @@ func populateTrafficMap(trafficMap graph.TrafficMap, vector *model.Vector, metri
 			continue
 		}
 
-		var code string
+		code := networkFilterCode
 		if isRequests {
 			lProtocol, protocolOk := m["request_protocol"]
 			lCode, codeOk := m["response_code"]
@@ -475,19 +590,28 @@ func BuildNodeTrafficMap(o graph.TelemetryOptions, client *prometheus.Client, gl
 	log.Tracef("Build graph for node [%+v]", n)
 
 	appenders, finalizers := appender.ParseAppenders(o)
+	diagnostics := globalInfo.Diagnostics
+	client = withDiagnostics(client, globalInfo)
+
+	diagnostics.StartStep(graph.DiagnosticsStepTraffic, o.NodeOptions.Namespace, graph.NewTrafficMap())
 	trafficMap := buildNodeTrafficMap(o.Cluster, o.NodeOptions.Namespace, n, o, client)
+	diagnostics.EndStep(trafficMap)
 
 	namespaceInfo := graph.NewAppenderNamespaceInfo(o.NodeOptions.Namespace)
 
 	for _, a := range appenders {
 		appenderTimer := internalmetrics.GetGraphAppenderTimePrometheusTimer(a.Name())
+		diagnostics.StartStep(a.Name(), o.NodeOptions.Namespace, trafficMap)
 		a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+		diagnostics.EndStep(trafficMap)
 		appenderTimer.ObserveDuration()
 	}
 
 	// The finalizers can perform final manipulations on the complete graph
 	for _, f := range finalizers {
+		diagnostics.StartStep(f.Name(), "", trafficMap)
 		f.AppendGraph(trafficMap, globalInfo, nil)
+		diagnostics.EndStep(trafficMap)
 	}
 
 	// Note that this is where we would call reduceToServiceGraph for graphTypeService but
@@ -844,6 +968,46 @@ func buildNodeTrafficMap(cluster, namespace string, n *graph.Node, o graph.Telem
 		}
 	}
 
+	// Envoy network filter (kafka, mysql, redis) traffic
+	if metrics := getNetworkFilterMetrics(o.Rates); len(metrics) > 0 {
+		var incomingSelector, outgoingSelector string
+
+		switch n.NodeType {
+		case graph.NodeTypeWorkload:
+			incomingSelector = fmt.Sprintf(`destination_workload_namespace="%s",destination_workload="%s"%s`, namespace, n.Workload, destCluster)
+			outgoingSelector = fmt.Sprintf(`source_workload_namespace="%s",source_workload="%s"%s`, namespace, n.Workload, sourceCluster)
+		case graph.NodeTypeApp:
+			if graph.IsOK(n.Version) {
+				incomingSelector = fmt.Sprintf(`destination_service_namespace="%s",destination_canonical_service="%s",destination_canonical_revision="%s"%s`, namespace, n.App, n.Version, destCluster)
+				outgoingSelector = fmt.Sprintf(`source_workload_namespace="%s",source_canonical_service="%s",source_canonical_revision="%s"%s`, namespace, n.App, n.Version, sourceCluster)
+			} else {
+				incomingSelector = fmt.Sprintf(`destination_service_namespace="%s",destination_canonical_service="%s"%s`, namespace, n.App, destCluster)
+				outgoingSelector = fmt.Sprintf(`source_workload_namespace="%s",source_canonical_service="%s"%s`, namespace, n.App, sourceCluster)
+			}
+		case graph.NodeTypeService:
+			incomingSelector = fmt.Sprintf(`destination_service_namespace="%s",destination_service=~"^%s\\.%s\\..*$"%s`, namespace, n.Service, namespace, destCluster)
+		default:
+			graph.Error(fmt.Sprintf("NodeType [%s] not supported", n.NodeType))
+		}
+
+		for _, nfm := range metrics {
+			for _, metric := range []string{nfm.requests, nfm.errors} {
+				// 1) query for incoming traffic
+				query := networkFilterQuery(metric, incomingSelector, namespace, duration, idleCondition)
+				incomingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+				populateTrafficMap(trafficMap, &incomingVector, metric, o)
+
+				// 2) query for outbound traffic
+				if outgoingSelector == "" {
+					continue
+				}
+				query = networkFilterQuery(metric, outgoingSelector, "", duration, idleCondition)
+				outgoingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+				populateTrafficMap(trafficMap, &outgoingVector, metric, o)
+			}
+		}
+	}
+
 	return trafficMap
 }
 
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/models"
+)
+
+// WorkloadAuthorization is the API to evaluate the AuthorizationPolicies applying to a workload for a given request
+func WorkloadAuthorization(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	request := models.AccessRequest{
+		SourceNamespace:      query.Get("sourceNamespace"),
+		SourceServiceAccount: query.Get("sourceServiceAccount"),
+		SourcePrincipal:      query.Get("sourcePrincipal"),
+		RequestPrincipal:     query.Get("requestPrincipal"),
+		Namespace:            params["namespace"],
+		Workload:             params["workload"],
+		Host:                 query.Get("host"),
+		Method:               query.Get("method"),
+		Path:                 query.Get("path"),
+		Port:                 query.Get("port"),
+	}
+
+	decision, err := business.Authorization.GetAccessDecision(r.Context(), clusterNameFromQuery(query), request)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, decision)
+}
@@ -15,13 +15,21 @@ package handlers
 // The current Handlers:
 //   GraphNamespaces: Generate a graph for one or more requested namespaces.
 //   GraphNode:       Generate a graph for a specific node, detailing the immediate incoming and outgoing traffic.
+//   GraphNamespacesStream: Stream a namespaces graph, as Server-Sent Events, pushing only the changes.
+//   GraphNamespacesSnapshot: Export a namespaces graph, with the options used to generate it, for offline replay.
+//   GraphSnapshotReplay: Return the graph of a previously exported snapshot.
+//   GraphPath:       Generate a graph of the traffic paths between a source and a dest node.
+//   GraphWorkloadImpact: Analyze the transitive upstream and downstream traffic of a workload.
 //
 // The handlers accept the following query parameters (see notes below)
 //   appenders:       Comma-separated list of TelemetryVendor-specific appenders to run. (default: all)
-//   configVendor:    default: cytoscape
+//   compareDuration: time.Duration of the baseline query range, used only with compareTime (default: duration)
+//   compareTime:     Unix time (seconds) of the baseline query, generates a diff graph (default: no diff)
+//   configVendor:    cytoscape | dot | graphml | mermaid (default: cytoscape)
+//   debug:           Add the graph generation diagnostics to the response, cytoscape only (default: false)
 //   duration:        time.Duration indicating desired query range duration, (default: 10m)
 //   graphType:       Determines how to present the telemetry data. app | service | versionedApp | workload (default: workload)
-//   boxBy:           If supported by vendor, visually box by a specified node attribute (default: none)
+//   boxBy:           If supported by vendor, visually box by a specified node attribute, or label:<name> (default: none)
 //   namespaces:      Comma-separated list of namespace names to use in the graph. Will override namespace path param
 //   queryTime:       Unix time (seconds) for query such that range is queryTime-duration..queryTime (default now)
 //   TelemetryVendor: default: istio
@@ -30,9 +38,15 @@ package handlers
 //  Note: vendors may support additional, vendor-specific query parameters.
 //
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
 
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/api"
@@ -52,6 +66,108 @@ func GraphNamespaces(w http.ResponseWriter, r *http.Request) {
 	respond(w, code, payload)
 }
 
+// GraphNamespacesStream is a REST http.HandlerFunc streaming the graph for 1 or more namespaces as Server-Sent
+// Events. The graph is regenerated server-side every refreshInterval, once for all clients requesting the same
+// options, and only the node and edge changes are pushed after the initial graph. Note that the stream is
+// closed by the server write timeout, the client is expected to reconnect (as EventSource does automatically)
+// and then receives the latest graph, without regeneration.
+func GraphNamespacesStream(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	params := r.URL.Query()
+	if params.Get("queryTime") != "" {
+		graph.BadRequest("Graph stream does not support the 'queryTime' query parameter")
+	}
+	interval := api.DefaultGraphStreamInterval
+	if intervalString := params.Get("refreshInterval"); intervalString != "" {
+		var err error
+		if interval, err = time.ParseDuration(intervalString); err != nil {
+			graph.BadRequest(fmt.Sprintf("Invalid refreshInterval [%s]", intervalString))
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		graph.Error("Streaming is not supported by the response writer")
+	}
+
+	o := graph.NewOptions(r)
+
+	authInfo, err := getAuthInfo(r)
+	graph.CheckError(err)
+
+	events, unsubscribe := api.SubscribeGraphNamespaces(authInfo, o, interval)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	// ask the client to reconnect quickly when the stream is closed
+	_, _ = fmt.Fprint(w, "retry: 1000\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				// the client fell behind, it must reconnect to re-sync
+				return
+			}
+			data, err := json.Marshal(event.Payload)
+			if err != nil {
+				log.Errorf("Graph stream failed to marshal [%s] event: %v", event.Type, err)
+				return
+			}
+			if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// GraphNamespacesSnapshot is a REST http.HandlerFunc exporting the graph for 1 or more namespaces, along with
+// the options and telemetry details used to generate it, as a downloadable snapshot.
+func GraphNamespacesSnapshot(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphNamespacesSnapshot(r.Context(), business, o)
+	if code == http.StatusOK {
+		filename := fmt.Sprintf("kiali-graph-%s.json", time.Unix(o.TelemetryOptions.QueryTime, 0).UTC().Format("20060102-150405"))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+	respond(w, code, payload)
+}
+
+// maxGraphSnapshotSize limits the size of a snapshot posted for replay
+const maxGraphSnapshotSize = 32 << 20
+
+// GraphSnapshotReplay is a REST http.HandlerFunc returning the graph of a snapshot, previously exported with
+// GraphNamespacesSnapshot and supplied as the request body. The response has the same shape as GraphNamespaces.
+func GraphSnapshotReplay(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGraphSnapshotSize))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Graph snapshot could not be read: "+err.Error())
+		return
+	}
+
+	snapshot, err := api.ParseSnapshot(body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	RespondWithJSONIndent(w, http.StatusOK, snapshot.Graph)
+}
+
 // GraphNode is a REST http.HandlerFunc handling node-detail graph config generation.
 func GraphNode(w http.ResponseWriter, r *http.Request) {
 	defer handlePanic(w)
@@ -65,6 +181,78 @@ func GraphNode(w http.ResponseWriter, r *http.Request) {
 	respond(w, code, payload)
 }
 
+// GraphWorkloadImpact is a REST http.HandlerFunc handling the impact analysis of a workload.
+func GraphWorkloadImpact(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	workload := vars["workload"]
+
+	// The analysis walks a namespaces graph, not a node graph, so the options are generated without the
+	// node path params, for the requested namespaces plus the workload namespace.
+	params := r.URL.Query()
+	namespaces := []string{namespace}
+	for _, ns := range strings.Split(params.Get("namespaces"), ",") {
+		if ns = strings.TrimSpace(ns); ns != "" && ns != namespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	params.Set("namespaces", strings.Join(namespaces, ","))
+	r = mux.SetURLVars(r.Clone(r.Context()), map[string]string{})
+	r.URL.RawQuery = params.Encode()
+
+	o := graph.NewOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphWorkloadImpact(r.Context(), business, o, namespace, workload)
+	respond(w, code, payload)
+}
+
+// GraphPath is a REST http.HandlerFunc handling the graph of the traffic paths from a source node to a dest node.
+// The nodes are referenced by the source and dest query params, see graph.ParseNodeRef.
+func GraphPath(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	params := r.URL.Query()
+	source, err := graph.ParseNodeRef(params.Get("source"))
+	if err != nil {
+		graph.BadRequest(fmt.Sprintf("Invalid source: %v", err))
+	}
+	dest, err := graph.ParseNodeRef(params.Get("dest"))
+	if err != nil {
+		graph.BadRequest(fmt.Sprintf("Invalid dest: %v", err))
+	}
+
+	// The graph includes the source and dest namespaces, plus any requested (intermediate) namespaces. Service
+	// nodes must be injected to find referenced services.
+	namespaces := []string{source.Namespace}
+	if dest.Namespace != source.Namespace {
+		namespaces = append(namespaces, dest.Namespace)
+	}
+	for _, ns := range strings.Split(params.Get("namespaces"), ",") {
+		if ns = strings.TrimSpace(ns); ns != "" && ns != source.Namespace && ns != dest.Namespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	params.Set("namespaces", strings.Join(namespaces, ","))
+	if source.Service != "" || dest.Service != "" {
+		params.Set("injectServiceNodes", "true")
+	}
+	r = r.Clone(r.Context())
+	r.URL.RawQuery = params.Encode()
+
+	o := graph.NewOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphPath(r.Context(), business, o, source, dest)
+	respond(w, code, payload)
+}
+
 func handlePanic(w http.ResponseWriter) {
 	code := http.StatusInternalServerError
 	if r := recover(); r != nil {
@@ -94,6 +282,13 @@ func handlePanic(w http.ResponseWriter) {
 
 func respond(w http.ResponseWriter, code int, payload interface{}) {
 	if code == http.StatusOK {
+		// non-JSON config vendors (e.g. dot) return the config as text
+		if textConfig, ok := payload.(graph.TextConfig); ok {
+			w.Header().Set("Content-Type", textConfig.ContentType)
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(textConfig.Text))
+			return
+		}
 		RespondWithJSONIndent(w, code, payload)
 		return
 	}
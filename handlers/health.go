@@ -7,13 +7,21 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/util"
 )
 
-const defaultHealthRateInterval = "10m"
+const (
+	defaultHealthRateInterval = "10m"
+	// The health history defaults to an evaluation every minute, over the last minute, for the last 6 hours
+	defaultHealthHistoryRateInterval = "1m"
+	defaultHealthHistoryDuration     = 21600
+	defaultHealthHistoryStep         = 60
+)
 
 // NamespaceHealth is the API handler to get app-based health of every services in the given namespace
 func NamespaceHealth(w http.ResponseWriter, r *http.Request) {
@@ -141,3 +149,96 @@ func adjustRateInterval(ctx context.Context, business *business.Layer, namespace
 
 	return interval, nil
 }
+
+// NamespaceHealthHistory is the API handler to get the health history of every app, service or workload in the given namespace
+func NamespaceHealthHistory(w http.ResponseWriter, r *http.Request) {
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	p := namespaceHealthHistoryParams{}
+	if ok, err := p.extract(r); !ok {
+		// Bad request
+		RespondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// The range is aligned to the second, as the timestamps of the steps of the Prometheus range queries
+	end := p.QueryTime.Truncate(time.Second)
+	criteria := business.HealthHistoryCriteria{
+		Namespace:    p.Namespace,
+		Cluster:      p.Cluster,
+		RateInterval: p.RateInterval,
+		Range: prom_v1.Range{
+			Start: end.Add(-time.Duration(p.Duration) * time.Second),
+			End:   end,
+			Step:  time.Duration(p.Step) * time.Second,
+		},
+	}
+	if err := business.ValidateHealthHistoryRange(criteria.Range); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Bad request, "+err.Error())
+		return
+	}
+
+	var history models.NamespaceHealthHistory
+	switch p.Type {
+	case "app":
+		history, err = businessLayer.Health.GetNamespaceAppHealthHistory(r.Context(), criteria)
+	case "service":
+		history, err = businessLayer.Health.GetNamespaceServiceHealthHistory(r.Context(), criteria)
+	case "workload":
+		history, err = businessLayer.Health.GetNamespaceWorkloadHealthHistory(r.Context(), criteria)
+	}
+	if err != nil {
+		handleErrorResponse(w, err, "Error while fetching "+p.Type+" health history: "+err.Error())
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, history)
+}
+
+// namespaceHealthHistoryParams holds the path and query parameters for NamespaceHealthHistory
+//
+// swagger:parameters namespaceHealthHistory
+type namespaceHealthHistoryParams struct {
+	namespaceHealthParams
+	// The duration of the history in seconds, ending at the query time.
+	//
+	// in: query
+	// default: 21600
+	Duration int64 `json:"duration"`
+	// The step between two evaluations of the health in seconds.
+	//
+	// in: query
+	// default: 60
+	Step int64 `json:"step"`
+}
+
+func (p *namespaceHealthHistoryParams) extract(r *http.Request) (bool, string) {
+	if ok, err := p.namespaceHealthParams.extract(r); !ok {
+		return ok, err
+	}
+	queryParams := r.URL.Query()
+	p.RateInterval = defaultHealthHistoryRateInterval
+	if rateInterval := queryParams.Get("rateInterval"); rateInterval != "" {
+		p.RateInterval = rateInterval
+	}
+	p.Duration = defaultHealthHistoryDuration
+	if duration := queryParams.Get("duration"); duration != "" {
+		num, err := strconv.ParseInt(duration, 10, 64)
+		if err != nil {
+			return false, "Bad request, cannot parse query parameter 'duration'"
+		}
+		p.Duration = num
+	}
+	p.Step = defaultHealthHistoryStep
+	if step := queryParams.Get("step"); step != "" {
+		num, err := strconv.ParseInt(step, 10, 64)
+		if err != nil {
+			return false, "Bad request, cannot parse query parameter 'step'"
+		}
+		p.Step = num
+	}
+	return true, ""
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 
@@ -257,8 +259,26 @@ func IstioConfigUpdate(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		RespondWithError(w, http.StatusBadRequest, "Update request with bad update patch: "+err.Error())
+		return
 	}
 	jsonPatch := string(body)
+
+	dryRun := query.Get("dryRun") == "true"
+	if dryRun || config.Get().KialiFeatureFlags.Validations.RejectOnError {
+		dryRunConfigDetails, err := business.IstioConfig.DryRunUpdateIstioConfigDetail(cluster, namespace, objectType, object, jsonPatch)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		if dryRun {
+			respondWithDryRunConfigDetails(w, r, business, cluster, namespace, objectType, dryRunConfigDetails)
+			return
+		}
+		if !checkDryRunNewErrors(w, r, business, cluster, namespace, objectType, dryRunConfigDetails) {
+			return
+		}
+	}
+
 	updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(cluster, namespace, objectType, object, jsonPatch)
 
 	if err != nil {
@@ -296,6 +316,22 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Create request could not be read: "+err.Error())
 	}
 
+	dryRun := query.Get("dryRun") == "true"
+	if dryRun || config.Get().KialiFeatureFlags.Validations.RejectOnError {
+		dryRunConfigDetails, err := business.IstioConfig.DryRunCreateIstioConfigDetail(cluster, namespace, objectType, body)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		if dryRun {
+			respondWithDryRunConfigDetails(w, r, business, cluster, namespace, objectType, dryRunConfigDetails)
+			return
+		}
+		if !checkDryRunNewErrors(w, r, business, cluster, namespace, objectType, dryRunConfigDetails) {
+			return
+		}
+	}
+
 	createdConfigDetails, err := business.IstioConfig.CreateIstioConfigDetail(cluster, namespace, objectType, body)
 	if err != nil {
 		handleErrorResponse(w, err)
@@ -306,6 +342,58 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, createdConfigDetails)
 }
 
+// validateIstioConfigDetails sets the validation and the references of the dry-run object of the config details
+func validateIstioConfigDetails(ctx context.Context, layer *business.Layer, cluster, namespace, objectType string, istioConfigDetails models.IstioConfigDetails) (models.IstioConfigDetails, error) {
+	validations, references, err := layer.Validations.GetDryRunValidations(ctx, cluster, namespace, objectType, istioConfigDetails)
+	if err != nil {
+		return istioConfigDetails, err
+	}
+	object := istioConfigDetails.IstioObject()
+	if validation, found := validations[models.IstioValidationKey{ObjectType: models.ObjectTypeSingular[objectType], Namespace: object.GetNamespace(), Name: object.GetName()}]; found {
+		istioConfigDetails.IstioValidation = validation
+	}
+	if objectReferences, found := references[models.IstioReferenceKey{ObjectType: models.ObjectTypeSingular[objectType], Namespace: object.GetNamespace(), Name: object.GetName()}]; found {
+		istioConfigDetails.IstioReferences = objectReferences
+	}
+	return istioConfigDetails, nil
+}
+
+// respondWithDryRunConfigDetails responds with the dry-run config details, along with their validation and references
+func respondWithDryRunConfigDetails(w http.ResponseWriter, r *http.Request, layer *business.Layer, cluster, namespace, objectType string, dryRunConfigDetails models.IstioConfigDetails) {
+	dryRunConfigDetails, err := validateIstioConfigDetails(r.Context(), layer, cluster, namespace, objectType, dryRunConfigDetails)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, dryRunConfigDetails)
+}
+
+// checkDryRunNewErrors returns true when the dry-run change doesn't introduce validation errors, to the changed
+// object or to any other object. Otherwise it responds with the new errors, or the error of the validation.
+func checkDryRunNewErrors(w http.ResponseWriter, r *http.Request, layer *business.Layer, cluster, namespace, objectType string, dryRunConfigDetails models.IstioConfigDetails) bool {
+	newErrors, err := layer.Validations.GetDryRunNewErrors(r.Context(), cluster, namespace, objectType, dryRunConfigDetails)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return false
+	}
+	if len(newErrors) > 0 {
+		respondWithValidationErrors(w, newErrors)
+		return false
+	}
+	return true
+}
+
+func respondWithValidationErrors(w http.ResponseWriter, newErrors map[models.IstioValidationKey][]*models.IstioCheck) {
+	details := []string{}
+	for key, checks := range newErrors {
+		for _, check := range checks {
+			details = append(details, fmt.Sprintf("%s %s/%s %s %s: %s", key.ObjectType, key.Namespace, key.Name, check.Code, check.Path, check.Message))
+		}
+	}
+	sort.Strings(details)
+	RespondWithDetailedError(w, http.StatusBadRequest, "The change is rejected because it introduces validation errors", strings.Join(details, "\n"))
+}
+
 func checkObjectType(objectType string) bool {
 	return business.GetIstioAPI(objectType)
 }
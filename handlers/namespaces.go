@@ -38,6 +38,12 @@ func NamespaceValidationSummary(w http.ResponseWriter, r *http.Request) {
 
 	cluster := clusterNameFromQuery(query)
 
+	format, err := validationsReportFormat(r)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	business, err := getBusiness(r)
 	if err != nil {
 		log.Error(err)
@@ -50,6 +56,9 @@ func NamespaceValidationSummary(w http.ResponseWriter, r *http.Request) {
 	if errValidations != nil {
 		log.Error(errValidations)
 		RespondWithError(w, http.StatusInternalServerError, errValidations.Error())
+	} else if format != validationsJSONFormat {
+		respondWithValidationsReport(w, format, istioConfigValidationResults.FilterByNamespaces([]string{namespace}))
+		return
 	} else {
 		validationSummary = *istioConfigValidationResults.SummarizeValidation(namespace)
 	}
@@ -68,6 +77,12 @@ func ConfigValidationSummary(w http.ResponseWriter, r *http.Request) {
 	}
 	cluster := clusterNameFromQuery(params)
 
+	format, err := validationsReportFormat(r)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	business, err := getBusiness(r)
 	if err != nil {
 		log.Error(err)
@@ -80,6 +95,9 @@ func ConfigValidationSummary(w http.ResponseWriter, r *http.Request) {
 	if errValidations != nil {
 		log.Error(errValidations)
 		RespondWithError(w, http.StatusInternalServerError, errValidations.Error())
+	} else if format != validationsJSONFormat {
+		respondWithValidationsReport(w, format, istioConfigValidationResults.FilterByNamespaces(nss))
+		return
 	} else {
 		for _, ns := range nss {
 			validationSummaries[ns] = istioConfigValidationResults.SummarizeValidation(ns)
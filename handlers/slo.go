@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/util"
+)
+
+// sloParams holds the path and query parameters for NamespaceSLOs and ServiceSLOs
+//
+// swagger:parameters namespaceSLOs serviceSLOs
+type sloParams struct {
+	// Cluster name
+	Cluster string `json:"cluster"`
+	// The namespace scope
+	//
+	// in: path
+	Namespace string `json:"namespace"`
+	// The time to use for the prometheus query
+	QueryTime time.Time
+}
+
+func (p *sloParams) extract(r *http.Request) {
+	queryParams := r.URL.Query()
+	p.Cluster = clusterNameFromQuery(queryParams)
+	p.Namespace = mux.Vars(r)["namespace"]
+	p.QueryTime = util.Clock.Now()
+	if queryTime := queryParams.Get("queryTime"); queryTime != "" {
+		unix, err := strconv.ParseInt(queryTime, 10, 64)
+		if err == nil {
+			p.QueryTime = time.Unix(unix, 0)
+		}
+	}
+}
+
+// NamespaceSLOs is the API handler to fetch the SLO statuses of the services of a namespace
+func NamespaceSLOs(w http.ResponseWriter, r *http.Request) {
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	p := sloParams{}
+	p.extract(r)
+
+	slos, err := businessLayer.SLO.GetNamespaceSLOs(r.Context(), p.Namespace, p.Cluster, p.QueryTime)
+	if err != nil {
+		handleErrorResponse(w, err, "Error while fetching SLOs: "+err.Error())
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, slos)
+}
+
+// ServiceSLOs is the API handler to fetch the SLO statuses of a service
+func ServiceSLOs(w http.ResponseWriter, r *http.Request) {
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	p := sloParams{}
+	p.extract(r)
+	service := mux.Vars(r)["service"]
+
+	slos, err := businessLayer.SLO.GetServiceSLOs(r.Context(), p.Namespace, p.Cluster, service, p.QueryTime)
+	if err != nil {
+		handleErrorResponse(w, err, "Error while fetching SLOs: "+err.Error())
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, slos)
+}
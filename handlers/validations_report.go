@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/status"
+)
+
+// Formats of the validation reports, the default json format returns the validation summaries
+const (
+	validationsJSONFormat  = "json"
+	validationsJUnitFormat = "junit"
+	validationsSarifFormat = "sarif"
+)
+
+// Media types of the Accept header requesting the reports
+const (
+	junitContentType = "application/junit+xml"
+	sarifContentType = "application/sarif+json"
+)
+
+// validationsReportFormat returns the format requested for the validations, from the format query param or,
+// when not set, from the application/junit+xml or application/sarif+json media types of the Accept header
+func validationsReportFormat(r *http.Request) (string, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		switch format {
+		case validationsJSONFormat, validationsJUnitFormat, validationsSarifFormat:
+			return format, nil
+		}
+		return "", fmt.Errorf("unsupported format [%s], must be one of: json, junit, sarif", format)
+	}
+
+	// The report formats are only returned when explicitly accepted, preferred over json when their quality is higher
+	format, quality := validationsJSONFormat, 0.0
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q <= quality {
+			continue
+		}
+		switch mediaType {
+		case "application/json":
+			format, quality = validationsJSONFormat, q
+		case sarifContentType:
+			format, quality = validationsSarifFormat, q
+		case junitContentType:
+			format, quality = validationsJUnitFormat, q
+		}
+	}
+	return format, nil
+}
+
+// respondWithValidationsReport writes the validations as a SARIF or a JUnit report
+func respondWithValidationsReport(w http.ResponseWriter, format string, validations models.IstioValidations) {
+	switch format {
+	case validationsSarifFormat:
+		version, _ := status.GetStatus(status.CoreVersion)
+		response, err := json.MarshalIndent(validations.SARIF(version), "", "  ")
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", sarifContentType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(response)
+	case validationsJUnitFormat:
+		response, err := xml.MarshalIndent(validations.JUnit("kiali-validations"), "", "  ")
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(xml.Header))
+		_, _ = w.Write(response)
+	}
+}
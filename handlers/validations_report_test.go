@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestValidationsReportFormat(t *testing.T) {
+	cases := map[string]struct {
+		url      string
+		accept   string
+		expected string
+		err      bool
+	}{
+		"default":              {url: "/api/istio/validations", expected: validationsJSONFormat},
+		"format param":         {url: "/api/istio/validations?format=sarif", expected: validationsSarifFormat},
+		"param over header":    {url: "/api/istio/validations?format=junit", accept: "application/sarif+json", expected: validationsJUnitFormat},
+		"sarif accept header":  {url: "/api/istio/validations", accept: "application/sarif+json", expected: validationsSarifFormat},
+		"junit accept header":  {url: "/api/istio/validations", accept: "application/junit+xml", expected: validationsJUnitFormat},
+		"xml accept header":    {url: "/api/istio/validations", accept: "text/html, application/xml;q=0.9", expected: validationsJSONFormat},
+		"browser accept":       {url: "/api/istio/validations", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expected: validationsJSONFormat},
+		"client accept":        {url: "/api/istio/validations", accept: "application/json, text/plain, */*", expected: validationsJSONFormat},
+		"preferred json":       {url: "/api/istio/validations", accept: "application/sarif+json;q=0.5, application/json", expected: validationsJSONFormat},
+		"preferred report":     {url: "/api/istio/validations", accept: "application/json;q=0.5, application/junit+xml", expected: validationsJUnitFormat},
+		"not acceptable":       {url: "/api/istio/validations", accept: "application/sarif+json;q=0", expected: validationsJSONFormat},
+		"unsupported format":   {url: "/api/istio/validations?format=yaml", err: true},
+		"invalid accept value": {url: "/api/istio/validations", accept: ";;", expected: validationsJSONFormat},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+			format, err := validationsReportFormat(r)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, format)
+		})
+	}
+}
+
+func TestRespondWithValidationsReport(t *testing.T) {
+	validations := models.IstioValidations{
+		models.IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"}: &models.IstioValidation{
+			Name:       "reviews",
+			ObjectType: "virtualservice",
+			Checks: []*models.IstioCheck{
+				{Code: "KIA1101", Severity: models.ErrorSeverity, Message: "DestinationWeight on route doesn't have a valid service", Path: "spec/http[0]/route[0]/destination/host"},
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	respondWithValidationsReport(w, validationsSarifFormat, validations)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, sarifContentType, w.Header().Get("Content-Type"))
+	report := models.SarifLog{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	require.Len(t, report.Runs, 1)
+	assert.Len(t, report.Runs[0].Results, 1)
+
+	w = httptest.NewRecorder()
+	respondWithValidationsReport(w, validationsJUnitFormat, validations)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "<?xml"))
+	assert.Contains(t, w.Body.String(), `<testsuites name="kiali-validations" tests="1" failures="1">`)
+}
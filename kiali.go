@@ -38,6 +38,7 @@ import (
 	_ "go.uber.org/automaxprocs"
 
 	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/business/checkers/customrules"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
@@ -184,6 +185,10 @@ func validateConfig() error {
 		log.Infof("Some validation errors will be ignored %v. If these errors do occur, they will still be logged. If you think the validation errors you see are incorrect, please report them to the Kiali team if you have not done so already and provide the details of your scenario. This will keep Kiali validations strong for the whole community.", cfg.KialiFeatureFlags.Validations.Ignore)
 	}
 
+	if err := customrules.ValidateRules(cfg.KialiFeatureFlags.Validations.Rules); err != nil {
+		return err
+	}
+
 	// log a info message if the user is disabling some features
 	if len(cfg.KialiFeatureFlags.DisabledFeatures) > 0 {
 		log.Infof("Some features are disabled: [%v]", strings.Join(cfg.KialiFeatureFlags.DisabledFeatures, ","))
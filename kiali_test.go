@@ -88,3 +88,30 @@ func TestValidateAuthStrategy(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateValidationRules(t *testing.T) {
+	// create a base config that we know is valid
+	conf := config.NewConfig()
+	conf.LoginToken.SigningKey = util.RandomString(16)
+	conf.Server.StaticContentRootDirectory = "."
+	conf.Auth.Strategy = "anonymous"
+
+	rule := config.ValidationRule{Code: "ORG001", Expression: "has(object.spec.gateways)", Message: "Every route must be exposed", ObjectType: "virtualservice"}
+	conf.KialiFeatureFlags.Validations.Rules = []config.ValidationRule{rule}
+	config.Set(conf)
+	if err := validateConfig(); err != nil {
+		t.Errorf("Validation rules validation should have succeeded: %v", err)
+	}
+
+	invalidExpression := rule
+	invalidExpression.Expression = "has(object.spec.gateways"
+	builtInCode := rule
+	builtInCode.Code = "KIA1102"
+	for _, invalid := range []config.ValidationRule{invalidExpression, builtInCode} {
+		conf.KialiFeatureFlags.Validations.Rules = []config.ValidationRule{rule, invalid}
+		config.Set(conf)
+		if err := validateConfig(); err == nil {
+			t.Errorf("Validation rules validation should have failed [%v]", invalid)
+		}
+	}
+}
@@ -30,9 +30,12 @@ type KialiCache interface {
 	// Embedded for backward compatibility for business methods that just use one cluster.
 	// All business methods should eventually use the multi-cluster cache.
 	KubeCache
+	ConfigDumpsCache
 	NamespacesCache
 	ProxyStatusCache
 	RegistryStatusCache
+	SecretsCache
+	SLIsCache
 }
 
 // namespaceCache caches namespaces according to their token.
@@ -71,15 +74,24 @@ type kialiCacheImpl struct {
 	registryStatusLock     sync.RWMutex
 	registryStatusCreated  *time.Time
 	registryStatus         *kubernetes.RegistryStatus
+	secretsLock            sync.RWMutex
+	secretLookups          map[string]secretLookup
+	configDumpsLock        sync.RWMutex
+	configDumps            map[string]configDumpEntry // keyed by <cluster>/<namespace>/<pod>
+	slisLock               sync.RWMutex
+	slis                   map[string]slisEntry // keyed by <cluster>/<namespace>/<query>
 }
 
 func NewKialiCache(clientFactory kubernetes.ClientFactory, cfg config.Config, namespaceSeedList ...string) (KialiCache, error) {
 	kialiCacheImpl := kialiCacheImpl{
 		clientFactory:              clientFactory,
 		clientRefreshPollingPeriod: time.Duration(time.Second * 60),
+		configDumps:                make(map[string]configDumpEntry),
 		kubeCache:                  make(map[string]KubeCache),
 		proxyStatusNamespaces:      make(map[string]map[string]map[string]podProxyStatus),
 		refreshDuration:            time.Duration(cfg.KubernetesConfig.CacheDuration) * time.Second,
+		secretLookups:              make(map[string]secretLookup),
+		slis:                       make(map[string]slisEntry),
 		tokenNamespaces:            make(map[string]namespaceCache),
 		tokenNamespaceDuration:     time.Duration(cfg.KubernetesConfig.CacheTokenNamespaceDuration) * time.Second,
 	}
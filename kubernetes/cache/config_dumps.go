@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+type (
+	ConfigDumpsCache interface {
+		// GetConfigDump returns the cached config dump of the proxy of a pod, found is false when it's not cached or
+		// expired. The dump is nil when it couldn't be fetched.
+		GetConfigDump(cluster, namespace, pod string) (dump *kubernetes.ConfigDump, found bool)
+		// SetConfigDump caches the config dump of the proxy of a pod for the cache duration
+		SetConfigDump(cluster, namespace, pod string, dump *kubernetes.ConfigDump)
+	}
+)
+
+type configDumpEntry struct {
+	created time.Time
+	dump    *kubernetes.ConfigDump
+}
+
+func (c *kialiCacheImpl) GetConfigDump(cluster, namespace, pod string) (*kubernetes.ConfigDump, bool) {
+	defer c.configDumpsLock.RUnlock()
+	c.configDumpsLock.RLock()
+	entry, found := c.configDumps[cluster+"/"+namespace+"/"+pod]
+	if !found || time.Since(entry.created) > c.refreshDuration {
+		return nil, false
+	}
+	return entry.dump, true
+}
+
+func (c *kialiCacheImpl) SetConfigDump(cluster, namespace, pod string, dump *kubernetes.ConfigDump) {
+	defer c.configDumpsLock.Unlock()
+	c.configDumpsLock.Lock()
+	// the dumps are large, the expired ones (i.e. of deleted pods) are dropped
+	for key, entry := range c.configDumps {
+		if time.Since(entry.created) > c.refreshDuration {
+			delete(c.configDumps, key)
+		}
+	}
+	c.configDumps[cluster+"/"+namespace+"/"+pod] = configDumpEntry{created: time.Now(), dump: dump}
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestConfigDumps(t *testing.T) {
+	assert := assert.New(t)
+
+	kialiCache := &kialiCacheImpl{
+		configDumps:     make(map[string]configDumpEntry),
+		refreshDuration: time.Minute,
+	}
+
+	_, found := kialiCache.GetConfigDump("east", "bookinfo", "reviews-v1-abcde")
+	assert.False(found)
+
+	dump := &kubernetes.ConfigDump{}
+	kialiCache.SetConfigDump("east", "bookinfo", "reviews-v1-abcde", dump)
+	cached, found := kialiCache.GetConfigDump("east", "bookinfo", "reviews-v1-abcde")
+	assert.True(found)
+	assert.Same(dump, cached)
+
+	// The failed fetches are cached too
+	kialiCache.SetConfigDump("east", "bookinfo", "ratings-v1-fghij", nil)
+	cached, found = kialiCache.GetConfigDump("east", "bookinfo", "ratings-v1-fghij")
+	assert.True(found)
+	assert.Nil(cached)
+
+	// The expired dumps are dropped
+	kialiCache.configDumps["east/bookinfo/reviews-v1-abcde"] = configDumpEntry{created: time.Now().Add(-2 * time.Minute), dump: dump}
+	_, found = kialiCache.GetConfigDump("east", "bookinfo", "reviews-v1-abcde")
+	assert.False(found)
+	kialiCache.SetConfigDump("east", "bookinfo", "details-v1-klmno", dump)
+	assert.Len(kialiCache.configDumps, 2)
+}
@@ -21,13 +21,17 @@ import (
 	istiotelem_v1alpha1_listers "istio.io/client-go/pkg/listers/telemetry/v1alpha1"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/informers"
 	apps_v1_listers "k8s.io/client-go/listers/apps/v1"
 	core_v1_listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
+	k8s_v1alpha2_listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
 	k8s_v1beta1_listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
 
 	"github.com/kiali/kiali/config"
@@ -97,6 +101,14 @@ type KubeCache interface {
 	GetK8sGateways(namespace, labelSelector string) ([]*gatewayapi_v1beta1.Gateway, error)
 	GetK8sHTTPRoute(namespace, name string) (*gatewayapi_v1beta1.HTTPRoute, error)
 	GetK8sHTTPRoutes(namespace, labelSelector string) ([]*gatewayapi_v1beta1.HTTPRoute, error)
+	GetK8sGRPCRoute(namespace, name string) (*gatewayapi_v1alpha2.GRPCRoute, error)
+	GetK8sGRPCRoutes(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.GRPCRoute, error)
+	GetK8sTCPRoute(namespace, name string) (*gatewayapi_v1alpha2.TCPRoute, error)
+	GetK8sTCPRoutes(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.TCPRoute, error)
+	GetK8sTLSRoute(namespace, name string) (*gatewayapi_v1alpha2.TLSRoute, error)
+	GetK8sTLSRoutes(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.TLSRoute, error)
+	GetK8sReferenceGrant(namespace, name string) (*gatewayapi_v1alpha2.ReferenceGrant, error)
+	GetK8sReferenceGrants(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.ReferenceGrant, error)
 
 	GetAuthorizationPolicy(namespace, name string) (*security_v1beta1.AuthorizationPolicy, error)
 	GetAuthorizationPolicies(namespace, labelSelector string) ([]*security_v1beta1.AuthorizationPolicy, error)
@@ -123,21 +135,25 @@ type cacheLister struct {
 	cachesSynced []cache.InformerSynced
 
 	// Istio listers
-	authzLister           istiosec_v1beta1_listers.AuthorizationPolicyLister
-	destinationRuleLister istionet_v1beta1_listers.DestinationRuleLister
-	envoyFilterLister     istionet_v1alpha3_listers.EnvoyFilterLister
-	gatewayLister         istionet_v1beta1_listers.GatewayLister
-	k8sgatewayLister      k8s_v1beta1_listers.GatewayLister
-	k8shttprouteLister    k8s_v1beta1_listers.HTTPRouteLister
-	peerAuthnLister       istiosec_v1beta1_listers.PeerAuthenticationLister
-	requestAuthnLister    istiosec_v1beta1_listers.RequestAuthenticationLister
-	serviceEntryLister    istionet_v1beta1_listers.ServiceEntryLister
-	sidecarLister         istionet_v1beta1_listers.SidecarLister
-	telemetryLister       istiotelem_v1alpha1_listers.TelemetryLister
-	virtualServiceLister  istionet_v1beta1_listers.VirtualServiceLister
-	wasmPluginLister      istioext_v1alpha1_listers.WasmPluginLister
-	workloadEntryLister   istionet_v1beta1_listers.WorkloadEntryLister
-	workloadGroupLister   istionet_v1beta1_listers.WorkloadGroupLister
+	authzLister             istiosec_v1beta1_listers.AuthorizationPolicyLister
+	destinationRuleLister   istionet_v1beta1_listers.DestinationRuleLister
+	envoyFilterLister       istionet_v1alpha3_listers.EnvoyFilterLister
+	gatewayLister           istionet_v1beta1_listers.GatewayLister
+	k8sgatewayLister        k8s_v1beta1_listers.GatewayLister
+	k8sgrpcrouteLister      k8s_v1alpha2_listers.GRPCRouteLister
+	k8shttprouteLister      k8s_v1beta1_listers.HTTPRouteLister
+	k8sreferencegrantLister k8s_v1alpha2_listers.ReferenceGrantLister
+	k8stcprouteLister       k8s_v1alpha2_listers.TCPRouteLister
+	k8stlsrouteLister       k8s_v1alpha2_listers.TLSRouteLister
+	peerAuthnLister         istiosec_v1beta1_listers.PeerAuthenticationLister
+	requestAuthnLister      istiosec_v1beta1_listers.RequestAuthenticationLister
+	serviceEntryLister      istionet_v1beta1_listers.ServiceEntryLister
+	sidecarLister           istionet_v1beta1_listers.SidecarLister
+	telemetryLister         istiotelem_v1alpha1_listers.TelemetryLister
+	virtualServiceLister    istionet_v1beta1_listers.VirtualServiceLister
+	wasmPluginLister        istioext_v1alpha1_listers.WasmPluginLister
+	workloadEntryLister     istionet_v1beta1_listers.WorkloadEntryLister
+	workloadGroupLister     istionet_v1beta1_listers.WorkloadGroupLister
 }
 
 // kubeCache is a local cache of kube objects. Manages informers and listers.
@@ -168,6 +184,9 @@ func NewKubeCache(kialiClient kubernetes.ClientInterface, cfg config.Config, ref
 	for _, iType := range cfg.KubernetesConfig.CacheIstioTypes {
 		cacheIstioTypes[iType] = true
 	}
+	if kialiClient.IsGatewayAPI() {
+		removeNotServedGatewayAPITypes(kialiClient, cacheIstioTypes)
+	}
 	log.Tracef("[Kiali Cache] cacheIstioTypes %v", cacheIstioTypes)
 
 	cacheNamespacesRegexps := make([]regexp.Regexp, len(cacheNamespaces))
@@ -330,6 +349,46 @@ func (c *kubeCache) CheckIstioResource(resourceType string) bool {
 	return exist && c.client.IsIstioAPI()
 }
 
+// gatewayAPIResources are the resources of the K8s Gateway API types of the cache
+var gatewayAPIResources = map[string]schema.GroupVersionResource{
+	kubernetes.K8sGateways:        kubernetes.K8sNetworkingGroupVersionV1Beta1.WithResource("gateways"),
+	kubernetes.K8sHTTPRoutes:      kubernetes.K8sNetworkingGroupVersionV1Beta1.WithResource("httproutes"),
+	kubernetes.K8sGRPCRoutes:      kubernetes.K8sNetworkingGroupVersionV1Alpha2.WithResource("grpcroutes"),
+	kubernetes.K8sReferenceGrants: kubernetes.K8sNetworkingGroupVersionV1Alpha2.WithResource("referencegrants"),
+	kubernetes.K8sTCPRoutes:       kubernetes.K8sNetworkingGroupVersionV1Alpha2.WithResource("tcproutes"),
+	kubernetes.K8sTLSRoutes:       kubernetes.K8sNetworkingGroupVersionV1Alpha2.WithResource("tlsroutes"),
+}
+
+// removeNotServedGatewayAPITypes removes from the cached types the K8s Gateway API types not served by the cluster.
+// The experimental types (e.g. TCPRoute) are not installed with the standard channel of the Gateway API, and their
+// informers would never sync.
+func removeNotServedGatewayAPITypes(kialiClient kubernetes.ClientInterface, cacheIstioTypes map[string]bool) {
+	servedResources := map[schema.GroupVersion]map[string]bool{}
+	for resourceType, resource := range gatewayAPIResources {
+		iType := kubernetes.PluralType[resourceType]
+		if !cacheIstioTypes[iType] {
+			continue
+		}
+		groupVersion := resource.GroupVersion()
+		if _, found := servedResources[groupVersion]; !found {
+			servedResources[groupVersion] = map[string]bool{}
+			resourceList, err := kialiClient.Kube().Discovery().ServerResourcesForGroupVersion(groupVersion.String())
+			if err != nil && !kube_errors.IsNotFound(err) {
+				log.Warningf("[Kiali Cache] Error checking the resources of the K8s Gateway API [%s]: %v", groupVersion, err)
+			}
+			if resourceList != nil {
+				for _, apiResource := range resourceList.APIResources {
+					servedResources[groupVersion][apiResource.Name] = true
+				}
+			}
+		}
+		if !servedResources[groupVersion][resource.Resource] {
+			log.Infof("[Kiali Cache] %s [%s] is not served by the cluster, it is not cached", iType, groupVersion)
+			delete(cacheIstioTypes, iType)
+		}
+	}
+}
+
 // starter is a small interface around the different informer factories that
 // allows us to start them all.
 type starter interface {
@@ -463,6 +522,26 @@ func (c *kubeCache) createGatewayInformers(namespace string) gateway.SharedInfor
 			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1beta1().HTTPRoutes().Informer().HasSynced)
 			sharedInformers.Gateway().V1beta1().Gateways().Informer().AddEventHandler(c.registryRefreshHandler)
 		}
+		if c.CheckIstioResource(kubernetes.K8sGRPCRoutes) {
+			lister.k8sgrpcrouteLister = sharedInformers.Gateway().V1alpha2().GRPCRoutes().Lister()
+			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1alpha2().GRPCRoutes().Informer().HasSynced)
+			sharedInformers.Gateway().V1alpha2().GRPCRoutes().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
+		if c.CheckIstioResource(kubernetes.K8sReferenceGrants) {
+			lister.k8sreferencegrantLister = sharedInformers.Gateway().V1alpha2().ReferenceGrants().Lister()
+			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1alpha2().ReferenceGrants().Informer().HasSynced)
+			sharedInformers.Gateway().V1alpha2().ReferenceGrants().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
+		if c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+			lister.k8stcprouteLister = sharedInformers.Gateway().V1alpha2().TCPRoutes().Lister()
+			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1alpha2().TCPRoutes().Informer().HasSynced)
+			sharedInformers.Gateway().V1alpha2().TCPRoutes().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
+		if c.CheckIstioResource(kubernetes.K8sTLSRoutes) {
+			lister.k8stlsrouteLister = sharedInformers.Gateway().V1alpha2().TLSRoutes().Lister()
+			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1alpha2().TLSRoutes().Informer().HasSynced)
+			sharedInformers.Gateway().V1alpha2().TLSRoutes().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
 	}
 	return sharedInformers
 }
@@ -1418,6 +1497,222 @@ func (c *kubeCache) GetK8sHTTPRoutes(namespace, labelSelector string) ([]*gatewa
 	return retRoutes, nil
 }
 
+func (c *kubeCache) GetK8sGRPCRoute(namespace, name string) (*gatewayapi_v1alpha2.GRPCRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sGRPCRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sGRPCRouteType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8sgrpcrouteLister.GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retR := r.DeepCopy()
+	retR.Kind = kubernetes.K8sGRPCRouteType
+	return retR, nil
+}
+
+func (c *kubeCache) GetK8sGRPCRoutes(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.GRPCRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sGRPCRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sGRPCRoutes)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8sgrpcrouteLister.GRPCRoutes(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi_v1alpha2.GRPCRoute{}, nil
+	}
+
+	var retR []*gatewayapi_v1alpha2.GRPCRoute
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sGRPCRouteType
+		retR = append(retR, ww)
+	}
+
+	return retR, nil
+}
+
+func (c *kubeCache) GetK8sTCPRoute(namespace, name string) (*gatewayapi_v1alpha2.TCPRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTCPRouteType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8stcprouteLister.TCPRoutes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retR := r.DeepCopy()
+	retR.Kind = kubernetes.K8sTCPRouteType
+	return retR, nil
+}
+
+func (c *kubeCache) GetK8sTCPRoutes(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.TCPRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTCPRoutes)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8stcprouteLister.TCPRoutes(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi_v1alpha2.TCPRoute{}, nil
+	}
+
+	var retR []*gatewayapi_v1alpha2.TCPRoute
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sTCPRouteType
+		retR = append(retR, ww)
+	}
+
+	return retR, nil
+}
+
+func (c *kubeCache) GetK8sTLSRoute(namespace, name string) (*gatewayapi_v1alpha2.TLSRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTLSRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTLSRouteType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8stlsrouteLister.TLSRoutes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retR := r.DeepCopy()
+	retR.Kind = kubernetes.K8sTLSRouteType
+	return retR, nil
+}
+
+func (c *kubeCache) GetK8sTLSRoutes(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.TLSRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTLSRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTLSRoutes)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8stlsrouteLister.TLSRoutes(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi_v1alpha2.TLSRoute{}, nil
+	}
+
+	var retR []*gatewayapi_v1alpha2.TLSRoute
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sTLSRouteType
+		retR = append(retR, ww)
+	}
+
+	return retR, nil
+}
+
+func (c *kubeCache) GetK8sReferenceGrant(namespace, name string) (*gatewayapi_v1alpha2.ReferenceGrant, error) {
+	if !c.CheckIstioResource(kubernetes.K8sReferenceGrants) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sReferenceGrantType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8sreferencegrantLister.ReferenceGrants(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retR := r.DeepCopy()
+	retR.Kind = kubernetes.K8sReferenceGrantType
+	return retR, nil
+}
+
+func (c *kubeCache) GetK8sReferenceGrants(namespace, labelSelector string) ([]*gatewayapi_v1alpha2.ReferenceGrant, error) {
+	if !c.CheckIstioResource(kubernetes.K8sReferenceGrants) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sReferenceGrants)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8sreferencegrantLister.ReferenceGrants(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi_v1alpha2.ReferenceGrant{}, nil
+	}
+
+	var retR []*gatewayapi_v1alpha2.ReferenceGrant
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sReferenceGrantType
+		retR = append(retR, ww)
+	}
+
+	return retR, nil
+}
+
 func (c *kubeCache) GetAuthorizationPolicy(namespace, name string) (*security_v1beta1.AuthorizationPolicy, error) {
 	if !c.CheckIstioResource(kubernetes.AuthorizationPolicies) {
 		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.AuthorizationPoliciesType)
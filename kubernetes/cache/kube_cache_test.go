@@ -13,6 +13,7 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -362,3 +363,37 @@ func TestIstioAPIDisabled(t *testing.T) {
 
 	assert.Error(err)
 }
+
+func TestGatewayAPITypesNotServed(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ns := &core_v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+
+	cfg := config.NewConfig()
+	cfg.KubernetesConfig.CacheIstioTypes = append(cfg.KubernetesConfig.CacheIstioTypes, kubernetes.K8sGRPCRouteType, kubernetes.K8sTCPRouteType, kubernetes.K8sTLSRouteType)
+	emptyRefreshHandler := NewRegistryHandler(func() {})
+	fakeClient := kubetest.NewFakeK8sClient(ns)
+	fakeClient.GatewayAPIEnabled = true
+	// Only the standard channel of the Gateway API is installed
+	kubeClientset := fakeClient.KubeClientset.(*kubefake.Clientset)
+	kubeClientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: kubernetes.K8sApiNetworkingVersionV1Beta1,
+		APIResources: []metav1.APIResource{{Name: "gateways", Namespaced: true}, {Name: "httproutes", Namespaced: true}},
+	}}
+	kubeCache, err := NewKubeCache(fakeClient, *cfg, emptyRefreshHandler, cfg.Deployment.AccessibleNamespaces...)
+	require.NoError(err)
+	defer kubeCache.Stop()
+
+	assert.True(kubeCache.CheckIstioResource(kubernetes.K8sGateways))
+	assert.True(kubeCache.CheckIstioResource(kubernetes.K8sHTTPRoutes))
+	assert.False(kubeCache.CheckIstioResource(kubernetes.K8sGRPCRoutes))
+	assert.False(kubeCache.CheckIstioResource(kubernetes.K8sReferenceGrants))
+	assert.False(kubeCache.CheckIstioResource(kubernetes.K8sTCPRoutes))
+	assert.False(kubeCache.CheckIstioResource(kubernetes.K8sTLSRoutes))
+
+	gateways, err := kubeCache.GetK8sGateways("test", "")
+	require.NoError(err)
+	assert.Empty(gateways)
+	_, err = kubeCache.GetK8sTCPRoutes("test", "")
+	assert.Error(err)
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 type ProxyStatusCache interface {
 	GetPodProxyStatus(cluster, namespace, pod string) *kubernetes.ProxyStatus
+	GetProxyStatuses(cluster string) []*kubernetes.ProxyStatus
 }
 
 // pollIstiodForProxyStatus is a long running goroutine that will periodically poll istiod for proxy status.
@@ -73,6 +75,22 @@ func (c *kialiCacheImpl) GetPodProxyStatus(cluster, namespace, pod string) *kube
 	return nil
 }
 
+// GetProxyStatuses returns the proxy status of every proxy in the cluster, sorted by proxy ID
+func (c *kialiCacheImpl) GetProxyStatuses(cluster string) []*kubernetes.ProxyStatus {
+	defer c.proxyStatusLock.RUnlock()
+	c.proxyStatusLock.RLock()
+	proxyStatuses := []*kubernetes.ProxyStatus{}
+	for _, nsProxyStatus := range c.proxyStatusNamespaces[cluster] {
+		for _, podProxyStatus := range nsProxyStatus {
+			proxyStatuses = append(proxyStatuses, podProxyStatus.proxyStatus)
+		}
+	}
+	sort.Slice(proxyStatuses, func(i, j int) bool {
+		return proxyStatuses[i].ProxyID < proxyStatuses[j].ProxyID
+	})
+	return proxyStatuses
+}
+
 func (c *kialiCacheImpl) setProxyStatus(proxyStatus []*kubernetes.ProxyStatus) {
 	defer c.proxyStatusLock.Unlock()
 	c.proxyStatusLock.Lock()
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/log"
+)
+
+type (
+	SecretsCache interface {
+		// SecretExists tells whether a secret exists, known is false when it can't be looked up, i.e. when the Kiali
+		// service account has no access to secrets. The lookups are cached for the cache duration.
+		SecretExists(cluster, namespace, name string) (exists, known bool)
+	}
+)
+
+// secretLookup is the result of looking up a secret with the Kiali service account
+type secretLookup struct {
+	created time.Time
+	exists  bool
+	known   bool
+}
+
+func (c *kialiCacheImpl) SecretExists(cluster, namespace, name string) (bool, bool) {
+	key := cluster + "/" + namespace + "/" + name
+
+	c.secretsLock.RLock()
+	lookup, found := c.secretLookups[key]
+	c.secretsLock.RUnlock()
+	if found && time.Since(lookup.created) <= c.refreshDuration {
+		return lookup.exists, lookup.known
+	}
+
+	client, found := c.clientFactory.GetSAClients()[cluster]
+	if !found {
+		return false, false
+	}
+	lookup = secretLookup{created: time.Now()}
+	if _, err := client.GetSecret(namespace, name); err == nil {
+		lookup.exists, lookup.known = true, true
+	} else if errors.IsNotFound(err) {
+		lookup.known = true
+	} else {
+		log.Debugf("[Kiali Cache] Unable to look up secret [%s/%s] in cluster [%s]: %v", namespace, name, cluster, err)
+	}
+
+	c.secretsLock.Lock()
+	c.secretLookups[key] = lookup
+	c.secretsLock.Unlock()
+	return lookup.exists, lookup.known
+}
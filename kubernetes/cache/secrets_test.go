@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestSecretExists(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("GetSecret", "bookinfo", "registry").Return(&core_v1.Secret{}, nil)
+	k8s.On("GetSecret", "bookinfo", "missing").Return(&core_v1.Secret{}, kubernetes.NewNotFound("missing", "v1", "Secret"))
+	k8s.On("GetSecret", "istio-system", "registry").Return(&core_v1.Secret{}, errors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "registry", nil))
+
+	kialiCache := &kialiCacheImpl{
+		clientFactory:   kubetest.NewK8SClientFactoryMock(k8s),
+		refreshDuration: time.Minute,
+		secretLookups:   make(map[string]secretLookup),
+	}
+
+	exists, known := kialiCache.SecretExists(cluster, "bookinfo", "registry")
+	assert.True(exists)
+	assert.True(known)
+
+	exists, known = kialiCache.SecretExists(cluster, "bookinfo", "missing")
+	assert.False(exists)
+	assert.True(known)
+
+	// Without access to the secrets it's unknown whether they exist
+	_, known = kialiCache.SecretExists(cluster, "istio-system", "registry")
+	assert.False(known)
+
+	_, known = kialiCache.SecretExists("unknown-cluster", "bookinfo", "registry")
+	assert.False(known)
+
+	// The lookups are cached
+	exists, known = kialiCache.SecretExists(cluster, "bookinfo", "registry")
+	assert.True(exists)
+	assert.True(known)
+	_, known = kialiCache.SecretExists(cluster, "istio-system", "registry")
+	assert.False(known)
+	k8s.AssertNumberOfCalls(t, "GetSecret", 3)
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"time"
+)
+
+// SLIsCacheDuration is how long the SLIs are cached, the shortest burn rate window of the SLOs. The SLIs over the long
+// windows (e.g. 30d) are expensive to query and barely change in this time.
+const SLIsCacheDuration = 5 * time.Minute
+
+type (
+	SLIsCache interface {
+		// GetSLIs returns the SLIs of the services of a namespace, keyed by service name, computed by the query less
+		// than SLIsCacheDuration before the query time. Found is false when they are not cached.
+		GetSLIs(cluster, namespace, query string, queryTime time.Time) (slis map[string]float64, found bool)
+		// SetSLIs caches the SLIs of the services of a namespace computed by the query at the query time
+		SetSLIs(cluster, namespace, query string, queryTime time.Time, slis map[string]float64)
+	}
+)
+
+type slisEntry struct {
+	created   time.Time
+	queryTime time.Time
+	slis      map[string]float64
+}
+
+func (c *kialiCacheImpl) GetSLIs(cluster, namespace, query string, queryTime time.Time) (map[string]float64, bool) {
+	defer c.slisLock.RUnlock()
+	c.slisLock.RLock()
+	entry, found := c.slis[cluster+"/"+namespace+"/"+query]
+	if !found || queryTime.Before(entry.queryTime) || queryTime.Sub(entry.queryTime) >= SLIsCacheDuration {
+		return nil, false
+	}
+	return entry.slis, true
+}
+
+func (c *kialiCacheImpl) SetSLIs(cluster, namespace, query string, queryTime time.Time, slis map[string]float64) {
+	defer c.slisLock.Unlock()
+	c.slisLock.Lock()
+	// the SLIs of the removed namespaces and SLOs are dropped once expired
+	for key, entry := range c.slis {
+		if time.Since(entry.created) >= SLIsCacheDuration {
+			delete(c.slis, key)
+		}
+	}
+	c.slis[cluster+"/"+namespace+"/"+query] = slisEntry{created: time.Now(), queryTime: queryTime, slis: slis}
+}
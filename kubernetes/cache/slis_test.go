@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLIs(t *testing.T) {
+	assert := assert.New(t)
+
+	kialiCache := &kialiCacheImpl{
+		slis: make(map[string]slisEntry),
+	}
+	queryTime := time.Now()
+
+	_, found := kialiCache.GetSLIs("east", "bookinfo", "availability/30d", queryTime)
+	assert.False(found)
+
+	kialiCache.SetSLIs("east", "bookinfo", "availability/30d", queryTime, map[string]float64{"reviews": 99.5})
+	slis, found := kialiCache.GetSLIs("east", "bookinfo", "availability/30d", queryTime.Add(time.Minute))
+	assert.True(found)
+	assert.Equal(map[string]float64{"reviews": 99.5}, slis)
+
+	// The SLIs are only returned for the query times of the next minutes
+	_, found = kialiCache.GetSLIs("east", "bookinfo", "availability/30d", queryTime.Add(-time.Minute))
+	assert.False(found)
+	_, found = kialiCache.GetSLIs("east", "bookinfo", "availability/30d", queryTime.Add(SLIsCacheDuration))
+	assert.False(found)
+	_, found = kialiCache.GetSLIs("east", "bookinfo", "latency/500/30d", queryTime)
+	assert.False(found)
+
+	// The SLIs of past query times are cached too, the expired SLIs are dropped
+	kialiCache.SetSLIs("east", "bookinfo", "availability/1h", queryTime.Add(-24*time.Hour), map[string]float64{})
+	kialiCache.slis["east/bookinfo/availability/30d"] = slisEntry{created: queryTime.Add(-2 * SLIsCacheDuration), queryTime: queryTime}
+	kialiCache.SetSLIs("east", "bookinfo", "availability/5m", queryTime, map[string]float64{})
+	assert.Len(kialiCache.slis, 2)
+}
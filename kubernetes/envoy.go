@@ -56,6 +56,7 @@ type StaticListener struct {
 }
 
 type EnvoyListener struct {
+	Name    string `mapstructure:"name"`
 	Address struct {
 		SocketAddress struct {
 			Address   string  `mapstructure:"address"`
@@ -96,6 +96,18 @@ func NewFakeK8sClient(objects ...runtime.Object) *FakeK8sClient {
 	istioClient := istiofake.NewSimpleClientset(istioObjects...)
 	gatewayAPIClient := gatewayapifake.NewSimpleClientset(gatewayapiObjects...)
 
+	// The discovery serves all the K8s Gateway API resources watched by the cache
+	kubeClient.Resources = append(kubeClient.Resources,
+		&metav1.APIResourceList{
+			GroupVersion: kialikube.K8sApiNetworkingVersionV1Beta1,
+			APIResources: []metav1.APIResource{{Name: "gateways", Namespaced: true}, {Name: "httproutes", Namespaced: true}},
+		},
+		&metav1.APIResourceList{
+			GroupVersion: kialikube.K8sApiNetworkingVersionV1Alpha2,
+			APIResources: []metav1.APIResource{{Name: "grpcroutes", Namespaced: true}, {Name: "referencegrants", Namespaced: true}, {Name: "tcproutes", Namespaced: true}, {Name: "tlsroutes", Namespaced: true}},
+		},
+	)
+
 	// These are created separately because the fake clientset guesses the resource name based on the Kind.
 	for _, gw := range istioGateways {
 		if _, err := istioClient.NetworkingV1beta1().Gateways(gw.Namespace).Create(context.TODO(), gw, metav1.CreateOptions{}); err != nil {
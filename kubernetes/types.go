@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -73,6 +74,26 @@ const (
 	// K8sActualHTTPRouteType There is a naming conflict between Istio and K8s Gateways, keeping here an actual type to show in YAML editor
 	K8sActualHTTPRouteType = "HTTPRoute"
 
+	K8sGRPCRoutes    = "k8sgrpcroutes"
+	K8sGRPCRouteType = "K8sGRPCRoute"
+	// K8sActualGRPCRouteType keeps the actual type to show in YAML editor, following the other K8s Gateway API types
+	K8sActualGRPCRouteType = "GRPCRoute"
+
+	K8sTCPRoutes    = "k8stcproutes"
+	K8sTCPRouteType = "K8sTCPRoute"
+	// K8sActualTCPRouteType keeps the actual type to show in YAML editor, following the other K8s Gateway API types
+	K8sActualTCPRouteType = "TCPRoute"
+
+	K8sTLSRoutes    = "k8stlsroutes"
+	K8sTLSRouteType = "K8sTLSRoute"
+	// K8sActualTLSRouteType keeps the actual type to show in YAML editor, following the other K8s Gateway API types
+	K8sActualTLSRouteType = "TLSRoute"
+
+	K8sReferenceGrants    = "k8sreferencegrants"
+	K8sReferenceGrantType = "K8sReferenceGrant"
+	// K8sActualReferenceGrantType keeps the actual type to show in YAML editor, following the other K8s Gateway API types
+	K8sActualReferenceGrantType = "ReferenceGrant"
+
 	// Authorization PeerAuthentications
 	AuthorizationPolicies     = "authorizationpolicies"
 	AuthorizationPoliciesType = "AuthorizationPolicy"
@@ -143,8 +164,12 @@ var (
 		Telemetries:      TelemetryType,
 
 		// K8s Networking Gateways
-		K8sGateways:   K8sGatewayType,
-		K8sHTTPRoutes: K8sHTTPRouteType,
+		K8sGateways:        K8sGatewayType,
+		K8sGRPCRoutes:      K8sGRPCRouteType,
+		K8sHTTPRoutes:      K8sHTTPRouteType,
+		K8sReferenceGrants: K8sReferenceGrantType,
+		K8sTCPRoutes:       K8sTCPRouteType,
+		K8sTLSRoutes:       K8sTLSRouteType,
 
 		// Security
 		AuthorizationPolicies:  AuthorizationPoliciesType,
@@ -164,8 +189,12 @@ var (
 		WasmPlugins:      ExtensionGroupVersionV1Alpha1.Group,
 		Telemetries:      TelemetryGroupV1Alpha1.Group,
 
-		K8sGateways:   K8sNetworkingGroupVersionV1Beta1.Group,
-		K8sHTTPRoutes: K8sNetworkingGroupVersionV1Beta1.Group,
+		K8sGateways:        K8sNetworkingGroupVersionV1Beta1.Group,
+		K8sGRPCRoutes:      K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sHTTPRoutes:      K8sNetworkingGroupVersionV1Beta1.Group,
+		K8sReferenceGrants: K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sTCPRoutes:       K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sTLSRoutes:       K8sNetworkingGroupVersionV1Alpha2.Group,
 
 		AuthorizationPolicies:  SecurityGroupVersion.Group,
 		PeerAuthentications:    SecurityGroupVersion.Group,
@@ -174,9 +203,19 @@ var (
 )
 
 type IstioMeshConfig struct {
-	DisableMixerHttpReports bool                    `yaml:"disableMixerHttpReports,omitempty"`
-	DiscoverySelectors      []*metav1.LabelSelector `yaml:"discoverySelectors,omitempty"`
-	EnableAutoMtls          *bool                   `yaml:"enableAutoMtls,omitempty"`
+	DisableMixerHttpReports bool                     `yaml:"disableMixerHttpReports,omitempty"`
+	DiscoverySelectors      []*metav1.LabelSelector  `yaml:"discoverySelectors,omitempty"`
+	EnableAutoMtls          *bool                    `yaml:"enableAutoMtls,omitempty"`
+	ExtensionProviders      []IstioExtensionProvider `yaml:"extensionProviders,omitempty"`
+	OutboundTrafficPolicy   struct {
+		Mode string `yaml:"mode,omitempty"`
+	} `yaml:"outboundTrafficPolicy,omitempty"`
+	TrustDomain string `yaml:"trustDomain,omitempty"`
+}
+
+// IstioExtensionProvider is a mesh config extension provider, referenced by name from the Telemetry API
+type IstioExtensionProvider struct {
+	Name string `yaml:"name"`
 }
 
 // MTLSDetails is a wrapper to group all Istio objects related to non-local mTLS configurations
@@ -229,8 +268,12 @@ type RegistryConfiguration struct {
 	Telemetries      []*v1alpha1.Telemetry
 
 	// K8s Networking Gateways
-	K8sGateways   []*k8s_networking_v1beta1.Gateway
-	K8sHTTPRoutes []*k8s_networking_v1beta1.HTTPRoute
+	K8sGateways        []*k8s_networking_v1beta1.Gateway
+	K8sGRPCRoutes      []*k8s_networking_v1alpha2.GRPCRoute
+	K8sHTTPRoutes      []*k8s_networking_v1beta1.HTTPRoute
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
+	K8sTCPRoutes       []*k8s_networking_v1alpha2.TCPRoute
+	K8sTLSRoutes       []*k8s_networking_v1alpha2.TLSRoute
 
 	// Security
 	AuthorizationPolicies  []*security_v1beta.AuthorizationPolicy
@@ -338,6 +381,15 @@ type RegistryStatus struct {
 	Services      []*RegistryService
 }
 
+// GetExtensionProviderNames returns the names of the extension providers defined in the mesh config
+func (imc IstioMeshConfig) GetExtensionProviderNames() []string {
+	names := make([]string, 0, len(imc.ExtensionProviders))
+	for _, provider := range imc.ExtensionProviders {
+		names = append(names, provider.Name)
+	}
+	return names
+}
+
 func (imc IstioMeshConfig) GetEnableAutoMtls() bool {
 	if imc.EnableAutoMtls == nil {
 		return true
@@ -345,6 +397,22 @@ func (imc IstioMeshConfig) GetEnableAutoMtls() bool {
 	return *imc.EnableAutoMtls
 }
 
+// GetOutboundTrafficPolicyMode returns the mode of the mesh outbound traffic policy, Istio defaults it to ALLOW_ANY
+func (imc IstioMeshConfig) GetOutboundTrafficPolicyMode() string {
+	if imc.OutboundTrafficPolicy.Mode == "" {
+		return "ALLOW_ANY"
+	}
+	return imc.OutboundTrafficPolicy.Mode
+}
+
+// GetTrustDomain returns the mesh trust domain, Istio defaults it to cluster.local
+func (imc IstioMeshConfig) GetTrustDomain() string {
+	if imc.TrustDomain == "" {
+		return "cluster.local"
+	}
+	return imc.TrustDomain
+}
+
 func GetPatchType(patchType string) types.PatchType {
 	switch patchType {
 	case "json":
@@ -0,0 +1,57 @@
+package models
+
+// AccessRequest describes a request to a workload, evaluated against the AuthorizationPolicies applying to it
+type AccessRequest struct {
+	// Source namespace, used to build the source principal when it isn't provided
+	SourceNamespace string `json:"sourceNamespace"`
+	// Source service account, used to build the source principal when it isn't provided
+	SourceServiceAccount string `json:"sourceServiceAccount"`
+	// Source principal (e.g. cluster.local/ns/bookinfo/sa/default)
+	SourcePrincipal string `json:"sourcePrincipal"`
+	// Request principal, as set by the RequestAuthentication of the destination (<iss>/<sub>)
+	RequestPrincipal string `json:"requestPrincipal"`
+	// Destination namespace
+	Namespace string `json:"namespace"`
+	// Destination workload
+	Workload string `json:"workload"`
+	// Destination host
+	Host string `json:"host"`
+	// HTTP method
+	Method string `json:"method"`
+	// HTTP path
+	Path string `json:"path"`
+	// Destination port
+	Port string `json:"port"`
+}
+
+// AccessDecision is the result of evaluating the AuthorizationPolicies for an AccessRequest
+type AccessDecision struct {
+	// Decision for the request: ALLOW, DENY
+	// required: true
+	// example: DENY
+	Decision string `json:"decision"`
+	// Human readable explanation of the decision
+	// required: true
+	Reason string `json:"reason"`
+	// True when the request is also subject to an external authorizer (CUSTOM action), which may deny it
+	Delegated bool `json:"delegated"`
+	// The policies, and their rules, that produced the decision
+	Policies []AccessPolicyMatch `json:"policies"`
+	// The policies applying to the destination workload, in evaluation order
+	Evaluated []AccessPolicyMatch `json:"evaluated"`
+	// Conditions that couldn't be evaluated with the request data. They were considered matching for DENY and CUSTOM
+	// policies, and not matching for ALLOW policies.
+	Warnings []string `json:"warnings"`
+}
+
+// AccessPolicyMatch references an AuthorizationPolicy, and the rule that matched the request, if any
+type AccessPolicyMatch struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// AuthorizationPolicy action: ALLOW, DENY, CUSTOM
+	Action string `json:"action"`
+	// External authorizer, for the CUSTOM action
+	Provider string `json:"provider,omitempty"`
+	// Index of the matching rule in spec/rules, or -1 when no rule matches
+	Rule int `json:"rule"`
+}
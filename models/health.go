@@ -1,6 +1,8 @@
 package models
 
 import (
+	"math"
+
 	"github.com/prometheus/common/model"
 
 	"github.com/kiali/kiali/log"
@@ -17,13 +19,15 @@ type NamespaceWorkloadHealth map[string]*WorkloadHealth
 
 // ServiceHealth contains aggregated health from various sources, for a given service
 type ServiceHealth struct {
-	Requests RequestHealth `json:"requests"`
+	Requests RequestHealth  `json:"requests"`
+	Status   *HealthVerdict `json:"status,omitempty"`
 }
 
 // AppHealth contains aggregated health from various sources, for a given app
 type AppHealth struct {
 	WorkloadStatuses []*WorkloadStatus `json:"workloadStatuses"`
 	Requests         RequestHealth     `json:"requests"`
+	Status           *HealthVerdict    `json:"status,omitempty"`
 }
 
 func NewEmptyRequestHealth() RequestHealth {
@@ -63,6 +67,7 @@ func EmptyWorkloadHealth() *WorkloadHealth {
 type WorkloadHealth struct {
 	WorkloadStatus *WorkloadStatus `json:"workloadStatus"`
 	Requests       RequestHealth   `json:"requests"`
+	Status         *HealthVerdict  `json:"status,omitempty"`
 }
 
 // WorkloadStatus gives
@@ -95,9 +100,14 @@ type ProxyStatus struct {
 // RequestHealth holds several stats about recent request errors
 // - Inbound//Outbound are the rates of requests by protocol and status_code.
 // Example:   Inbound: { "http": {"200": 1.5, "400": 2.3}, "grpc": {"1": 1.2} }
+// - InboundLatency//OutboundLatency are the response times in milliseconds by protocol and quantile, only fetched
+// for the quantiles of the latency tolerances.
+// Example:   InboundLatency: { "http": {"0.95": 120.5, "0.99": 480} }
 type RequestHealth struct {
 	Inbound            map[string]map[string]float64 `json:"inbound"`
 	Outbound           map[string]map[string]float64 `json:"outbound"`
+	InboundLatency     map[string]map[string]float64 `json:"inboundLatency,omitempty"`
+	OutboundLatency    map[string]map[string]float64 `json:"outboundLatency,omitempty"`
 	HealthAnnotations  map[string]string             `json:"healthAnnotations"`
 	inboundSource      map[string]map[string]float64
 	inboundDestination map[string]map[string]float64
@@ -162,6 +172,34 @@ func (in *RequestHealth) CombineReporters() {
 	}
 }
 
+// AddInboundLatency stores the response time of the quantile, from a sample grouped by request_protocol
+func (in *RequestHealth) AddInboundLatency(quantile string, sample *model.Sample) {
+	if in.InboundLatency == nil {
+		in.InboundLatency = make(map[string]map[string]float64)
+	}
+	addLatency(quantile, sample, in.InboundLatency)
+}
+
+// AddOutboundLatency stores the response time of the quantile, from a sample grouped by request_protocol
+func (in *RequestHealth) AddOutboundLatency(quantile string, sample *model.Sample) {
+	if in.OutboundLatency == nil {
+		in.OutboundLatency = make(map[string]map[string]float64)
+	}
+	addLatency(quantile, sample, in.OutboundLatency)
+}
+
+func addLatency(quantile string, sample *model.Sample, latencies map[string]map[string]float64) {
+	// histogram_quantile returns NaN without requests
+	if math.IsNaN(float64(sample.Value)) {
+		return
+	}
+	protocol := string(sample.Metric["request_protocol"])
+	if _, ok := latencies[protocol]; !ok {
+		latencies[protocol] = make(map[string]float64)
+	}
+	latencies[protocol][quantile] = float64(sample.Value)
+}
+
 func aggregate(sample *model.Sample, requests map[string]map[string]float64) {
 	code := string(sample.Metric["response_code"])
 	protocol := string(sample.Metric["request_protocol"])
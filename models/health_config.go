@@ -4,12 +4,14 @@ package models
 type AnnotationKey string
 
 const (
-	AllHealthAnnotation  AnnotationKey = ".*"
-	RateHealthAnnotation AnnotationKey = "health.kiali.io/rate"
+	AllHealthAnnotation     AnnotationKey = ".*"
+	LatencyHealthAnnotation AnnotationKey = "health.kiali.io/latency"
+	RateHealthAnnotation    AnnotationKey = "health.kiali.io/rate"
+	SLOHealthAnnotation     AnnotationKey = "health.kiali.io/slo"
 )
 
 func GetHealthConfigAnnotation() []AnnotationKey {
-	return []AnnotationKey{RateHealthAnnotation}
+	return []AnnotationKey{LatencyHealthAnnotation, RateHealthAnnotation, SLOHealthAnnotation}
 }
 
 func GetHealthAnnotation(annotations map[string]string, filters []AnnotationKey) map[string]string {
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// NamespaceHealthHistory is a map of app, service or workload name x health history
+type NamespaceHealthHistory map[string]HealthHistory
+
+// HealthHistory is the list of the health intervals of an item, sorted by time
+type HealthHistory []HealthInterval
+
+// HealthInterval is the health status of an item from Start to End. The reasons are the ones of the status at Start,
+// when the item entered this status.
+type HealthInterval struct {
+	Start   time.Time      `json:"start"`
+	End     time.Time      `json:"end"`
+	Status  HealthStatus   `json:"status"`
+	Reasons []HealthReason `json:"reasons"`
+}
+
+// Add appends the health status evaluated at the given time, the last interval is extended up to this time and a new
+// interval starts when the status changes
+func (in HealthHistory) Add(t time.Time, verdict *HealthVerdict) HealthHistory {
+	if len(in) > 0 {
+		last := &in[len(in)-1]
+		last.End = t
+		if last.Status == verdict.Status {
+			return in
+		}
+	}
+	return append(in, HealthInterval{Start: t, End: t, Status: verdict.Status, Reasons: verdict.Reasons})
+}
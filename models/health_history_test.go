@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHistoryAdd(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	degraded := &HealthVerdict{Status: HealthStatusDegraded, Reasons: []HealthReason{{Status: HealthStatusDegraded, Message: "inbound http 5XX error rate 2.00% >= 0.1%"}}}
+
+	history := HealthHistory{}
+	history = history.Add(start, &HealthVerdict{Status: HealthStatusHealthy})
+	history = history.Add(start.Add(time.Minute), &HealthVerdict{Status: HealthStatusHealthy})
+	history = history.Add(start.Add(2*time.Minute), degraded)
+	history = history.Add(start.Add(3*time.Minute), &HealthVerdict{Status: HealthStatusDegraded})
+
+	if assert.Len(history, 2) {
+		assert.Equal(HealthInterval{Start: start, End: start.Add(2 * time.Minute), Status: HealthStatusHealthy}, history[0])
+		// The reasons are the ones of the first step of the interval
+		assert.Equal(HealthInterval{Start: start.Add(2 * time.Minute), End: start.Add(3 * time.Minute), Status: HealthStatusDegraded, Reasons: degraded.Reasons}, history[1])
+	}
+}
@@ -0,0 +1,428 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+)
+
+// HealthStatus is the verdict of the health of an app, a service or a workload
+type HealthStatus string
+
+const (
+	HealthStatusNA       HealthStatus = "NA"
+	HealthStatusHealthy  HealthStatus = "Healthy"
+	HealthStatusNotReady HealthStatus = "Not Ready"
+	HealthStatusDegraded HealthStatus = "Degraded"
+	HealthStatusFailure  HealthStatus = "Failure"
+)
+
+// Health kinds used to select the rate configuration, as in the kind field of the health_config rates
+const (
+	HealthKindApp      = "app"
+	HealthKindService  = "service"
+	HealthKindWorkload = "workload"
+)
+
+var healthStatusPriority = map[HealthStatus]int{
+	HealthStatusNA:       0,
+	HealthStatusHealthy:  1,
+	HealthStatusNotReady: 2,
+	HealthStatusDegraded: 3,
+	HealthStatusFailure:  4,
+}
+
+// Merge returns the status with more priority
+func (s HealthStatus) Merge(other HealthStatus) HealthStatus {
+	if healthStatusPriority[other] > healthStatusPriority[s] {
+		return other
+	}
+	return s
+}
+
+// HealthReason explains why a health check resulted in a non healthy status.
+// The request reasons set the protocol, the direction and the code of the tolerance crossed, the latency reasons
+// set the quantile instead of the code, the SLO reasons set the SLO with its error budget exhausted, the workload
+// reasons set the workload with the replicas or proxies in a bad condition.
+type HealthReason struct {
+	Status    HealthStatus `json:"status"`
+	Message   string       `json:"message"`
+	Code      string       `json:"code,omitempty"`
+	Direction string       `json:"direction,omitempty"`
+	Protocol  string       `json:"protocol,omitempty"`
+	Quantile  string       `json:"quantile,omitempty"`
+	SLO       string       `json:"slo,omitempty"`
+	Threshold float64      `json:"threshold,omitempty"`
+	Value     float64      `json:"value,omitempty"`
+	Workload  string       `json:"workload,omitempty"`
+}
+
+// HealthVerdict is the final status of a health, with the reasons of the status when it is not healthy
+type HealthVerdict struct {
+	Status  HealthStatus   `json:"status"`
+	Reasons []HealthReason `json:"reasons"`
+}
+
+func newHealthVerdict() *HealthVerdict {
+	return &HealthVerdict{Status: HealthStatusNA, Reasons: []HealthReason{}}
+}
+
+func (in *HealthVerdict) merge(status HealthStatus, reasons []HealthReason) {
+	in.Status = in.Status.Merge(status)
+	in.Reasons = append(in.Reasons, reasons...)
+}
+
+// EvaluateStatus sets the status of the service health from its request error rates
+func (in *ServiceHealth) EvaluateStatus(namespace, name string) {
+	verdict := newHealthVerdict()
+	verdict.merge(in.Requests.Status(namespace, name, HealthKindService))
+	verdict.merge(in.Requests.LatencyStatus(namespace, name, HealthKindService))
+	in.Status = verdict
+}
+
+// EvaluateStatus sets the status of the app health from the statuses of its workloads and its request error rates
+func (in *AppHealth) EvaluateStatus(namespace, name string) {
+	verdict := newHealthVerdict()
+	for _, ws := range in.WorkloadStatuses {
+		verdict.merge(ws.Status())
+	}
+	verdict.merge(in.Requests.Status(namespace, name, HealthKindApp))
+	verdict.merge(in.Requests.LatencyStatus(namespace, name, HealthKindApp))
+	in.Status = verdict
+}
+
+// EvaluateStatus sets the status of the workload health from its replicas and its request error rates
+func (in *WorkloadHealth) EvaluateStatus(namespace, name string) {
+	verdict := newHealthVerdict()
+	if in.WorkloadStatus != nil {
+		verdict.merge(in.WorkloadStatus.Status())
+	}
+	verdict.merge(in.Requests.Status(namespace, name, HealthKindWorkload))
+	verdict.merge(in.Requests.LatencyStatus(namespace, name, HealthKindWorkload))
+	in.Status = verdict
+}
+
+// Status returns the status of the replicas and the proxies of the workload
+func (ws WorkloadStatus) Status() (HealthStatus, []HealthReason) {
+	reason := func(status HealthStatus, format string, args ...interface{}) (HealthStatus, []HealthReason) {
+		return status, []HealthReason{{Status: status, Message: ws.Name + ": " + fmt.Sprintf(format, args...), Workload: ws.Name}}
+	}
+
+	// User has scaled down the workload, it's not an error condition
+	if ws.DesiredReplicas == 0 {
+		return reason(HealthStatusNotReady, "scaled to 0 desired replicas")
+	}
+	// Some pods are available, but less than desired
+	if ws.CurrentReplicas > 0 && ws.AvailableReplicas > 0 &&
+		(ws.CurrentReplicas < ws.DesiredReplicas || ws.AvailableReplicas < ws.DesiredReplicas) {
+		return reason(HealthStatusDegraded, "%d / %d available replicas", ws.AvailableReplicas, ws.DesiredReplicas)
+	}
+	if ws.AvailableReplicas == 0 {
+		return reason(HealthStatusFailure, "no available replicas of %d desired", ws.DesiredReplicas)
+	}
+	// Pending pods
+	if ws.DesiredReplicas == ws.AvailableReplicas && ws.AvailableReplicas != ws.CurrentReplicas {
+		return reason(HealthStatusFailure, "%d current replicas for %d available, pods are pending", ws.CurrentReplicas, ws.AvailableReplicas)
+	}
+	// A negative value means that the workload has no proxies
+	if ws.SyncedProxies >= 0 && ws.SyncedProxies < ws.DesiredReplicas {
+		unsynced := ws.DesiredReplicas - ws.SyncedProxies
+		if unsynced == 1 {
+			return reason(HealthStatusDegraded, "1 proxy unsynced")
+		}
+		return reason(HealthStatusDegraded, "%d proxies unsynced", unsynced)
+	}
+	if ws.DesiredReplicas == ws.CurrentReplicas && ws.CurrentReplicas == ws.AvailableReplicas {
+		return HealthStatusHealthy, []HealthReason{}
+	}
+	return reason(HealthStatusDegraded, "%d desired, %d current and %d available replicas", ws.DesiredReplicas, ws.CurrentReplicas, ws.AvailableReplicas)
+}
+
+// Status returns the status of the request error rates, checked against the tolerances of the health.kiali.io/rate
+// annotation or, when it is not set or not valid, of the first rate of the health config matching the object.
+// Without requests the status is NA.
+func (in RequestHealth) Status(namespace, name, kind string) (HealthStatus, []HealthReason) {
+	tolerances := GetRateTolerances(namespace, name, kind, in.HealthAnnotations)
+
+	status := HealthStatusNA
+	reasons := []HealthReason{}
+	for _, direction := range []string{"inbound", "outbound"} {
+		requests := in.Inbound
+		if direction == "outbound" {
+			requests = in.Outbound
+		}
+		for _, tolerance := range tolerances {
+			if !matchesExpr(tolerance.Direction, direction, false) {
+				continue
+			}
+			for _, protocol := range sortedKeys(requests) {
+				if !matchesExpr(tolerance.Protocol, protocol, false) {
+					continue
+				}
+				requestRate, errorRate := 0.0, 0.0
+				for code, rate := range requests[protocol] {
+					requestRate += rate
+					if matchesExpr(tolerance.Code, code, true) {
+						errorRate += rate
+					}
+				}
+				if requestRate == 0 {
+					continue
+				}
+				value := 100 * errorRate / requestRate
+				rateStatus, threshold := toleranceStatus(value, tolerance)
+				status = status.Merge(rateStatus)
+				if rateStatus != HealthStatusHealthy {
+					reasons = append(reasons, HealthReason{
+						Status:    rateStatus,
+						Message:   fmt.Sprintf("%s %s %s error rate %.2f%% >= %g%%", direction, protocol, tolerance.Code, value, threshold),
+						Code:      tolerance.Code,
+						Direction: direction,
+						Protocol:  protocol,
+						Threshold: threshold,
+						Value:     value,
+					})
+				}
+			}
+		}
+	}
+	return status, reasons
+}
+
+// LatencyStatus returns the status of the response times, checked against the latency tolerances of the
+// health.kiali.io/latency annotation or of the health config. Without response times the status is NA.
+func (in RequestHealth) LatencyStatus(namespace, name, kind string) (HealthStatus, []HealthReason) {
+	tolerances := GetLatencyTolerances(namespace, name, kind, in.HealthAnnotations)
+
+	status := HealthStatusNA
+	reasons := []HealthReason{}
+	for _, direction := range []string{"inbound", "outbound"} {
+		latencies := in.InboundLatency
+		if direction == "outbound" {
+			latencies = in.OutboundLatency
+		}
+		for _, tolerance := range tolerances {
+			if !matchesExpr(tolerance.Direction, direction, false) {
+				continue
+			}
+			for _, protocol := range sortedKeys(latencies) {
+				if !matchesExpr(tolerance.Protocol, protocol, false) {
+					continue
+				}
+				value, ok := latencies[protocol][tolerance.Quantile]
+				if !ok {
+					continue
+				}
+				latencyStatus, threshold := latencyToleranceStatus(value, tolerance)
+				status = status.Merge(latencyStatus)
+				if latencyStatus != HealthStatusHealthy {
+					reasons = append(reasons, HealthReason{
+						Status:    latencyStatus,
+						Message:   fmt.Sprintf("%s %s p%s latency %.2fms >= %gms", direction, protocol, quantileName(tolerance.Quantile), value, threshold),
+						Direction: direction,
+						Protocol:  protocol,
+						Quantile:  tolerance.Quantile,
+						Threshold: threshold,
+						Value:     value,
+					})
+				}
+			}
+		}
+	}
+	return status, reasons
+}
+
+// latencyToleranceStatus returns the status of the response time, and the threshold crossed when it's not healthy
+func latencyToleranceStatus(value float64, tolerance config.LatencyTolerance) (HealthStatus, float64) {
+	if tolerance.Failure > 0 && value >= float64(tolerance.Failure) {
+		return HealthStatusFailure, float64(tolerance.Failure)
+	}
+	if tolerance.Degraded > 0 && value >= float64(tolerance.Degraded) {
+		return HealthStatusDegraded, float64(tolerance.Degraded)
+	}
+	return HealthStatusHealthy, 0
+}
+
+// quantileName returns the percentile of the quantile, i.e. 95 for 0.95
+func quantileName(quantile string) string {
+	q, err := strconv.ParseFloat(quantile, 64)
+	if err != nil {
+		return quantile
+	}
+	return strconv.FormatFloat(q*100, 'f', -1, 64)
+}
+
+// toleranceStatus returns the status of the error rate percentage, and the threshold crossed when it's not healthy
+func toleranceStatus(value float64, tolerance config.Tolerance) (HealthStatus, float64) {
+	if value > 0 {
+		if value >= float64(tolerance.Failure) {
+			return HealthStatusFailure, float64(tolerance.Failure)
+		}
+		if value >= float64(tolerance.Degraded) {
+			return HealthStatusDegraded, float64(tolerance.Degraded)
+		}
+	}
+	return HealthStatusHealthy, 0
+}
+
+// GetRateTolerances returns the tolerances defined in the health.kiali.io/rate annotation, or the tolerances of the
+// first rate of the health config matching the namespace, the name and the kind of the object. The last rate of the
+// config holds the Kiali defaults.
+func GetRateTolerances(namespace, name, kind string, annotations map[string]string) []config.Tolerance {
+	if annotation, ok := annotations[string(RateHealthAnnotation)]; ok && annotation != "" {
+		tolerances, err := ParseRateHealthAnnotation(annotation)
+		if err == nil {
+			return tolerances
+		}
+		log.Debugf("Ignoring the %s annotation of %s [%s/%s]: %s", RateHealthAnnotation, kind, namespace, name, err)
+	}
+
+	rates := config.Get().HealthConfig.Rate
+	for _, rate := range rates {
+		if matchesExpr(rate.Namespace, namespace, false) && matchesExpr(rate.Name, name, false) && matchesExpr(rate.Kind, kind, false) {
+			return rate.Tolerance
+		}
+	}
+	if len(rates) > 0 {
+		return rates[len(rates)-1].Tolerance
+	}
+	return []config.Tolerance{}
+}
+
+// GetLatencyTolerances returns the latency tolerances defined in the health.kiali.io/latency annotation, or the
+// tolerances of the first latency of the health config matching the namespace, the name and the kind of the object.
+// There are no latency tolerances by default.
+func GetLatencyTolerances(namespace, name, kind string, annotations map[string]string) []config.LatencyTolerance {
+	if annotation, ok := annotations[string(LatencyHealthAnnotation)]; ok && annotation != "" {
+		tolerances, err := ParseLatencyHealthAnnotation(annotation)
+		if err == nil {
+			return tolerances
+		}
+		log.Debugf("Ignoring the %s annotation of %s [%s/%s]: %s", LatencyHealthAnnotation, kind, namespace, name, err)
+	}
+
+	for _, latency := range config.Get().HealthConfig.Latency {
+		if matchesExpr(latency.Namespace, namespace, false) && matchesExpr(latency.Name, name, false) && matchesExpr(latency.Kind, kind, false) {
+			return latency.Tolerance
+		}
+	}
+	return []config.LatencyTolerance{}
+}
+
+// ParseLatencyHealthAnnotation parses the tolerances of a health.kiali.io/latency annotation,
+// i.e. "0.95,200,500,http,inbound;0.99,1000,2000,grpc,.*"
+func ParseLatencyHealthAnnotation(annotation string) ([]config.LatencyTolerance, error) {
+	tolerances := []config.LatencyTolerance{}
+	for _, value := range strings.Split(annotation, ";") {
+		fields := strings.Split(value, ",")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("tolerance [%s] must have the format quantile,degraded,failure,protocol,direction", value)
+		}
+		if q, err := strconv.ParseFloat(fields[0], 64); err != nil || q <= 0 || q >= 1 {
+			return nil, fmt.Errorf("quantile of tolerance [%s] must be a number between 0 and 1", value)
+		}
+		degraded, failure, err := parseThresholds(value, fields[1], fields[2])
+		if err != nil {
+			return nil, err
+		}
+		tolerances = append(tolerances, config.LatencyTolerance{
+			Quantile:  fields[0],
+			Degraded:  degraded,
+			Failure:   failure,
+			Protocol:  fields[3],
+			Direction: fields[4],
+		})
+	}
+	return tolerances, nil
+}
+
+// LatencyQuantiles returns the distinct quantiles of the tolerances
+func LatencyQuantiles(tolerances []config.LatencyTolerance) []string {
+	quantiles := []string{}
+	seen := map[string]bool{}
+	for _, tolerance := range tolerances {
+		if tolerance.Quantile != "" && !seen[tolerance.Quantile] {
+			seen[tolerance.Quantile] = true
+			quantiles = append(quantiles, tolerance.Quantile)
+		}
+	}
+	return quantiles
+}
+
+// ParseRateHealthAnnotation parses the tolerances of a health.kiali.io/rate annotation,
+// i.e. "4XX,10,20,http,inbound;5XX,5,10,http,.*"
+func ParseRateHealthAnnotation(annotation string) ([]config.Tolerance, error) {
+	tolerances := []config.Tolerance{}
+	for _, value := range strings.Split(annotation, ";") {
+		fields := strings.Split(value, ",")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("tolerance [%s] must have the format code,degraded,failure,protocol,direction", value)
+		}
+		degraded, failure, err := parseThresholds(value, fields[1], fields[2])
+		if err != nil {
+			return nil, err
+		}
+		tolerances = append(tolerances, config.Tolerance{
+			Code:      fields[0],
+			Degraded:  degraded,
+			Failure:   failure,
+			Protocol:  fields[3],
+			Direction: fields[4],
+		})
+	}
+	return tolerances, nil
+}
+
+func parseThresholds(tolerance, degradedValue, failureValue string) (float32, float32, error) {
+	degraded, err := strconv.ParseFloat(degradedValue, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("degraded threshold of tolerance [%s] is not a number", tolerance)
+	}
+	failure, err := strconv.ParseFloat(failureValue, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failure threshold of tolerance [%s] is not a number", tolerance)
+	}
+	if degraded > failure {
+		return 0, 0, fmt.Errorf("degraded threshold of tolerance [%s] is greater than the failure threshold", tolerance)
+	}
+	return float32(degraded), float32(failure), nil
+}
+
+// healthExprs caches the regular expressions of the health config, they are evaluated for every health
+var healthExprs sync.Map
+
+// matchesExpr returns true when the value matches the regular expression of the health config. Empty expressions
+// match any value, invalid ones don't match. The X in the codes are any digit, i.e. 5XX.
+func matchesExpr(expr, value string, code bool) bool {
+	if expr == "" {
+		return true
+	}
+	if code {
+		expr = strings.NewReplacer("x", `\d`, "X", `\d`).Replace(expr)
+	}
+	if re, ok := healthExprs.Load(expr); ok {
+		return re.(*regexp.Regexp).MatchString(value)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		log.Debugf("Invalid expression [%s] in the health config: %s", expr, err)
+		return false
+	}
+	healthExprs.Store(expr, re)
+	return re.MatchString(value)
+}
+
+func sortedKeys(requests map[string]map[string]float64) []string {
+	keys := make([]string, 0, len(requests))
+	for key := range requests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+)
+
+func TestWorkloadStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := map[string]struct {
+		workloadStatus WorkloadStatus
+		status         HealthStatus
+		message        string
+	}{
+		"healthy":          {WorkloadStatus{Name: "reviews-v1", DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 2, SyncedProxies: 2}, HealthStatusHealthy, ""},
+		"without proxies":  {WorkloadStatus{Name: "reviews-v1", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: -1}, HealthStatusHealthy, ""},
+		"scaled down":      {WorkloadStatus{Name: "reviews-v1"}, HealthStatusNotReady, "reviews-v1: scaled to 0 desired replicas"},
+		"partially ready":  {WorkloadStatus{Name: "reviews-v1", DesiredReplicas: 3, CurrentReplicas: 3, AvailableReplicas: 1, SyncedProxies: 1}, HealthStatusDegraded, "reviews-v1: 1 / 3 available replicas"},
+		"not available":    {WorkloadStatus{Name: "reviews-v1", DesiredReplicas: 2, CurrentReplicas: 2, SyncedProxies: 0}, HealthStatusFailure, "reviews-v1: no available replicas of 2 desired"},
+		"pending pods":     {WorkloadStatus{Name: "reviews-v1", DesiredReplicas: 1, CurrentReplicas: 2, AvailableReplicas: 1, SyncedProxies: 1}, HealthStatusFailure, "reviews-v1: 2 current replicas for 1 available, pods are pending"},
+		"unsynced proxies": {WorkloadStatus{Name: "reviews-v1", DesiredReplicas: 3, CurrentReplicas: 3, AvailableReplicas: 3, SyncedProxies: 1}, HealthStatusDegraded, "reviews-v1: 2 proxies unsynced"},
+	}
+
+	for name, c := range cases {
+		status, reasons := c.workloadStatus.Status()
+		assert.Equal(c.status, status, name)
+		if c.message == "" {
+			assert.Empty(reasons, name)
+		} else if assert.Len(reasons, 1, name) {
+			assert.Equal(c.message, reasons[0].Message, name)
+			assert.Equal("reviews-v1", reasons[0].Workload, name)
+		}
+	}
+}
+
+func TestRequestHealthStatus(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+	require := require.New(t)
+
+	requests := NewEmptyRequestHealth()
+	status, reasons := requests.Status("bookinfo", "reviews", HealthKindService)
+	assert.Equal(HealthStatusNA, status)
+	assert.Empty(reasons)
+
+	requests.Inbound = map[string]map[string]float64{"http": {"200": 80, "404": 15, "503": 5}}
+	status, reasons = requests.Status("bookinfo", "reviews", HealthKindService)
+	assert.Equal(HealthStatusDegraded, status)
+	require.Len(reasons, 2)
+	assert.Equal(HealthReason{Status: HealthStatusDegraded, Message: "inbound http 5XX error rate 5.00% >= 0%", Code: "5XX", Direction: "inbound", Protocol: "http", Value: 5}, reasons[0])
+	assert.Equal(HealthReason{Status: HealthStatusDegraded, Message: "inbound http 4XX error rate 15.00% >= 10%", Code: "4XX", Direction: "inbound", Protocol: "http", Threshold: 10, Value: 15}, reasons[1])
+
+	requests.Outbound = map[string]map[string]float64{"grpc": {"0": 1, "14": 1}}
+	status, reasons = requests.Status("bookinfo", "reviews", HealthKindService)
+	assert.Equal(HealthStatusFailure, status)
+	require.Len(reasons, 3)
+	assert.Equal("outbound", reasons[2].Direction)
+	assert.Equal("grpc", reasons[2].Protocol)
+}
+
+func TestRequestHealthStatusAnnotation(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	requests := NewEmptyRequestHealth()
+	requests.Inbound = map[string]map[string]float64{"http": {"200": 80, "404": 20}}
+	requests.Outbound = map[string]map[string]float64{"http": {"200": 50, "500": 50}}
+
+	// the annotation only applies to inbound requests
+	requests.HealthAnnotations = map[string]string{string(RateHealthAnnotation): "4xx,30,40,http,inbound"}
+	status, reasons := requests.Status("bookinfo", "reviews", HealthKindWorkload)
+	assert.Equal(HealthStatusHealthy, status)
+	assert.Empty(reasons)
+
+	requests.HealthAnnotations = map[string]string{string(RateHealthAnnotation): "4xx,10,20,http,inbound"}
+	status, _ = requests.Status("bookinfo", "reviews", HealthKindWorkload)
+	assert.Equal(HealthStatusFailure, status)
+
+	// invalid annotations are ignored
+	requests.HealthAnnotations = map[string]string{string(RateHealthAnnotation): "4xx,40,30,http,inbound"}
+	status, reasons = requests.Status("bookinfo", "reviews", HealthKindWorkload)
+	assert.Equal(HealthStatusFailure, status)
+	assert.Len(reasons, 2)
+}
+
+func TestGetRateTolerances(t *testing.T) {
+	conf := config.NewConfig()
+	conf.HealthConfig.Rate = []config.Rate{
+		{Namespace: "bookinfo", Kind: "service", Tolerance: []config.Tolerance{{Code: "5XX", Failure: 50, Protocol: "http", Direction: ".*"}}},
+	}
+	config.Set(conf)
+	assert := assert.New(t)
+
+	tolerances := GetRateTolerances("bookinfo", "reviews", HealthKindService, nil)
+	assert.Equal(conf.HealthConfig.Rate[0].Tolerance, tolerances)
+
+	// the Kiali defaults are used when no rate matches
+	tolerances = GetRateTolerances("bookinfo", "reviews", HealthKindWorkload, nil)
+	assert.Len(tolerances, 4)
+
+	_, err := ParseRateHealthAnnotation("4xx,10,20,http")
+	assert.Error(err)
+	_, err = ParseRateHealthAnnotation("4xx,ten,20,http,inbound")
+	assert.Error(err)
+	tolerances, err = ParseRateHealthAnnotation("4xx,10,20,http,inbound;5xx,5,10,http|grpc,.*")
+	assert.NoError(err)
+	assert.Equal([]config.Tolerance{
+		{Code: "4xx", Degraded: 10, Failure: 20, Protocol: "http", Direction: "inbound"},
+		{Code: "5xx", Degraded: 5, Failure: 10, Protocol: "http|grpc", Direction: ".*"},
+	}, tolerances)
+}
+
+func TestRequestHealthLatencyStatus(t *testing.T) {
+	conf := config.NewConfig()
+	conf.HealthConfig.Latency = []config.Latency{
+		{Kind: "workload", Tolerance: []config.LatencyTolerance{
+			{Quantile: "0.95", Degraded: 200, Failure: 500, Protocol: "http", Direction: ".*"},
+			{Quantile: "0.99", Failure: 1000, Protocol: "grpc", Direction: "outbound"},
+		}},
+	}
+	config.Set(conf)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	requests := NewEmptyRequestHealth()
+	status, reasons := requests.LatencyStatus("bookinfo", "reviews-v1", HealthKindWorkload)
+	assert.Equal(HealthStatusNA, status)
+	assert.Empty(reasons)
+
+	requests.InboundLatency = map[string]map[string]float64{"http": {"0.95": 150}}
+	requests.OutboundLatency = map[string]map[string]float64{"http": {"0.95": 250}, "grpc": {"0.99": 1200}}
+	status, reasons = requests.LatencyStatus("bookinfo", "reviews-v1", HealthKindWorkload)
+	assert.Equal(HealthStatusFailure, status)
+	require.Len(reasons, 2)
+	assert.Equal(HealthReason{Status: HealthStatusDegraded, Message: "outbound http p95 latency 250.00ms >= 200ms", Direction: "outbound", Protocol: "http", Quantile: "0.95", Threshold: 200, Value: 250}, reasons[0])
+	assert.Equal(HealthReason{Status: HealthStatusFailure, Message: "outbound grpc p99 latency 1200.00ms >= 1000ms", Direction: "outbound", Protocol: "grpc", Quantile: "0.99", Threshold: 1000, Value: 1200}, reasons[1])
+
+	// the config doesn't apply to services, and there are no latency tolerances by default
+	status, _ = requests.LatencyStatus("bookinfo", "reviews", HealthKindService)
+	assert.Equal(HealthStatusNA, status)
+
+	requests.HealthAnnotations = map[string]string{string(LatencyHealthAnnotation): "0.95,100,200,http,inbound"}
+	status, reasons = requests.LatencyStatus("bookinfo", "reviews", HealthKindService)
+	assert.Equal(HealthStatusDegraded, status)
+	require.Len(reasons, 1)
+	assert.Equal("inbound", reasons[0].Direction)
+
+	// the latency tolerances are merged with the rates in the health status
+	health := WorkloadHealth{WorkloadStatus: &WorkloadStatus{Name: "reviews-v1", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: 1}, Requests: requests}
+	health.EvaluateStatus("bookinfo", "reviews-v1")
+	assert.Equal(HealthStatusDegraded, health.Status.Status)
+}
+
+func TestParseLatencyHealthAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	tolerances, err := ParseLatencyHealthAnnotation("0.95,200,500,http,inbound;0.99,0,1000,grpc,.*")
+	assert.NoError(err)
+	assert.Equal([]config.LatencyTolerance{
+		{Quantile: "0.95", Degraded: 200, Failure: 500, Protocol: "http", Direction: "inbound"},
+		{Quantile: "0.99", Failure: 1000, Protocol: "grpc", Direction: ".*"},
+	}, tolerances)
+	assert.Equal([]string{"0.95", "0.99"}, LatencyQuantiles(append(tolerances, tolerances...)))
+
+	_, err = ParseLatencyHealthAnnotation("95,200,500,http,inbound")
+	assert.Error(err)
+	_, err = ParseLatencyHealthAnnotation("0.95,500,200,http,inbound")
+	assert.Error(err)
+}
@@ -6,7 +6,11 @@ import (
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
 )
 
 // IstioConfigList istioConfigList
@@ -29,8 +33,12 @@ type IstioConfigList struct {
 	WasmPlugins      []*extentions_v1alpha1.WasmPlugin     `json:"wasmPlugins"`
 	Telemetries      []*v1alpha1.Telemetry                 `json:"telemetries"`
 
-	K8sGateways   []*k8s_networking_v1beta1.Gateway   `json:"k8sGateways"`
-	K8sHTTPRoutes []*k8s_networking_v1beta1.HTTPRoute `json:"k8sHTTPRoutes"`
+	K8sGateways        []*k8s_networking_v1beta1.Gateway         `json:"k8sGateways"`
+	K8sGRPCRoutes      []*k8s_networking_v1alpha2.GRPCRoute      `json:"k8sGRPCRoutes"`
+	K8sHTTPRoutes      []*k8s_networking_v1beta1.HTTPRoute       `json:"k8sHTTPRoutes"`
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant `json:"k8sReferenceGrants"`
+	K8sTCPRoutes       []*k8s_networking_v1alpha2.TCPRoute       `json:"k8sTCPRoutes"`
+	K8sTLSRoutes       []*k8s_networking_v1alpha2.TLSRoute       `json:"k8sTLSRoutes"`
 
 	AuthorizationPolicies  []*security_v1beta.AuthorizationPolicy   `json:"authorizationPolicies"`
 	PeerAuthentications    []*security_v1beta.PeerAuthentication    `json:"peerAuthentications"`
@@ -56,8 +64,12 @@ type IstioConfigDetails struct {
 	WasmPlugin            *extentions_v1alpha1.WasmPlugin        `json:"wasmPlugin"`
 	Telemetry             *v1alpha1.Telemetry                    `json:"telemetry"`
 
-	K8sGateway   *k8s_networking_v1beta1.Gateway   `json:"k8sGateway"`
-	K8sHTTPRoute *k8s_networking_v1beta1.HTTPRoute `json:"k8sHTTPRoute"`
+	K8sGateway        *k8s_networking_v1beta1.Gateway         `json:"k8sGateway"`
+	K8sGRPCRoute      *k8s_networking_v1alpha2.GRPCRoute      `json:"k8sGRPCRoute"`
+	K8sHTTPRoute      *k8s_networking_v1beta1.HTTPRoute       `json:"k8sHTTPRoute"`
+	K8sReferenceGrant *k8s_networking_v1alpha2.ReferenceGrant `json:"k8sReferenceGrant"`
+	K8sTCPRoute       *k8s_networking_v1alpha2.TCPRoute       `json:"k8sTCPRoute"`
+	K8sTLSRoute       *k8s_networking_v1alpha2.TLSRoute       `json:"k8sTLSRoute"`
 
 	Permissions           ResourcePermissions `json:"permissions"`
 	IstioValidation       *IstioValidation    `json:"validation"`
@@ -190,6 +202,89 @@ type IstioConfigPermissions map[string]*ResourcesPermissions
 // IstioConfigs holds a map of IstioConfigList per namespace
 type IstioConfigs map[string]*IstioConfigList
 
+// IstioObject returns the Istio object of the config details, or nil when it is not set
+func (istioConfigDetail IstioConfigDetails) IstioObject() meta_v1.Object {
+	switch istioConfigDetail.ObjectType {
+	case kubernetes.AuthorizationPolicies:
+		if istioConfigDetail.AuthorizationPolicy != nil {
+			return istioConfigDetail.AuthorizationPolicy
+		}
+	case kubernetes.DestinationRules:
+		if istioConfigDetail.DestinationRule != nil {
+			return istioConfigDetail.DestinationRule
+		}
+	case kubernetes.EnvoyFilters:
+		if istioConfigDetail.EnvoyFilter != nil {
+			return istioConfigDetail.EnvoyFilter
+		}
+	case kubernetes.Gateways:
+		if istioConfigDetail.Gateway != nil {
+			return istioConfigDetail.Gateway
+		}
+	case kubernetes.K8sGateways:
+		if istioConfigDetail.K8sGateway != nil {
+			return istioConfigDetail.K8sGateway
+		}
+	case kubernetes.K8sGRPCRoutes:
+		if istioConfigDetail.K8sGRPCRoute != nil {
+			return istioConfigDetail.K8sGRPCRoute
+		}
+	case kubernetes.K8sHTTPRoutes:
+		if istioConfigDetail.K8sHTTPRoute != nil {
+			return istioConfigDetail.K8sHTTPRoute
+		}
+	case kubernetes.K8sReferenceGrants:
+		if istioConfigDetail.K8sReferenceGrant != nil {
+			return istioConfigDetail.K8sReferenceGrant
+		}
+	case kubernetes.K8sTCPRoutes:
+		if istioConfigDetail.K8sTCPRoute != nil {
+			return istioConfigDetail.K8sTCPRoute
+		}
+	case kubernetes.K8sTLSRoutes:
+		if istioConfigDetail.K8sTLSRoute != nil {
+			return istioConfigDetail.K8sTLSRoute
+		}
+	case kubernetes.PeerAuthentications:
+		if istioConfigDetail.PeerAuthentication != nil {
+			return istioConfigDetail.PeerAuthentication
+		}
+	case kubernetes.RequestAuthentications:
+		if istioConfigDetail.RequestAuthentication != nil {
+			return istioConfigDetail.RequestAuthentication
+		}
+	case kubernetes.ServiceEntries:
+		if istioConfigDetail.ServiceEntry != nil {
+			return istioConfigDetail.ServiceEntry
+		}
+	case kubernetes.Sidecars:
+		if istioConfigDetail.Sidecar != nil {
+			return istioConfigDetail.Sidecar
+		}
+	case kubernetes.Telemetries:
+		if istioConfigDetail.Telemetry != nil {
+			return istioConfigDetail.Telemetry
+		}
+	case kubernetes.VirtualServices:
+		if istioConfigDetail.VirtualService != nil {
+			return istioConfigDetail.VirtualService
+		}
+	case kubernetes.WasmPlugins:
+		if istioConfigDetail.WasmPlugin != nil {
+			return istioConfigDetail.WasmPlugin
+		}
+	case kubernetes.WorkloadEntries:
+		if istioConfigDetail.WorkloadEntry != nil {
+			return istioConfigDetail.WorkloadEntry
+		}
+	case kubernetes.WorkloadGroups:
+		if istioConfigDetail.WorkloadGroup != nil {
+			return istioConfigDetail.WorkloadGroup
+		}
+	}
+	return nil
+}
+
 // FilterIstioConfigs Filters all Istio configs from Istio registry by given namespaces and return a map config list per namespace
 func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs {
 	filtered := IstioConfigs{}
@@ -203,7 +298,11 @@ func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs
 			filtered[ns].EnvoyFilters = []*networking_v1alpha3.EnvoyFilter{}
 			filtered[ns].Gateways = []*networking_v1beta1.Gateway{}
 			filtered[ns].K8sGateways = []*k8s_networking_v1beta1.Gateway{}
+			filtered[ns].K8sGRPCRoutes = []*k8s_networking_v1alpha2.GRPCRoute{}
 			filtered[ns].K8sHTTPRoutes = []*k8s_networking_v1beta1.HTTPRoute{}
+			filtered[ns].K8sReferenceGrants = []*k8s_networking_v1alpha2.ReferenceGrant{}
+			filtered[ns].K8sTCPRoutes = []*k8s_networking_v1alpha2.TCPRoute{}
+			filtered[ns].K8sTLSRoutes = []*k8s_networking_v1alpha2.TLSRoute{}
 			filtered[ns].VirtualServices = []*networking_v1beta1.VirtualService{}
 			filtered[ns].ServiceEntries = []*networking_v1beta1.ServiceEntry{}
 			filtered[ns].Sidecars = []*networking_v1beta1.Sidecar{}
@@ -239,12 +338,36 @@ func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs
 			}
 		}
 
+		for _, route := range configList.K8sGRPCRoutes {
+			if route.Namespace == ns {
+				filtered[ns].K8sGRPCRoutes = append(filtered[ns].K8sGRPCRoutes, route)
+			}
+		}
+
 		for _, route := range configList.K8sHTTPRoutes {
 			if route.Namespace == ns {
 				filtered[ns].K8sHTTPRoutes = append(filtered[ns].K8sHTTPRoutes, route)
 			}
 		}
 
+		for _, rg := range configList.K8sReferenceGrants {
+			if rg.Namespace == ns {
+				filtered[ns].K8sReferenceGrants = append(filtered[ns].K8sReferenceGrants, rg)
+			}
+		}
+
+		for _, route := range configList.K8sTCPRoutes {
+			if route.Namespace == ns {
+				filtered[ns].K8sTCPRoutes = append(filtered[ns].K8sTCPRoutes, route)
+			}
+		}
+
+		for _, route := range configList.K8sTLSRoutes {
+			if route.Namespace == ns {
+				filtered[ns].K8sTLSRoutes = append(filtered[ns].K8sTLSRoutes, route)
+			}
+		}
+
 		for _, se := range configList.ServiceEntries {
 			if se.Namespace == ns {
 				filtered[ns].ServiceEntries = append(filtered[ns].ServiceEntries, se)
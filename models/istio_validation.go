@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
@@ -31,6 +32,9 @@ type IstioValidationSummary struct {
 	// required: true
 	// example: 4
 	Warnings int `json:"warnings"`
+	// Number of validations suppressed by the Kiali config or by the kiali.io/ignore-checks annotation
+	// example: 1
+	Suppressed int `json:"suppressed"`
 }
 
 // ValidationSummaries holds a map of IstioValidationSummary per namespace
@@ -62,6 +66,80 @@ type IstioValidation struct {
 
 	// Related objects (only validation errors)
 	References []IstioValidationKey `json:"references"`
+
+	// Checks that are not reported, suppressed by the Kiali config or by the kiali.io/ignore-checks annotation
+	SuppressedChecks []*SuppressedCheck `json:"suppressedChecks,omitempty"`
+}
+
+// IgnoreChecksAnnotation is the annotation of the Istio objects and namespaces with a comma-separated list of the
+// checks to ignore, by code (e.g. KIA1101) or by id (e.g. virtualservices.singlehost)
+const IgnoreChecksAnnotation = "kiali.io/ignore-checks"
+
+// What suppressed a check
+const (
+	SuppressedByConfig    = "config"
+	SuppressedByNamespace = "namespace"
+	SuppressedByObject    = "object"
+)
+
+// SuppressedCheck represents a check that is not reported, and what suppressed it.
+// swagger:model
+type SuppressedCheck struct {
+	IstioCheck
+
+	// What suppressed the check: the ignored checks of the Kiali config, or the kiali.io/ignore-checks annotation
+	// of the object or of its namespace
+	// required: true
+	// example: object
+	SuppressedBy string `json:"suppressedBy"`
+}
+
+// IgnoredChecks holds the checks ignored by the kiali.io/ignore-checks annotation of the Istio objects and namespaces
+type IgnoredChecks struct {
+	Objects    map[IstioValidationKey][]string
+	Namespaces map[string][]string
+}
+
+func NewIgnoredChecks() IgnoredChecks {
+	return IgnoredChecks{Objects: map[IstioValidationKey][]string{}, Namespaces: map[string][]string{}}
+}
+
+// AddObject adds the checks ignored by the annotations of an object
+func (ic IgnoredChecks) AddObject(key IstioValidationKey, annotations map[string]string) {
+	if ignored := ParseIgnoreChecksAnnotation(annotations); len(ignored) > 0 {
+		ic.Objects[key] = ignored
+	}
+}
+
+// AddNamespace adds the checks ignored by the annotations of a namespace
+func (ic IgnoredChecks) AddNamespace(namespace string, annotations map[string]string) {
+	if ignored := ParseIgnoreChecksAnnotation(annotations); len(ignored) > 0 {
+		ic.Namespaces[namespace] = ignored
+	}
+}
+
+// ParseIgnoreChecksAnnotation returns the checks listed by the kiali.io/ignore-checks annotation
+func ParseIgnoreChecksAnnotation(annotations map[string]string) []string {
+	ignored := []string{}
+	for _, id := range strings.Split(annotations[IgnoreChecksAnnotation], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ignored = append(ignored, id)
+		}
+	}
+	return ignored
+}
+
+// matchesCheck returns true when one of the codes or check ids identifies the check
+func matchesCheck(ignored []string, check *IstioCheck) bool {
+	for _, id := range ignored {
+		if id == check.Code {
+			return true
+		}
+		if descriptor, found := checkDescriptors[id]; found && descriptor.Code == check.Code && descriptor.Message == check.Message {
+			return true
+		}
+	}
+	return false
 }
 
 // IstioCheck represents an individual check.
@@ -112,10 +190,15 @@ var ObjectTypeSingular = map[string]string{
 	"peerauthentications":    "peerauthentication",
 	"requestauthentications": "requestauthentication",
 	"workloads":              "workload",
-	"wasmplugins":            "wasmpluin",
+	"wasmplugins":            "wasmplugin",
 	"telemetries":            "telemetry",
 	"k8shttproutes":          "k8shttproute",
 	"k8sgateways":            "k8sgateway",
+	"k8sgrpcroutes":          "k8sgrpcroute",
+	"k8sreferencegrants":     "k8sreferencegrant",
+	"k8stcproutes":           "k8stcproute",
+	"k8stlsroutes":           "k8stlsroute",
+	"envoyfilters":           "envoyfilter",
 }
 
 var checkDescriptors = map[string]IstioCheck{
@@ -189,6 +272,21 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This subset has not labels",
 		Severity: WarningSeverity,
 	},
+	"envoyfilter.proxyversion.nomatch": {
+		Code:     "KIA1801",
+		Message:  "The proxyVersion does not match any running proxy version",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.patch.targetnotfound": {
+		Code:     "KIA1802",
+		Message:  "The patched listener or cluster is not found in the sampled proxy config",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.patch.ambiguousorder": {
+		Code:     "KIA1803",
+		Message:  "More than one EnvoyFilter with the same priority patching the same object, the order is undefined",
+		Severity: WarningSeverity,
+	},
 	"gateways.multimatch": {
 		Code:     "KIA0301",
 		Message:  "More than one Gateway for the same host port combination",
@@ -229,6 +327,36 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "HTTPRoute is pointing to a non-existent K8s gateway",
 		Severity: ErrorSeverity,
 	},
+	"k8sreferencegrants.from.namespacenotfound": {
+		Code:     "KIA2001",
+		Message:  "Namespace not found for this ReferenceGrant source",
+		Severity: WarningSeverity,
+	},
+	"k8sroutes.nohost.namenotfound": {
+		Code:     "KIA1901",
+		Message:  "BackendRef on rule doesn't have a valid service (host not found)",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nok8sgateway": {
+		Code:     "KIA1902",
+		Message:  "Route is pointing to a non-existent K8s gateway",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nok8sgateway.listener": {
+		Code:     "KIA1903",
+		Message:  "No listener of the K8s gateway matches the sectionName or port of the parentRef",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nok8sgateway.notallowed": {
+		Code:     "KIA1904",
+		Message:  "No listener of the K8s gateway allows this route kind from this namespace",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nok8sreferencegrant": {
+		Code:     "KIA1905",
+		Message:  "BackendRef to another namespace is not allowed by any ReferenceGrant",
+		Severity: ErrorSeverity,
+	},
 	"peerauthentication.mtls.destinationrulemissing": {
 		Code:     "KIA0401",
 		Message:  "Mesh-wide Destination Rule enabling mTLS is missing",
@@ -284,6 +412,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "OutboundTrafficPolicy with empty mode value is ambiguous due to an Istio limitation. This may indicate ALLOW_ANY or REGISTRY_ONLY. Inspect the value using other means.",
 		Severity: Unknown,
 	},
+	"telemetry.provider.notfound": {
+		Code:     "KIA1601",
+		Message:  "Provider not found in the mesh config extensionProviders",
+		Severity: ErrorSeverity,
+	},
 	"virtualservices.gateway.oldnomenclature": {
 		Code:     "KIA1108",
 		Message:  "Preferred nomenclature: <gateway namespace>/<gateway name>",
@@ -309,6 +442,16 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This host subset combination is already referenced in another route destination",
 		Severity: WarningSeverity,
 	},
+	"virtualservices.route.unreachable": {
+		Code:     "KIA1109",
+		Message:  "This route is never matched, an earlier route matches all of its requests",
+		Severity: WarningSeverity,
+	},
+	"virtualservices.route.contradictorymatch": {
+		Code:     "KIA1110",
+		Message:  "The match conditions contradict each other, this match request is never matched",
+		Severity: WarningSeverity,
+	},
 	"virtualservices.singlehost": {
 		Code:     "KIA1106",
 		Message:  "More than one Virtual Service for same host",
@@ -319,6 +462,21 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Subset not found",
 		Severity: WarningSeverity,
 	},
+	"wasmplugin.url.invalidscheme": {
+		Code:     "KIA1701",
+		Message:  "URL scheme must be one of: oci:// | file:// | http:// | https://",
+		Severity: ErrorSeverity,
+	},
+	"wasmplugin.imagepullsecret.notfound": {
+		Code:     "KIA1702",
+		Message:  "Image pull secret not found in this namespace",
+		Severity: ErrorSeverity,
+	},
+	"wasmplugin.phase.priority.conflict": {
+		Code:     "KIA1703",
+		Message:  "More than one WasmPlugin with the same phase and priority applied to the same workload, the order is undefined",
+		Severity: WarningSeverity,
+	},
 	"workload.authorizationpolicy.needstobecovered": {
 		Code:     "KIA1301",
 		Message:  "This workload is not covered by any authorization policy",
@@ -351,6 +509,16 @@ func BuildKey(objectType, name, namespace string) IstioValidationKey {
 	return IstioValidationKey{ObjectType: objectType, Namespace: namespace, Name: name}
 }
 
+// IsBuiltInCheckCode returns true when the code is the code of a check of the Kiali validations (e.g. KIA1101)
+func IsBuiltInCheckCode(code string) bool {
+	for _, descriptor := range checkDescriptors {
+		if descriptor.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
 func CheckMessage(checkId string) string {
 	if val, ok := checkDescriptors[checkId]; ok {
 		return val.GetFullMessage()
@@ -407,6 +575,62 @@ func (iv IstioValidations) FilterByTypes(objectTypes []string) IstioValidations
 	return fiv
 }
 
+// NewErrorChecks returns the error checks of the validation that the previous validation of the same object doesn't
+// report, matched by code and path. A nil previous validation means that the object didn't exist.
+func (iv *IstioValidation) NewErrorChecks(previous *IstioValidation) []*IstioCheck {
+	newErrors := []*IstioCheck{}
+	if iv == nil {
+		return newErrors
+	}
+	for _, check := range iv.Checks {
+		if check.Severity != ErrorSeverity {
+			continue
+		}
+		found := false
+		if previous != nil {
+			for _, previousCheck := range previous.Checks {
+				if previousCheck.Severity == ErrorSeverity && previousCheck.Code == check.Code && previousCheck.Path == check.Path {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			newErrors = append(newErrors, check)
+		}
+	}
+	return newErrors
+}
+
+// NewErrorChecks returns, per object, the error checks that the previous validations of the same objects don't
+// report. The objects missing from the previous validations didn't exist, all their errors are new.
+func (iv IstioValidations) NewErrorChecks(previous IstioValidations) map[IstioValidationKey][]*IstioCheck {
+	newErrors := map[IstioValidationKey][]*IstioCheck{}
+	for key, validation := range iv {
+		if checks := validation.NewErrorChecks(previous[key]); len(checks) > 0 {
+			newErrors[key] = checks
+		}
+	}
+	return newErrors
+}
+
+// FilterByNamespaces returns the validations of the Istio objects in the given namespaces, workloads are excluded
+// the same way they are from the validation summaries
+func (iv IstioValidations) FilterByNamespaces(namespaces []string) IstioValidations {
+	nss := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		nss[ns] = true
+	}
+	fiv := IstioValidations{}
+	for k, v := range iv {
+		if nss[k.Namespace] && k.ObjectType != "workload" {
+			fiv[k] = v
+		}
+	}
+
+	return fiv
+}
+
 func (iv IstioValidations) MergeValidations(validations IstioValidations) IstioValidations {
 	for key, validation := range validations {
 		v, ok := iv[key]
@@ -457,6 +681,7 @@ func (iv IstioValidations) SummarizeValidation(ns string) *IstioValidationSummar
 	for k, v := range iv {
 		if k.Namespace == ns && k.ObjectType != "workload" {
 			ivs.mergeSummaries(v.Checks)
+			ivs.Suppressed += len(v.SuppressedChecks)
 		}
 	}
 	return &ivs
@@ -486,32 +711,52 @@ func (iv IstioValidations) MarshalJSON() ([]byte, error) {
 	return json.Marshal(out)
 }
 
-func (iv *IstioValidations) StripIgnoredChecks() {
-	// strip away codes that are to be ignored
+// StripIgnoredChecks moves the checks ignored by the Kiali config, or by the kiali.io/ignore-checks annotation of the
+// objects and of their namespaces, to the suppressed checks of the validations
+func (iv *IstioValidations) StripIgnoredChecks(ignoredChecks IgnoredChecks) {
 	codesToIgnore := config.Get().KialiFeatureFlags.Validations.Ignore
-	if len(codesToIgnore) > 0 {
-		for curValidationKey, curValidation := range *iv {
-			idx := 0
-			// loop over each IstioCheck in the current Validation and only keep it if it is not ignored
-			for _, curCheck := range curValidation.Checks {
-				ignoreCheck := false
-				for _, cti := range codesToIgnore {
-					if cti == curCheck.Code {
-						ignoreCheck = true
-						log.Tracef("Ignoring validation failure [%+v] for object [%s:%s] in namespace [%s]", curCheck, curValidationKey.ObjectType, curValidationKey.Name, curValidationKey.Namespace)
-						break
-					}
-				}
-				if !ignoreCheck {
-					curValidation.Checks[idx] = curCheck
-					idx++
-				}
+	for curValidationKey, curValidation := range *iv {
+		objectIgnored := ignoredChecks.Objects[curValidationKey]
+		namespaceIgnored := ignoredChecks.Namespaces[curValidationKey.Namespace]
+		if len(codesToIgnore) == 0 && len(objectIgnored) == 0 && len(namespaceIgnored) == 0 {
+			continue
+		}
+
+		idx := 0
+		// loop over each IstioCheck in the current Validation and only keep it if it is not ignored
+		for _, curCheck := range curValidation.Checks {
+			suppressedBy := ""
+			if matchesCheck(codesToIgnore, curCheck) {
+				suppressedBy = SuppressedByConfig
+			} else if matchesCheck(objectIgnored, curCheck) {
+				suppressedBy = SuppressedByObject
+			} else if matchesCheck(namespaceIgnored, curCheck) {
+				suppressedBy = SuppressedByNamespace
+			}
+			if suppressedBy != "" {
+				log.Tracef("Ignoring validation failure [%+v] for object [%s:%s] in namespace [%s], suppressed by [%s]", curCheck, curValidationKey.ObjectType, curValidationKey.Name, curValidationKey.Namespace, suppressedBy)
+				curValidation.SuppressedChecks = append(curValidation.SuppressedChecks, &SuppressedCheck{IstioCheck: *curCheck, SuppressedBy: suppressedBy})
+				continue
 			}
-			// Prevent memory leak - nil out ignored checks
-			for extraIdx := idx; extraIdx < len(curValidation.Checks); extraIdx++ {
-				curValidation.Checks[extraIdx] = nil
+			curValidation.Checks[idx] = curCheck
+			idx++
+		}
+		if idx == len(curValidation.Checks) {
+			continue
+		}
+		// Prevent memory leak - nil out ignored checks
+		for extraIdx := idx; extraIdx < len(curValidation.Checks); extraIdx++ {
+			curValidation.Checks[extraIdx] = nil
+		}
+		curValidation.Checks = curValidation.Checks[:idx]
+
+		// the object is valid when the remaining checks are not errors
+		curValidation.Valid = true
+		for _, curCheck := range curValidation.Checks {
+			if curCheck.Severity == ErrorSeverity {
+				curValidation.Valid = false
+				break
 			}
-			curValidation.Checks = curValidation.Checks[:idx]
 		}
 	}
 }
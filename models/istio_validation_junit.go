@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JUnitTestSuites is the JUnit XML report of a set of IstioValidations: a test suite per object type, and a test case
+// per object. Objects with error checks are failures, warning checks are reported in the test case output.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	SystemOut *JUnitOutput  `xml:"system-out,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+type JUnitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// SortedKeys returns the keys of the validations sorted by object type, namespace and name
+func (iv IstioValidations) SortedKeys() []IstioValidationKey {
+	keys := make([]IstioValidationKey, 0, len(iv))
+	for k := range iv {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ObjectType != keys[j].ObjectType {
+			return keys[i].ObjectType < keys[j].ObjectType
+		}
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// JUnit returns the JUnit XML report of the validations, with the given report name
+func (iv IstioValidations) JUnit(name string) JUnitTestSuites {
+	report := JUnitTestSuites{Name: name, Suites: []JUnitTestSuite{}}
+
+	var suite *JUnitTestSuite
+	for _, key := range iv.SortedKeys() {
+		if suite == nil || suite.Name != key.ObjectType {
+			report.Suites = append(report.Suites, JUnitTestSuite{Name: key.ObjectType, TestCases: []JUnitTestCase{}})
+			suite = &report.Suites[len(report.Suites)-1]
+		}
+
+		testCase := JUnitTestCase{Name: key.Namespace + "/" + key.Name, ClassName: key.ObjectType}
+		var errors, warnings []string
+		for _, check := range iv[key].Checks {
+			line := fmt.Sprintf("%s %s: %s", check.Code, check.Path, check.Message)
+			if check.Severity == ErrorSeverity {
+				errors = append(errors, line)
+			} else {
+				warnings = append(warnings, fmt.Sprintf("[%s] %s", check.Severity, line))
+			}
+		}
+		if len(errors) > 0 {
+			testCase.Failure = &JUnitFailure{
+				Message: fmt.Sprintf("%d validation error(s)", len(errors)),
+				Type:    string(ErrorSeverity),
+				Text:    strings.Join(errors, "\n"),
+			}
+			suite.Failures++
+			report.Failures++
+		}
+		if len(warnings) > 0 {
+			testCase.SystemOut = &JUnitOutput{Text: strings.Join(warnings, "\n")}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	return report
+}
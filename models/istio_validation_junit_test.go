@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIstioValidationsJUnit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	validations := IstioValidations{
+		IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"}: &IstioValidation{
+			Name:       "reviews",
+			ObjectType: "virtualservice",
+			Checks: []*IstioCheck{
+				{Code: "KIA1101", Severity: ErrorSeverity, Message: "DestinationWeight on route doesn't have a valid service", Path: "spec/http[0]/route[0]/destination/host"},
+				{Code: "KIA1109", Severity: WarningSeverity, Message: "This route is never matched", Path: "spec/http[1]"},
+			},
+		},
+		IstioValidationKey{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo"}: &IstioValidation{
+			Name:       "reviews",
+			ObjectType: "destinationrule",
+			Valid:      true,
+			Checks:     []*IstioCheck{},
+		},
+		IstioValidationKey{ObjectType: "virtualservice", Name: "details", Namespace: "bookinfo"}: &IstioValidation{
+			Name:       "details",
+			ObjectType: "virtualservice",
+			Valid:      true,
+			Checks:     []*IstioCheck{},
+		},
+	}
+
+	report := validations.JUnit("validations")
+	assert.Equal(3, report.Tests)
+	assert.Equal(1, report.Failures)
+	require.Len(report.Suites, 2)
+
+	assert.Equal("destinationrule", report.Suites[0].Name)
+	assert.Equal(1, report.Suites[0].Tests)
+	assert.Equal(0, report.Suites[0].Failures)
+
+	suite := report.Suites[1]
+	assert.Equal("virtualservice", suite.Name)
+	assert.Equal(2, suite.Tests)
+	assert.Equal(1, suite.Failures)
+	require.Len(suite.TestCases, 2)
+	assert.Equal("bookinfo/details", suite.TestCases[0].Name)
+	assert.Nil(suite.TestCases[0].Failure)
+	assert.Nil(suite.TestCases[0].SystemOut)
+
+	testCase := suite.TestCases[1]
+	assert.Equal("bookinfo/reviews", testCase.Name)
+	assert.Equal("virtualservice", testCase.ClassName)
+	require.NotNil(testCase.Failure)
+	assert.Equal("1 validation error(s)", testCase.Failure.Message)
+	assert.Equal("KIA1101 spec/http[0]/route[0]/destination/host: DestinationWeight on route doesn't have a valid service", testCase.Failure.Text)
+	require.NotNil(testCase.SystemOut)
+	assert.Equal("[warning] KIA1109 spec/http[1]: This route is never matched", testCase.SystemOut.Text)
+
+	b, err := xml.Marshal(report)
+	require.NoError(err)
+	assert.Contains(string(b), `<testsuites name="validations" tests="3" failures="1">`)
+	assert.Contains(string(b), `<failure message="1 validation error(s)" type="error"><![CDATA[KIA1101`)
+}
@@ -0,0 +1,143 @@
+package models
+
+import "strings"
+
+const (
+	SarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	SarifVersion = "2.1.0"
+)
+
+// SarifLog is the SARIF 2.1.0 report of a set of IstioValidations: a rule per check code, and a result per check
+// located in the validated object and the path of the check.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     SarifMessage       `json:"shortDescription"`
+	HelpURI              string             `json:"helpUri"`
+	DefaultConfiguration SarifConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// SarifLevel maps the severity of a check to a SARIF result level
+func SarifLevel(severity SeverityLevel) string {
+	switch severity {
+	case ErrorSeverity:
+		return "error"
+	case WarningSeverity:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// SARIF returns the SARIF report of the validations, with the given Kiali version as the tool version.
+// The artifact of each result is the validated object, as <namespace>/<object type>/<name>, and the path of the
+// check is reported as a logical location inside of it.
+func (iv IstioValidations) SARIF(version string) SarifLog {
+	driver := SarifDriver{
+		Name:           "kiali",
+		Version:        version,
+		InformationURI: "https://kiali.io",
+		Rules:          []SarifRule{},
+	}
+	results := []SarifResult{}
+
+	ruleIndexes := map[string]int{}
+	for _, key := range iv.SortedKeys() {
+		object := key.Namespace + "/" + key.ObjectType + "/" + key.Name
+		for _, check := range iv[key].Checks {
+			index, found := ruleIndexes[check.Code]
+			if !found {
+				index = len(driver.Rules)
+				ruleIndexes[check.Code] = index
+				driver.Rules = append(driver.Rules, SarifRule{
+					ID:                   check.Code,
+					ShortDescription:     SarifMessage{Text: check.Message},
+					HelpURI:              "https://kiali.io/docs/features/validations/#" + strings.ToLower(check.Code),
+					DefaultConfiguration: SarifConfiguration{Level: SarifLevel(check.Severity)},
+				})
+			}
+
+			location := SarifLocation{
+				PhysicalLocation: SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{URI: object}},
+				LogicalLocations: []SarifLogicalLocation{{Name: key.Name, FullyQualifiedName: object, Kind: "object"}},
+			}
+			if check.Path != "" {
+				location.LogicalLocations = append(location.LogicalLocations, SarifLogicalLocation{
+					Name:               check.Path,
+					FullyQualifiedName: object + "/" + check.Path,
+					Kind:               "member",
+				})
+			}
+
+			results = append(results, SarifResult{
+				RuleID:    check.Code,
+				RuleIndex: index,
+				Level:     SarifLevel(check.Severity),
+				Message:   SarifMessage{Text: check.Message},
+				Locations: []SarifLocation{location},
+			})
+		}
+	}
+
+	return SarifLog{
+		Schema:  SarifSchema,
+		Version: SarifVersion,
+		Runs:    []SarifRun{{Tool: SarifTool{Driver: driver}, Results: results}},
+	}
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIstioValidationsSARIF(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	validations := IstioValidations{
+		IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"}: &IstioValidation{
+			Name:       "reviews",
+			ObjectType: "virtualservice",
+			Checks: []*IstioCheck{
+				{Code: "KIA1101", Severity: ErrorSeverity, Message: "DestinationWeight on route doesn't have a valid service", Path: "spec/http[0]/route[0]/destination/host"},
+				{Code: "KIA1109", Severity: WarningSeverity, Message: "This route is never matched", Path: "spec/http[1]"},
+			},
+		},
+		IstioValidationKey{ObjectType: "virtualservice", Name: "details", Namespace: "bookinfo"}: &IstioValidation{
+			Name:       "details",
+			ObjectType: "virtualservice",
+			Checks: []*IstioCheck{
+				{Code: "KIA1101", Severity: ErrorSeverity, Message: "DestinationWeight on route doesn't have a valid service", Path: "spec/http[0]/route[0]/destination/host"},
+			},
+		},
+		IstioValidationKey{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo"}: &IstioValidation{
+			Name:       "reviews",
+			ObjectType: "destinationrule",
+			Valid:      true,
+			Checks:     []*IstioCheck{},
+		},
+	}
+
+	report := validations.SARIF("v1.0")
+	assert.Equal(SarifVersion, report.Version)
+	require.Len(report.Runs, 1)
+
+	driver := report.Runs[0].Tool.Driver
+	assert.Equal("kiali", driver.Name)
+	assert.Equal("v1.0", driver.Version)
+	require.Len(driver.Rules, 2)
+	assert.Equal("KIA1101", driver.Rules[0].ID)
+	assert.Equal("error", driver.Rules[0].DefaultConfiguration.Level)
+	assert.Equal("https://kiali.io/docs/features/validations/#kia1101", driver.Rules[0].HelpURI)
+	assert.Equal("KIA1109", driver.Rules[1].ID)
+	assert.Equal("warning", driver.Rules[1].DefaultConfiguration.Level)
+
+	results := report.Runs[0].Results
+	require.Len(results, 3)
+	assert.Equal("KIA1101", results[0].RuleID)
+	assert.Equal(0, results[0].RuleIndex)
+	assert.Equal("bookinfo/virtualservice/details", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	result := results[2]
+	assert.Equal("KIA1109", result.RuleID)
+	assert.Equal(1, result.RuleIndex)
+	assert.Equal("warning", result.Level)
+	assert.Equal("This route is never matched", result.Message.Text)
+	require.Len(result.Locations, 1)
+	assert.Equal("bookinfo/virtualservice/reviews", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Len(result.Locations[0].LogicalLocations, 2)
+	assert.Equal("spec/http[1]", result.Locations[0].LogicalLocations[1].Name)
+	assert.Equal("bookinfo/virtualservice/reviews/spec/http[1]", result.Locations[0].LogicalLocations[1].FullyQualifiedName)
+
+	b, err := json.Marshal(report)
+	require.NoError(err)
+	assert.Contains(string(b), `"$schema":"https://json.schemastore.org/sarif-2.1.0.json"`)
+}
+
+func TestIstioValidationsFilterByNamespaces(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := IstioValidations{
+		IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"}: &IstioValidation{},
+		IstioValidationKey{ObjectType: "workload", Name: "reviews-v1", Namespace: "bookinfo"}:    &IstioValidation{},
+		IstioValidationKey{ObjectType: "gateway", Name: "ingress", Namespace: "istio-system"}:    &IstioValidation{},
+		IstioValidationKey{ObjectType: "sidecar", Name: "default", Namespace: "travels"}:         &IstioValidation{},
+	}
+
+	filtered := validations.FilterByNamespaces([]string{"bookinfo", "istio-system"})
+	assert.Len(filtered, 2)
+	assert.Contains(filtered, IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"})
+	assert.Contains(filtered, IstioValidationKey{ObjectType: "gateway", Name: "ingress", Namespace: "istio-system"})
+}
@@ -77,10 +77,118 @@ func TestSummarizeValidations(t *testing.T) {
 	conf := config.NewConfig()
 	conf.KialiFeatureFlags.Validations.Ignore = []string{"FOO2", "FOO3"}
 	config.Set(conf)
-	validations.StripIgnoredChecks()
+	validations.StripIgnoredChecks(NewIgnoredChecks())
 	assert.Equal(1, len(validations[key1].Checks))
 	assert.Equal(1, len(validations[key2].Checks))
 	summary = validations.SummarizeValidation("bookinfo")
 	assert.Equal(1, summary.Warnings)
 	assert.Equal(1, summary.Errors)
+	assert.Equal(2, summary.Suppressed)
+}
+
+func TestStripIgnoredChecksByAnnotation(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	singleHost := Build("virtualservices.singlehost", "spec/hosts")
+	subsetNotFound := Build("virtualservices.subsetpresent.subsetnotfound", "spec/http[0]/route[0]/destination")
+	multiMatch := Build("generic.multimatch.selector", "spec/selector")
+
+	vsKey := IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "reviews"}
+	otherVsKey := IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "details"}
+	sidecarKey := IstioValidationKey{ObjectType: "sidecar", Namespace: "travels", Name: "default"}
+	validations := IstioValidations{
+		vsKey:      &IstioValidation{Name: "reviews", ObjectType: "virtualservice", Valid: false, Checks: []*IstioCheck{&singleHost, &subsetNotFound}},
+		otherVsKey: &IstioValidation{Name: "details", ObjectType: "virtualservice", Valid: true, Checks: []*IstioCheck{&singleHost}},
+		sidecarKey: &IstioValidation{Name: "default", ObjectType: "sidecar", Valid: true, Checks: []*IstioCheck{&multiMatch}},
+	}
+
+	ignoredChecks := NewIgnoredChecks()
+	// by check id and by code
+	ignoredChecks.AddObject(vsKey, map[string]string{IgnoreChecksAnnotation: "virtualservices.singlehost, " + subsetNotFound.Code})
+	ignoredChecks.AddNamespace("travels", map[string]string{IgnoreChecksAnnotation: "generic.multimatch.selector"})
+	ignoredChecks.AddNamespace("bookinfo", map[string]string{"other": "annotation"})
+	validations.StripIgnoredChecks(ignoredChecks)
+
+	// all the checks of the object are suppressed, it is valid
+	assert.Empty(validations[vsKey].Checks)
+	assert.True(validations[vsKey].Valid)
+	assert.Len(validations[vsKey].SuppressedChecks, 2)
+	assert.Equal(SuppressedByObject, validations[vsKey].SuppressedChecks[0].SuppressedBy)
+	assert.Equal(singleHost.Code, validations[vsKey].SuppressedChecks[0].Code)
+
+	// the annotation of an object doesn't suppress the checks of other objects
+	assert.Len(validations[otherVsKey].Checks, 1)
+	assert.Empty(validations[otherVsKey].SuppressedChecks)
+
+	assert.Empty(validations[sidecarKey].Checks)
+	assert.Len(validations[sidecarKey].SuppressedChecks, 1)
+	assert.Equal(SuppressedByNamespace, validations[sidecarKey].SuppressedChecks[0].SuppressedBy)
+
+	summary := validations.SummarizeValidation("bookinfo")
+	assert.Equal(1, summary.Warnings)
+	assert.Equal(2, summary.Suppressed)
+
+	b, err := json.Marshal(validations[sidecarKey])
+	assert.NoError(err)
+	assert.Contains(string(b), `"suppressedChecks":[{"code":"`+multiMatch.Code+`","message":"`+multiMatch.Message+`","severity":"`+string(multiMatch.Severity)+`","path":"spec/selector","suppressedBy":"namespace"}]`)
+}
+
+func TestNewErrorChecks(t *testing.T) {
+	assert := assert.New(t)
+
+	previous := &IstioValidation{
+		Name:       "reviews",
+		ObjectType: "virtualservice",
+		Checks: []*IstioCheck{
+			{Code: "KIA1101", Severity: ErrorSeverity, Path: "spec/http[0]/route[0]/destination/host"},
+			{Code: "KIA1109", Severity: WarningSeverity, Path: "spec/http[1]"},
+		},
+	}
+	current := &IstioValidation{
+		Name:       "reviews",
+		ObjectType: "virtualservice",
+		Checks: []*IstioCheck{
+			{Code: "KIA1101", Severity: ErrorSeverity, Path: "spec/http[0]/route[0]/destination/host"},
+			{Code: "KIA1101", Severity: ErrorSeverity, Path: "spec/http[1]/route[0]/destination/host"},
+			{Code: "KIA1109", Severity: WarningSeverity, Path: "spec/http[2]"},
+		},
+	}
+
+	newErrors := current.NewErrorChecks(previous)
+	assert.Len(newErrors, 1)
+	assert.Equal("spec/http[1]/route[0]/destination/host", newErrors[0].Path)
+
+	// all the errors of a new object are new
+	assert.Len(current.NewErrorChecks(nil), 2)
+
+	// objects without validations don't have errors
+	var unvalidated *IstioValidation
+	assert.Empty(unvalidated.NewErrorChecks(previous))
+}
+
+func TestValidationsNewErrorChecks(t *testing.T) {
+	assert := assert.New(t)
+
+	reviews := IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "reviews"}
+	ratings := IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "ratings"}
+	details := IstioValidationKey{ObjectType: "destinationrule", Namespace: "bookinfo", Name: "details"}
+	hostError := &IstioCheck{Code: "KIA1101", Severity: ErrorSeverity, Path: "spec/http[0]/route[0]/destination/host"}
+
+	previous := IstioValidations{
+		reviews: {Name: "reviews", ObjectType: "virtualservice", Checks: []*IstioCheck{hostError}},
+		ratings: {Name: "ratings", ObjectType: "virtualservice", Checks: []*IstioCheck{}},
+	}
+	current := IstioValidations{
+		reviews: {Name: "reviews", ObjectType: "virtualservice", Checks: []*IstioCheck{hostError}},
+		ratings: {Name: "ratings", ObjectType: "virtualservice", Checks: []*IstioCheck{hostError}},
+		details: {Name: "details", ObjectType: "destinationrule", Checks: []*IstioCheck{{Code: "KIA0202", Severity: ErrorSeverity, Path: "spec/host"}}},
+	}
+
+	// the errors of other objects are new too, the errors already reported are not
+	newErrors := current.NewErrorChecks(previous)
+	assert.Len(newErrors, 2)
+	assert.Equal([]*IstioCheck{hostError}, newErrors[ratings])
+	assert.Len(newErrors[details], 1)
+	assert.NotContains(newErrors, reviews)
 }
@@ -65,6 +65,9 @@ func CastNamespace(ns core_v1.Namespace, cluster string) Namespace {
 	if da, ok := ns.Annotations[dashboards.DashboardTemplateAnnotation]; ok {
 		namespace.Annotations[dashboards.DashboardTemplateAnnotation] = da
 	}
+	if ic, ok := ns.Annotations[IgnoreChecksAnnotation]; ok {
+		namespace.Annotations[IgnoreChecksAnnotation] = ic
+	}
 	return namespace
 }
 
@@ -87,6 +90,9 @@ func CastProject(p osproject_v1.Project) Namespace {
 	if da, ok := p.Annotations[dashboards.DashboardTemplateAnnotation]; ok {
 		namespace.Annotations[dashboards.DashboardTemplateAnnotation] = da
 	}
+	if ic, ok := p.Annotations[IgnoreChecksAnnotation]; ok {
+		namespace.Annotations[IgnoreChecksAnnotation] = ic
+	}
 	return namespace
 }
 
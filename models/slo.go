@@ -0,0 +1,228 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	prom_model "github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+)
+
+const (
+	SLOTypeAvailability = "availability"
+	SLOTypeLatency      = "latency"
+)
+
+// SLOBurnRateWindows are the windows of the burn rates, the pairs of short and long windows (5m and 1h, 30m and 6h)
+// of the multi-window burn rate alerts
+var SLOBurnRateWindows = []string{"5m", "30m", "1h", "6h"}
+
+// SLOLatencyThresholds are the buckets, in milliseconds, of the Istio request duration histogram with its default
+// buckets. The latency thresholds of the SLOs must be one of them, the requests within other thresholds can't be
+// counted.
+var SLOLatencyThresholds = []float64{0.5, 1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000, 600000, 1800000, 3600000}
+
+// NamespaceSLOs is a list of service name x SLO statuses for a given namespace
+type NamespaceSLOs map[string][]SLOStatus
+
+// SLOStatus is the status of a service level objective over its window
+type SLOStatus struct {
+	config.SLO
+	// SLI is the percentage of good requests over the window, -1 without requests
+	SLI float64 `json:"sli"`
+	// ErrorBudget is the percentage of bad requests allowed by the target
+	ErrorBudget float64 `json:"errorBudget"`
+	// ErrorBudgetRemaining is the percentage of the error budget not consumed yet, negative when it's overspent
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+	// Exhausted is true when all the error budget is consumed
+	Exhausted bool       `json:"exhausted"`
+	BurnRates []BurnRate `json:"burnRates"`
+}
+
+// BurnRate is the rate at which the error budget is consumed over a window, 1 consumes the whole budget in the
+// window of the SLO
+type BurnRate struct {
+	Window string  `json:"window"`
+	SLI    float64 `json:"sli"`
+	Rate   float64 `json:"rate"`
+}
+
+// NewSLOStatus returns the status of the SLO from the SLI over its window and the SLIs over the burn rate windows,
+// the SLIs are -1 without requests
+func NewSLOStatus(slo config.SLO, sli float64, burnSLIs map[string]float64) SLOStatus {
+	status := SLOStatus{
+		SLO:                  slo,
+		SLI:                  sli,
+		ErrorBudget:          100 - slo.Target,
+		ErrorBudgetRemaining: 100,
+		BurnRates:            []BurnRate{},
+	}
+	if sli >= 0 && status.ErrorBudget > 0 {
+		status.ErrorBudgetRemaining = 100 - 100*(100-sli)/status.ErrorBudget
+	}
+	status.Exhausted = status.ErrorBudgetRemaining <= 0
+
+	for _, window := range SLOBurnRateWindows {
+		burnSLI, ok := burnSLIs[window]
+		if !ok {
+			continue
+		}
+		burnRate := BurnRate{Window: window, SLI: burnSLI}
+		if burnSLI >= 0 && status.ErrorBudget > 0 {
+			burnRate.Rate = (100 - burnSLI) / status.ErrorBudget
+		}
+		status.BurnRates = append(status.BurnRates, burnRate)
+	}
+	return status
+}
+
+// AvailabilitySLI returns the percentage of the inbound requests without server errors, -1 without requests
+func (in RequestHealth) AvailabilitySLI() float64 {
+	total, bad := 0.0, 0.0
+	for protocol, codes := range in.Inbound {
+		for code, rate := range codes {
+			total += rate
+			if isServerError(protocol, code) {
+				bad += rate
+			}
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+	return 100 * (total - bad) / total
+}
+
+// isServerError returns true for the responses counted against the availability objectives: the http 5xx, the grpc
+// statuses of server errors and the requests without response
+func isServerError(protocol, code string) bool {
+	if code == "-" {
+		return true
+	}
+	if protocol == "grpc" {
+		switch code {
+		// UNKNOWN, DEADLINE_EXCEEDED, INTERNAL, UNAVAILABLE, DATA_LOSS
+		case "2", "4", "13", "14", "15":
+			return true
+		}
+	}
+	return len(code) == 3 && strings.HasPrefix(code, "5")
+}
+
+// GetServiceSLOs returns the SLOs defined in the health.kiali.io/slo annotation of the service or, when it is not set
+// or not valid, all the SLOs of the health config matching the namespace and the name of the service
+func GetServiceSLOs(namespace, service string, annotations map[string]string) []config.SLO {
+	if annotation, ok := annotations[string(SLOHealthAnnotation)]; ok && annotation != "" {
+		slos, err := ParseSLOHealthAnnotation(annotation)
+		if err == nil {
+			return slos
+		}
+		log.Debugf("Ignoring the %s annotation of service [%s/%s]: %s", SLOHealthAnnotation, namespace, service, err)
+	}
+
+	slos := []config.SLO{}
+	for _, slo := range config.Get().HealthConfig.SLOs {
+		if !matchesExpr(slo.Namespace, namespace, false) || !matchesExpr(slo.Service, service, false) {
+			continue
+		}
+		if err := ValidateSLO(slo); err != nil {
+			log.Debugf("Ignoring the SLO [%s] of the health config: %s", slo.Name, err)
+			continue
+		}
+		if slo.Name == "" {
+			slo.Name = slo.Type + "-" + slo.Window
+		}
+		slos = append(slos, slo)
+	}
+	return slos
+}
+
+// ParseSLOHealthAnnotation parses the SLOs of a health.kiali.io/slo annotation, with the format
+// type,target,window[,latencyThreshold], i.e. "availability,99.9,30d;latency,99,7d,500"
+func ParseSLOHealthAnnotation(annotation string) ([]config.SLO, error) {
+	slos := []config.SLO{}
+	for _, value := range strings.Split(annotation, ";") {
+		fields := strings.Split(value, ",")
+		if len(fields) != 3 && len(fields) != 4 {
+			return nil, fmt.Errorf("SLO [%s] must have the format type,target,window[,latencyThreshold]", value)
+		}
+		target, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("target of SLO [%s] is not a number", value)
+		}
+		slo := config.SLO{Name: fields[0] + "-" + fields[2], Type: fields[0], Target: target, Window: fields[2]}
+		if len(fields) == 4 {
+			if slo.LatencyThreshold, err = strconv.ParseFloat(fields[3], 64); err != nil {
+				return nil, fmt.Errorf("latency threshold of SLO [%s] is not a number", value)
+			}
+		}
+		if err := ValidateSLO(slo); err != nil {
+			return nil, fmt.Errorf("SLO [%s] is not valid: %s", value, err)
+		}
+		slos = append(slos, slo)
+	}
+	return slos, nil
+}
+
+// ValidateSLO returns an error when the type, the target, the window or the latency threshold of the SLO are not valid
+func ValidateSLO(slo config.SLO) error {
+	switch slo.Type {
+	case SLOTypeAvailability:
+	case SLOTypeLatency:
+		if slo.LatencyThreshold <= 0 {
+			return fmt.Errorf("latency SLOs need a latency threshold")
+		}
+		if !isSLOLatencyThreshold(slo.LatencyThreshold) {
+			return fmt.Errorf("latency threshold must be a bucket of the Istio request duration histogram: %s", formatSLOLatencyThresholds())
+		}
+	default:
+		return fmt.Errorf("type must be %s or %s", SLOTypeAvailability, SLOTypeLatency)
+	}
+	if slo.Target <= 0 || slo.Target >= 100 {
+		return fmt.Errorf("target must be a percentage between 0 and 100")
+	}
+	if _, err := prom_model.ParseDuration(slo.Window); err != nil {
+		return fmt.Errorf("window must be a Prometheus duration: %s", err)
+	}
+	return nil
+}
+
+func isSLOLatencyThreshold(threshold float64) bool {
+	for _, bucket := range SLOLatencyThresholds {
+		if threshold == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+func formatSLOLatencyThresholds() string {
+	thresholds := make([]string, 0, len(SLOLatencyThresholds))
+	for _, bucket := range SLOLatencyThresholds {
+		thresholds = append(thresholds, strconv.FormatFloat(bucket, 'f', -1, 64))
+	}
+	return strings.Join(thresholds, ", ")
+}
+
+// MergeSLOs adds the exhausted error budgets of the service SLOs to the status of the service health.
+// The status must be evaluated first.
+func (in *ServiceHealth) MergeSLOs(slos []SLOStatus) {
+	if in.Status == nil {
+		return
+	}
+	for _, slo := range slos {
+		if !slo.Exhausted {
+			continue
+		}
+		in.Status.merge(HealthStatusFailure, []HealthReason{{
+			Status:    HealthStatusFailure,
+			Message:   fmt.Sprintf("SLO %s error budget exhausted, SLI %.3f%% < %g%% over %s", slo.Name, slo.SLI, slo.Target, slo.Window),
+			SLO:       slo.Name,
+			Threshold: slo.Target,
+			Value:     slo.SLI,
+		}})
+	}
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+)
+
+func TestNewSLOStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	slo := config.SLO{Name: "availability-30d", Type: SLOTypeAvailability, Target: 99, Window: "30d"}
+
+	status := NewSLOStatus(slo, 99.5, map[string]float64{"5m": 95, "1h": 99.9, "6h": -1})
+	assert.InDelta(1, status.ErrorBudget, 0.0001)
+	assert.InDelta(50, status.ErrorBudgetRemaining, 0.0001)
+	assert.False(status.Exhausted)
+	require.Len(t, status.BurnRates, 3)
+	assert.Equal("5m", status.BurnRates[0].Window)
+	assert.InDelta(5, status.BurnRates[0].Rate, 0.0001)
+	assert.Equal("1h", status.BurnRates[1].Window)
+	assert.InDelta(0.1, status.BurnRates[1].Rate, 0.0001)
+	assert.Equal("6h", status.BurnRates[2].Window)
+	assert.Zero(status.BurnRates[2].Rate)
+
+	status = NewSLOStatus(slo, 98.5, nil)
+	assert.InDelta(-50, status.ErrorBudgetRemaining, 0.0001)
+	assert.True(status.Exhausted)
+	assert.Empty(status.BurnRates)
+
+	// Without requests nothing of the budget is consumed
+	status = NewSLOStatus(slo, -1, nil)
+	assert.Equal(float64(100), status.ErrorBudgetRemaining)
+	assert.False(status.Exhausted)
+}
+
+func TestAvailabilitySLI(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := NewEmptyRequestHealth()
+	assert.Equal(float64(-1), requests.AvailabilitySLI())
+
+	requests.Inbound = map[string]map[string]float64{
+		"http": {"200": 90, "404": 5, "503": 3, "-": 1},
+		"grpc": {"0": 0.5, "5": 0.3, "14": 0.2},
+	}
+	// 100 requests, the 404 and the grpc NOT_FOUND are not server errors
+	assert.InDelta(95.8, requests.AvailabilitySLI(), 0.0001)
+}
+
+func TestParseSLOHealthAnnotation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	slos, err := ParseSLOHealthAnnotation("availability,99.9,30d;latency,99,7d,500")
+	require.NoError(err)
+	require.Len(slos, 2)
+	assert.Equal(config.SLO{Name: "availability-30d", Type: SLOTypeAvailability, Target: 99.9, Window: "30d"}, slos[0])
+	assert.Equal(config.SLO{Name: "latency-7d", Type: SLOTypeLatency, Target: 99, Window: "7d", LatencyThreshold: 500}, slos[1])
+
+	for _, annotation := range []string{
+		"availability,99.9",
+		"availability,high,30d",
+		"availability,100,30d",
+		"availability,99.9,a month",
+		"latency,99,7d",
+		"latency,99,7d,300",
+		"throughput,99,7d",
+	} {
+		_, err := ParseSLOHealthAnnotation(annotation)
+		assert.Error(err, annotation)
+	}
+}
+
+func TestGetServiceSLOs(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.HealthConfig.SLOs = []config.SLO{
+		{Namespace: "bookinfo", Service: "^reviews$", Type: SLOTypeAvailability, Target: 99.9, Window: "30d"},
+		{Name: "fast", Namespace: "bookinfo", Type: SLOTypeLatency, Target: 99, Window: "7d", LatencyThreshold: 250},
+		{Name: "invalid", Type: SLOTypeLatency, Target: 99, Window: "7d"},
+	}
+	config.Set(conf)
+	defer config.Set(config.NewConfig())
+
+	slos := GetServiceSLOs("bookinfo", "reviews", map[string]string{})
+	if assert.Len(slos, 2) {
+		assert.Equal("availability-30d", slos[0].Name)
+		assert.Equal("fast", slos[1].Name)
+	}
+
+	slos = GetServiceSLOs("bookinfo", "ratings", map[string]string{})
+	if assert.Len(slos, 1) {
+		assert.Equal("fast", slos[0].Name)
+	}
+
+	assert.Empty(GetServiceSLOs("tutorial", "reviews", map[string]string{}))
+
+	// The annotation replaces the SLOs of the config
+	slos = GetServiceSLOs("bookinfo", "reviews", map[string]string{string(SLOHealthAnnotation): "availability,99,1d"})
+	if assert.Len(slos, 1) {
+		assert.Equal("availability-1d", slos[0].Name)
+	}
+}
+
+func TestServiceHealthMergeSLOs(t *testing.T) {
+	assert := assert.New(t)
+
+	health := EmptyServiceHealth()
+	health.EvaluateStatus("bookinfo", "reviews")
+	require.Equal(t, HealthStatusNA, health.Status.Status)
+
+	slo := config.SLO{Name: "availability-30d", Type: SLOTypeAvailability, Target: 99, Window: "30d"}
+	health.MergeSLOs([]SLOStatus{NewSLOStatus(slo, 99.5, nil)})
+	assert.Equal(HealthStatusNA, health.Status.Status)
+	assert.Empty(health.Status.Reasons)
+
+	health.MergeSLOs([]SLOStatus{NewSLOStatus(slo, 98.5, nil)})
+	assert.Equal(HealthStatusFailure, health.Status.Status)
+	if assert.Len(health.Status.Reasons, 1) {
+		assert.Equal("availability-30d", health.Status.Reasons[0].SLO)
+		assert.Equal("SLO availability-30d error budget exhausted, SLI 98.500% < 99% over 30d", health.Status.Reasons[0].Message)
+	}
+}
@@ -33,6 +33,7 @@ type ClientInterface interface {
 	GetAppRequestRates(namespace, cluster, app, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
 	GetConfiguration() (prom_v1.ConfigResult, error)
 	GetFlags() (prom_v1.FlagsResult, error)
+	GetNamespaceServicesLatencyRates(namespace, cluster, threshold, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
 	GetNamespaceServicesRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error)
 	GetServiceRequestRates(namespace, cluster, service, ratesInterval string, queryTime time.Time) (model.Vector, error)
 	GetWorkloadRequestRates(namespace, cluster, workload, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
@@ -164,6 +165,15 @@ func (in *Client) GetNamespaceServicesRequestRates(namespace, cluster string, ra
 	return result, nil
 }
 
+// GetNamespaceServicesLatencyRates queries Prometheus to fetch, by destination_service_name, the rates of the requests
+// of the namespace services answered within the threshold, and the rates of all their requests, over a time interval.
+// The threshold, in milliseconds, must be one of the buckets of the request duration histogram.
+// Returns (within threshold, all, error)
+func (in *Client) GetNamespaceServicesLatencyRates(namespace, cluster, threshold, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error) {
+	log.Tracef("GetNamespaceServicesLatencyRates [namespace: %s] [threshold: %s] [ratesInterval: %s] [queryTime: %s]", namespace, threshold, ratesInterval, queryTime.String())
+	return getNamespaceServicesLatencyRates(in.ctx, in.api, namespace, cluster, threshold, queryTime, ratesInterval)
+}
+
 // GetServiceRequestRates queries Prometheus to fetch request counters rates over a time interval
 // for a given service (hence only inbound). Note that it does not discriminate on "reporter", so rates can
 // be inflated due to duplication, and therefore should be used mainly for calculating ratios
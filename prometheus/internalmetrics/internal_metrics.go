@@ -23,6 +23,10 @@ const (
 	labelService          = "service"
 	labelType             = "type"
 	labelName             = "name"
+	labelCluster          = "cluster"
+	labelKind             = "kind"
+	labelSeverity         = "severity"
+	labelStatus           = "status"
 )
 
 // MetricsType defines all of Kiali's own internal metrics.
@@ -38,6 +42,10 @@ type MetricsType struct {
 	CheckerProcessingTime          *prometheus.HistogramVec
 	ValidationProcessingTime       *prometheus.HistogramVec
 	SingleValidationProcessingTime *prometheus.HistogramVec
+	IstioConfigObjects             *prometheus.GaugeVec
+	IstioConfigValidations         *prometheus.GaugeVec
+	HealthStatus                   *prometheus.GaugeVec
+	HealthStatusItems              *prometheus.GaugeVec
 }
 
 // Metrics contains all of Kiali's own internal metrics.
@@ -121,6 +129,34 @@ var Metrics = MetricsType{
 		},
 		[]string{labelNamespace, labelType, labelName},
 	),
+	IstioConfigObjects: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_istio_config_objects",
+			Help: "The number of Istio objects validated in a namespace.",
+		},
+		[]string{labelCluster, labelNamespace},
+	),
+	IstioConfigValidations: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_istio_config_validations",
+			Help: "The number of Istio config validations of a severity in a namespace.",
+		},
+		[]string{labelCluster, labelNamespace, labelSeverity},
+	),
+	HealthStatus: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_health_status",
+			Help: "The health status of an app, service or workload, set to 1 for its current status.",
+		},
+		[]string{labelCluster, labelNamespace, labelKind, labelName, labelStatus},
+	),
+	HealthStatusItems: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_health_status_items",
+			Help: "The number of apps, services or workloads of a namespace with a health status.",
+		},
+		[]string{labelCluster, labelNamespace, labelKind, labelStatus},
+	),
 }
 
 // SuccessOrFailureMetricType let's you capture metrics for both successes and failures,
@@ -182,6 +218,10 @@ func RegisterInternalMetrics() {
 		Metrics.CheckerProcessingTime,
 		Metrics.ValidationProcessingTime,
 		Metrics.SingleValidationProcessingTime,
+		Metrics.IstioConfigObjects,
+		Metrics.IstioConfigValidations,
+		Metrics.HealthStatus,
+		Metrics.HealthStatusItems,
 	)
 }
 
@@ -361,3 +401,75 @@ func GetAPIFailureMetric(route string) prometheus.Counter {
 func SetKubernetesClients(clientCount int) {
 	Metrics.KubernetesClients.With(prometheus.Labels{}).Set(float64(clientCount))
 }
+
+// SetIstioConfigValidations sets the number of Istio objects and the number of validations per severity of a namespace
+func SetIstioConfigValidations(cluster, namespace string, objects, errors, warnings int) {
+	Metrics.IstioConfigObjects.With(prometheus.Labels{
+		labelCluster:   cluster,
+		labelNamespace: namespace,
+	}).Set(float64(objects))
+	for severity, count := range map[string]int{"error": errors, "warning": warnings} {
+		Metrics.IstioConfigValidations.With(prometheus.Labels{
+			labelCluster:   cluster,
+			labelNamespace: namespace,
+			labelSeverity:  severity,
+		}).Set(float64(count))
+	}
+}
+
+// SetHealthStatus sets the current health status of an app, service or workload
+func SetHealthStatus(cluster, namespace, kind, name, status string) {
+	Metrics.HealthStatus.With(prometheus.Labels{
+		labelCluster:   cluster,
+		labelNamespace: namespace,
+		labelKind:      kind,
+		labelName:      name,
+		labelStatus:    status,
+	}).Set(1)
+}
+
+// SetHealthStatusItems sets the number of apps, services or workloads of a namespace with a health status
+func SetHealthStatusItems(cluster, namespace, kind, status string, count int) {
+	Metrics.HealthStatusItems.With(prometheus.Labels{
+		labelCluster:   cluster,
+		labelNamespace: namespace,
+		labelKind:      kind,
+		labelStatus:    status,
+	}).Set(float64(count))
+}
+
+// DeleteIstioConfigValidations deletes the Istio objects and validations gauges of a namespace
+func DeleteIstioConfigValidations(cluster, namespace string) {
+	Metrics.IstioConfigObjects.Delete(prometheus.Labels{
+		labelCluster:   cluster,
+		labelNamespace: namespace,
+	})
+	for _, severity := range []string{"error", "warning"} {
+		Metrics.IstioConfigValidations.Delete(prometheus.Labels{
+			labelCluster:   cluster,
+			labelNamespace: namespace,
+			labelSeverity:  severity,
+		})
+	}
+}
+
+// DeleteHealthStatus deletes the health status gauge of an app, service or workload
+func DeleteHealthStatus(cluster, namespace, kind, name, status string) {
+	Metrics.HealthStatus.Delete(prometheus.Labels{
+		labelCluster:   cluster,
+		labelNamespace: namespace,
+		labelKind:      kind,
+		labelName:      name,
+		labelStatus:    status,
+	})
+}
+
+// DeleteHealthStatusItems deletes the gauge of the number of apps, services or workloads of a namespace with a health status
+func DeleteHealthStatusItems(cluster, namespace, kind, status string) {
+	Metrics.HealthStatusItems.Delete(prometheus.Labels{
+		labelCluster:   cluster,
+		labelNamespace: namespace,
+		labelKind:      kind,
+		labelStatus:    status,
+	})
+}
@@ -141,6 +141,33 @@ func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, name
 	return ns, nil
 }
 
+// getNamespaceServicesLatencyRates retrieves, by service, the rates of the requests answered within the threshold and
+// the rates of all the requests, for the services of the namespace
+func getNamespaceServicesLatencyRates(ctx context.Context, api prom_v1.API, namespace, cluster, threshold string, queryTime time.Time, ratesInterval string) (model.Vector, model.Vector, error) {
+	lbl := fmt.Sprintf(`reporter="destination",destination_service_namespace="%s",destination_cluster="%s"`, namespace, cluster)
+	within, err := getSumRatesForQuery(ctx, api, queryTime, fmt.Sprintf(`sum(rate(istio_request_duration_milliseconds_bucket{%s,le="%s"}[%s])) by (destination_service_name)`, lbl, threshold, ratesInterval))
+	if err != nil {
+		return model.Vector{}, model.Vector{}, err
+	}
+	all, err := getSumRatesForQuery(ctx, api, queryTime, fmt.Sprintf(`sum(rate(istio_request_duration_milliseconds_count{%s}[%s])) by (destination_service_name)`, lbl, ratesInterval))
+	if err != nil {
+		return model.Vector{}, model.Vector{}, err
+	}
+	return within, all, nil
+}
+
+func getSumRatesForQuery(ctx context.Context, api prom_v1.API, time time.Time, query string) (model.Vector, error) {
+	log.Tracef("[Prom] getSumRatesForQuery: %s", query)
+	result, warnings, err := api.Query(ctx, query, time)
+	if len(warnings) > 0 {
+		log.Warningf("getSumRatesForQuery. Prometheus Warnings: [%s]", strings.Join(warnings, ","))
+	}
+	if err != nil {
+		return model.Vector{}, errors.NewServiceUnavailable(err.Error())
+	}
+	return result.(model.Vector), nil
+}
+
 // getServiceRequestRates retrieves traffic rates for requests entering, or internal to the namespace, for a specific service name
 // Note that it does not discriminate on "reporter", so rates can be inflated due to duplication, and therefore
 // should be used mainly for calculating ratios (e.g total rates / error rates)
@@ -314,6 +314,11 @@ func (o *PromClientMock) GetFlags() (prom_v1.FlagsResult, error) {
 	return args.Get(0).(prom_v1.FlagsResult), args.Error(1)
 }
 
+func (o *PromClientMock) GetNamespaceServicesLatencyRates(namespace, cluster, threshold, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error) {
+	args := o.Called(namespace, cluster, threshold, ratesInterval, queryTime)
+	return args.Get(0).(model.Vector), args.Get(1).(model.Vector), args.Error(2)
+}
+
 func (o *PromClientMock) GetNamespaceServicesRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error) {
 	args := o.Called(namespace, cluster, ratesInterval, queryTime)
 	return args.Get(0).(model.Vector), args.Error(1)
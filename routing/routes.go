@@ -303,6 +303,9 @@ func NewRoutes() (r *Routes) {
 		// swagger:route PATCH /namespaces/{namespace}/istio/{object_type}/{object} config istioConfigUpdate
 		// ---
 		// Endpoint to update the Istio Config of an Istio object used for templates and adapters using Json Merge Patch strategy.
+		// With dryRun, the updated object is validated and returned without persisting it. When the validations reject
+		// changes on error, an update introducing validation errors, to the object or to the other objects of its
+		// namespace, fails with a bad request error listing them.
 		//
 		//     Consumes:
 		//	   - application/json
@@ -328,6 +331,9 @@ func NewRoutes() (r *Routes) {
 		// swagger:route POST /namespaces/{namespace}/istio/{object_type} config istioConfigCreate
 		// ---
 		// Endpoint to create an Istio object by using an Istio Config item
+		// With dryRun, the object is validated and returned without persisting it. When the validations reject
+		// changes on error, a create introducing validation errors, to the object or to the other objects of its
+		// namespace, fails with a bad request error listing them.
 		//
 		//     Produces:
 		//     - application/json
@@ -335,6 +341,7 @@ func NewRoutes() (r *Routes) {
 		//     Schemes: http, https
 		//
 		// responses:
+		//      400: badRequestError
 		//      404: notFoundError
 		//      500: internalError
 		//		202
@@ -642,6 +649,27 @@ func NewRoutes() (r *Routes) {
 			handlers.WorkloadUpdate,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/authorization workloads workloadAuthorization
+		// ---
+		// Endpoint to evaluate the AuthorizationPolicies applying to a workload for a given request. It returns the decision and the policies and rules that produced it.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      200: accessDecisionResponse
+		//
+		{
+			"WorkloadAuthorization",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/authorization",
+			handlers.WorkloadAuthorization,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/apps apps appList
 		// ---
 		// Endpoint to get the list of apps for a namespace
@@ -938,12 +966,80 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceHealth,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/health/history namespaces namespaceHealthHistory
+		// ---
+		// Get the health history of all objects in the given namespace, as the intervals of their health status over a time range
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: namespaceHealthHistoryResponse
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//
+		{
+			"NamespaceHealthHistory",
+			"GET",
+			"/api/namespaces/{namespace}/health/history",
+			handlers.NamespaceHealthHistory,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/slos namespaces namespaceSLOs
+		// ---
+		// Get the SLO statuses of the services of the given namespace, with their error budgets and burn rates
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: namespaceSLOsResponse
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//
+		{
+			"NamespaceSLOs",
+			"GET",
+			"/api/namespaces/{namespace}/slos",
+			handlers.NamespaceSLOs,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/slos services serviceSLOs
+		// ---
+		// Get the SLO statuses of the given service, with their error budgets and burn rates
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: serviceSLOsResponse
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//
+		{
+			"ServiceSLOs",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/slos",
+			handlers.ServiceSLOs,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/validations namespaces namespaceValidations
 		// ---
-		// Get validation summary for all objects in the given namespace
+		// Get validation summary for all objects in the given namespace, or a SARIF or JUnit report of their validations
 		//
 		//     Produces:
 		//     - application/json
+		//     - application/sarif+json
+		//     - application/xml
 		//
 		//     Schemes: http, https
 		//
@@ -961,10 +1057,12 @@ func NewRoutes() (r *Routes) {
 		},
 		// swagger:route GET /istio/validations namespaces namespacesValidations
 		// ---
-		// Get validation summary for all objects in the given namespaces
+		// Get validation summary for all objects in the given namespaces, or a SARIF or JUnit report of their validations
 		//
 		//     Produces:
 		//     - application/json
+		//     - application/sarif+json
+		//     - application/xml
 		//
 		//     Schemes: http, https
 		//
@@ -1084,6 +1182,94 @@ func NewRoutes() (r *Routes) {
 			handlers.GraphNamespaces,
 			true,
 		},
+		// swagger:route GET /namespaces/graph/stream graphs graphNamespacesStream
+		// ---
+		// A stream of Server-Sent Events for a namespaces graph. The first "graph" event holds the full graph, subsequent "delta" events hold the node and edge changes.
+		// The graph is regenerated every refreshInterval, once for all clients requesting the same options.
+		//
+		//     Produces:
+		//     - text/event-stream
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: graphResponse
+		//
+		{
+			"GraphNamespacesStream",
+			"GET",
+			"/api/namespaces/graph/stream",
+			handlers.GraphNamespacesStream,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/snapshot graphs graphNamespacesSnapshot
+		// ---
+		// A namespaces graph snapshot, holding the graph along with the options and telemetry details used to generate it, for offline replay.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: graphSnapshotResponse
+		//
+		{
+			"GraphNamespacesSnapshot",
+			"GET",
+			"/api/namespaces/graph/snapshot",
+			handlers.GraphNamespacesSnapshot,
+			true,
+		},
+		// swagger:route POST /namespaces/graph/snapshot graphs graphSnapshotReplay
+		// ---
+		// The backing JSON for a previously exported namespaces graph snapshot, supplied as the request body.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      200: graphResponse
+		//
+		{
+			"GraphSnapshotReplay",
+			"POST",
+			"/api/namespaces/graph/snapshot",
+			handlers.GraphSnapshotReplay,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/path graphs graphPath
+		// ---
+		// The backing JSON for the graph of all traffic paths from a source node to a dest node, empty if there is no path. The edges provide the per-hop protocol and rates. (supported graphTypes: versionedApp | workload)
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: graphResponse
+		//
+		{
+			"GraphPath",
+			"GET",
+			"/api/namespaces/graph/path",
+			handlers.GraphPath,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/aggregates/{aggregate}/{aggregateValue}/graph graphs graphAggregate
 		// ---
 		// The backing JSON for an aggregate node detail graph. (supported graphTypes: app | versionedApp | workload)
@@ -1213,6 +1399,28 @@ func NewRoutes() (r *Routes) {
 			handlers.GraphNode,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/impact graphs graphWorkloadImpact
+		// ---
+		// The impact analysis for a workload: its transitive upstream callers (blast radius), its transitive downstream dependencies and its critical (highest-latency) path. (supported graphTypes: versionedApp | workload)
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: graphImpactResponse
+		//
+		{
+			"GraphWorkloadImpact",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/impact",
+			handlers.GraphWorkloadImpact,
+			true,
+		},
 		// swagger:route GET /grafana integrations grafanaInfo
 		// ---
 		// Get the grafana URL and other descriptors
@@ -26,10 +26,15 @@ func StartMetricsServer() {
 	go func() {
 		log.Warning(metricsServer.ListenAndServe())
 	}()
+
+	if conf.Server.Observability.Metrics.Status.Enabled {
+		StartStatusMetrics()
+	}
 }
 
 // StopMetricsServer stops the metrics server
 func StopMetricsServer() {
+	StopStatusMetrics()
 	if metricsServer != nil {
 		log.Info("Stopping Metrics Server")
 		metricsServer.Close()
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+	"github.com/kiali/kiali/util"
+)
+
+const defaultStatusMetricsRefreshInterval = 60 * time.Second
+
+var statusMetricsStop chan struct{}
+
+// namespaceStatus is the validation summary and the health statuses, keyed by kind and name, of a namespace. A nil
+// part has never been collected successfully.
+type namespaceStatus struct {
+	validations *models.IstioValidationSummary
+	health      map[string]map[string]models.HealthStatus
+}
+
+// validationsLabels are the labels of the validation gauges of a namespace
+type validationsLabels struct {
+	cluster, namespace string
+}
+
+// healthStatusItemsLabels are the labels of a gauge of the number of apps, services or workloads with a health status
+type healthStatusItemsLabels struct {
+	cluster, namespace, kind, status string
+}
+
+// healthStatusLabels are the labels of a health status gauge of an app, service or workload
+type healthStatusLabels struct {
+	cluster, namespace, kind, name, status string
+}
+
+// statusMetricsLabels are the label sets of the status gauges that are published
+type statusMetricsLabels struct {
+	validations       map[validationsLabels]bool
+	healthStatusItems map[healthStatusItemsLabels]bool
+	healthStatus      map[healthStatusLabels]bool
+}
+
+// statusMetricsCollector keeps the last good status of every namespace, so that a namespace failing to be collected
+// keeps its previous gauges, and the published label sets, so that only the stale gauges are deleted
+type statusMetricsCollector struct {
+	statuses  map[string]namespaceStatus
+	published statusMetricsLabels
+}
+
+func newStatusMetricsCollector() *statusMetricsCollector {
+	return &statusMetricsCollector{
+		statuses:  map[string]namespaceStatus{},
+		published: newStatusMetricsLabels(),
+	}
+}
+
+func newStatusMetricsLabels() statusMetricsLabels {
+	return statusMetricsLabels{
+		validations:       map[validationsLabels]bool{},
+		healthStatusItems: map[healthStatusItemsLabels]bool{},
+		healthStatus:      map[healthStatusLabels]bool{},
+	}
+}
+
+// StartStatusMetrics starts the background collector publishing the validation summaries and the health statuses of
+// the namespaces as gauges of the metrics server
+func StartStatusMetrics() {
+	conf := config.Get().Server.Observability.Metrics.Status
+	interval, err := time.ParseDuration(conf.RefreshInterval)
+	if err != nil || interval <= 0 {
+		log.Warningf("Invalid refresh interval [%s] for the status metrics, using %s", conf.RefreshInterval, defaultStatusMetricsRefreshInterval)
+		interval = defaultStatusMetricsRefreshInterval
+	}
+
+	log.Infof("Starting the status metrics collector, refreshed every %s", interval)
+	stop := make(chan struct{})
+	statusMetricsStop = stop
+	collector := newStatusMetricsCollector()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			collector.collect(conf)
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopStatusMetrics stops the status metrics collector
+func StopStatusMetrics() {
+	if statusMetricsStop != nil {
+		log.Info("Stopping the status metrics collector")
+		close(statusMetricsStop)
+		statusMetricsStop = nil
+	}
+}
+
+// collect computes the status of the namespaces with the Kiali ServiceAccount and publishes it. The validations and
+// the health of a namespace are collected separately, the part failing to be collected keeps its last good status.
+// The gauges are left untouched when the namespaces can't be listed.
+func (c *statusMetricsCollector) collect(conf config.StatusMetrics) {
+	layer, err := business.GetWithSAClients()
+	if err != nil {
+		log.Errorf("Unable to collect the status metrics: %s", err)
+		return
+	}
+
+	ctx := context.Background()
+	cluster := config.Get().KubernetesConfig.ClusterName
+	namespaces := conf.Namespaces
+	if len(namespaces) == 0 {
+		nss, err := layer.Namespace.GetNamespaces(ctx)
+		if err != nil {
+			log.Errorf("Unable to collect the status metrics, error fetching the namespaces: %s", err)
+			return
+		}
+		for _, ns := range nss {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	namespaces = limitNames(namespaces, conf.MaxNamespaces, "namespaces of the status metrics")
+
+	statuses := make(map[string]namespaceStatus, len(namespaces))
+	for _, namespace := range namespaces {
+		status := c.statuses[namespace]
+		if validations, err := collectNamespaceValidations(ctx, layer, cluster, namespace); err != nil {
+			log.Errorf("Unable to collect the validations of namespace [%s] for the status metrics: %s", namespace, err)
+		} else {
+			status.validations = validations
+		}
+		if health, err := collectNamespaceHealth(ctx, layer, cluster, namespace, conf.RateInterval); err != nil {
+			log.Errorf("Unable to collect the health of namespace [%s] for the status metrics: %s", namespace, err)
+		} else {
+			status.health = health
+		}
+		statuses[namespace] = status
+	}
+	c.statuses = statuses
+	c.publish(cluster, conf.MaxItemsPerNamespace)
+}
+
+func collectNamespaceValidations(ctx context.Context, layer *business.Layer, cluster, namespace string) (*models.IstioValidationSummary, error) {
+	validations, err := layer.Validations.GetValidations(ctx, cluster, namespace, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return validations.SummarizeValidation(namespace), nil
+}
+
+func collectNamespaceHealth(ctx context.Context, layer *business.Layer, cluster, namespace, rateInterval string) (map[string]map[string]models.HealthStatus, error) {
+	health := make(map[string]map[string]models.HealthStatus, 3)
+	criteria := business.NamespaceHealthCriteria{Namespace: namespace, Cluster: cluster, RateInterval: rateInterval, QueryTime: util.Clock.Now(), IncludeMetrics: true}
+	appHealth, err := layer.Health.GetNamespaceAppHealth(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	health[models.HealthKindApp] = make(map[string]models.HealthStatus, len(appHealth))
+	for name, h := range appHealth {
+		health[models.HealthKindApp][name] = h.Status.Status
+	}
+	serviceHealth, err := layer.Health.GetNamespaceServiceHealth(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	health[models.HealthKindService] = make(map[string]models.HealthStatus, len(serviceHealth))
+	for name, h := range serviceHealth {
+		health[models.HealthKindService][name] = h.Status.Status
+	}
+	workloadHealth, err := layer.Health.GetNamespaceWorkloadHealth(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	health[models.HealthKindWorkload] = make(map[string]models.HealthStatus, len(workloadHealth))
+	for name, h := range workloadHealth {
+		health[models.HealthKindWorkload][name] = h.Status.Status
+	}
+	return health, nil
+}
+
+// publish sets the gauges with the statuses of the namespaces, then deletes the gauges of the label sets that are not
+// published anymore, so that the gauges are never missing while being updated.
+func (c *statusMetricsCollector) publish(cluster string, maxItems int) {
+	published := newStatusMetricsLabels()
+	for namespace, status := range c.statuses {
+		if status.validations != nil {
+			publishValidations(cluster, namespace, status.validations, published)
+		}
+		if status.health != nil {
+			publishHealth(cluster, namespace, status.health, maxItems, published)
+		}
+	}
+
+	for labels := range c.published.validations {
+		if !published.validations[labels] {
+			internalmetrics.DeleteIstioConfigValidations(labels.cluster, labels.namespace)
+		}
+	}
+	for labels := range c.published.healthStatusItems {
+		if !published.healthStatusItems[labels] {
+			internalmetrics.DeleteHealthStatusItems(labels.cluster, labels.namespace, labels.kind, labels.status)
+		}
+	}
+	for labels := range c.published.healthStatus {
+		if !published.healthStatus[labels] {
+			internalmetrics.DeleteHealthStatus(labels.cluster, labels.namespace, labels.kind, labels.name, labels.status)
+		}
+	}
+	c.published = published
+}
+
+func publishValidations(cluster, namespace string, validations *models.IstioValidationSummary, published statusMetricsLabels) {
+	internalmetrics.SetIstioConfigValidations(cluster, namespace, validations.ObjectCount, validations.Errors, validations.Warnings)
+	published.validations[validationsLabels{cluster: cluster, namespace: namespace}] = true
+}
+
+// publishHealth sets the health gauges of a namespace. To limit the cardinality, only the first maxItems apps, services
+// and workloads have a health status gauge, the counts per status include all of them.
+func publishHealth(cluster, namespace string, health map[string]map[string]models.HealthStatus, maxItems int, published statusMetricsLabels) {
+	for kind, statuses := range health {
+		names := make([]string, 0, len(statuses))
+		counts := map[models.HealthStatus]int{}
+		for name, healthStatus := range statuses {
+			names = append(names, name)
+			counts[healthStatus]++
+		}
+		for healthStatus, count := range counts {
+			internalmetrics.SetHealthStatusItems(cluster, namespace, kind, string(healthStatus), count)
+			published.healthStatusItems[healthStatusItemsLabels{cluster: cluster, namespace: namespace, kind: kind, status: string(healthStatus)}] = true
+		}
+		for _, name := range limitNames(names, maxItems, kind+"s of namespace ["+namespace+"] with a health status metric") {
+			internalmetrics.SetHealthStatus(cluster, namespace, kind, name, string(statuses[name]))
+			published.healthStatus[healthStatusLabels{cluster: cluster, namespace: namespace, kind: kind, name: name, status: string(statuses[name])}] = true
+		}
+	}
+}
+
+// limitNames returns the first names in alphabetical order, all of them when the limit is not positive
+func limitNames(names []string, limit int, what string) []string {
+	sort.Strings(names)
+	if limit > 0 && len(names) > limit {
+		log.Debugf("Only the first %d of the %d %s are collected", limit, len(names), what)
+		return names[:limit]
+	}
+	return names
+}
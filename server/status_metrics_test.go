@@ -0,0 +1,83 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+func TestPublishStatusMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	collector := newStatusMetricsCollector()
+	collector.statuses = map[string]namespaceStatus{
+		"bookinfo": {
+			validations: &models.IstioValidationSummary{ObjectCount: 6, Errors: 2, Warnings: 1},
+			health: map[string]map[string]models.HealthStatus{
+				models.HealthKindApp: {
+					"details":     models.HealthStatusHealthy,
+					"productpage": models.HealthStatusHealthy,
+					"reviews":     models.HealthStatusDegraded,
+				},
+			},
+		},
+		// The health of travels has never been collected
+		"travels": {
+			validations: &models.IstioValidationSummary{ObjectCount: 3},
+		},
+	}
+	collector.publish("east", 2)
+
+	assert.Equal(2, testutil.CollectAndCount(internalmetrics.Metrics.IstioConfigObjects))
+	assert.Equal(float64(6), testutil.ToFloat64(internalmetrics.Metrics.IstioConfigObjects.WithLabelValues("east", "bookinfo")))
+	assert.Equal(float64(2), testutil.ToFloat64(internalmetrics.Metrics.IstioConfigValidations.WithLabelValues("east", "bookinfo", "error")))
+	assert.Equal(float64(1), testutil.ToFloat64(internalmetrics.Metrics.IstioConfigValidations.WithLabelValues("east", "bookinfo", "warning")))
+	assert.Equal(float64(3), testutil.ToFloat64(internalmetrics.Metrics.IstioConfigObjects.WithLabelValues("east", "travels")))
+
+	// The counts include all the apps, the status gauges only the first ones
+	assert.Equal(2, testutil.CollectAndCount(internalmetrics.Metrics.HealthStatusItems))
+	assert.Equal(float64(2), testutil.ToFloat64(internalmetrics.Metrics.HealthStatusItems.WithLabelValues("east", "bookinfo", "app", "Healthy")))
+	assert.Equal(float64(1), testutil.ToFloat64(internalmetrics.Metrics.HealthStatusItems.WithLabelValues("east", "bookinfo", "app", "Degraded")))
+	assert.Equal(2, testutil.CollectAndCount(internalmetrics.Metrics.HealthStatus))
+	assert.Equal(float64(1), testutil.ToFloat64(internalmetrics.Metrics.HealthStatus.WithLabelValues("east", "bookinfo", "app", "details", "Healthy")))
+
+	// Only the stale label sets are deleted
+	collector.statuses = map[string]namespaceStatus{
+		"bookinfo": {
+			validations: &models.IstioValidationSummary{ObjectCount: 6, Errors: 2, Warnings: 1},
+			health: map[string]map[string]models.HealthStatus{
+				models.HealthKindApp: {
+					"productpage": models.HealthStatusHealthy,
+					"reviews":     models.HealthStatusHealthy,
+				},
+			},
+		},
+	}
+	collector.publish("east", 2)
+
+	assert.Equal(1, testutil.CollectAndCount(internalmetrics.Metrics.IstioConfigObjects))
+	assert.Equal(2, testutil.CollectAndCount(internalmetrics.Metrics.IstioConfigValidations))
+	assert.Equal(1, testutil.CollectAndCount(internalmetrics.Metrics.HealthStatusItems))
+	assert.Equal(float64(2), testutil.ToFloat64(internalmetrics.Metrics.HealthStatusItems.WithLabelValues("east", "bookinfo", "app", "Healthy")))
+	assert.Equal(2, testutil.CollectAndCount(internalmetrics.Metrics.HealthStatus))
+	assert.Equal(float64(1), testutil.ToFloat64(internalmetrics.Metrics.HealthStatus.WithLabelValues("east", "bookinfo", "app", "reviews", "Healthy")))
+
+	// The namespaces that are not collected anymore are removed
+	collector.statuses = map[string]namespaceStatus{}
+	collector.publish("east", 2)
+	assert.Equal(0, testutil.CollectAndCount(internalmetrics.Metrics.HealthStatus))
+	assert.Equal(0, testutil.CollectAndCount(internalmetrics.Metrics.HealthStatusItems))
+	assert.Equal(0, testutil.CollectAndCount(internalmetrics.Metrics.IstioConfigObjects))
+	assert.Equal(0, testutil.CollectAndCount(internalmetrics.Metrics.IstioConfigValidations))
+}
+
+func TestLimitNames(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"a", "b"}, limitNames([]string{"c", "a", "b"}, 2, "names"))
+	assert.Equal([]string{"a", "b", "c"}, limitNames([]string{"c", "a", "b"}, 0, "names"))
+}
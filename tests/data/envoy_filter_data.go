@@ -0,0 +1,49 @@
+package data
+
+import (
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+)
+
+func CreateEnvoyFilter(name string, namespace string, selector map[string]string) *networking_v1alpha3.EnvoyFilter {
+	ef := networking_v1alpha3.EnvoyFilter{}
+	ef.Name = name
+	ef.Namespace = namespace
+	if len(selector) > 0 {
+		ef.Spec.WorkloadSelector = &api_networking_v1alpha3.WorkloadSelector{
+			Labels: selector,
+		}
+	}
+	return &ef
+}
+
+func AddListenerPatchToEnvoyFilter(operation api_networking_v1alpha3.EnvoyFilter_Patch_Operation, listener *api_networking_v1alpha3.EnvoyFilter_ListenerMatch, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	ef.Spec.ConfigPatches = append(ef.Spec.ConfigPatches, &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: api_networking_v1alpha3.EnvoyFilter_LISTENER,
+		Match: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+			Context:     api_networking_v1alpha3.EnvoyFilter_SIDECAR_OUTBOUND,
+			ObjectTypes: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Listener{Listener: listener},
+		},
+		Patch: &api_networking_v1alpha3.EnvoyFilter_Patch{Operation: operation},
+	})
+	return ef
+}
+
+func AddClusterPatchToEnvoyFilter(operation api_networking_v1alpha3.EnvoyFilter_Patch_Operation, cluster *api_networking_v1alpha3.EnvoyFilter_ClusterMatch, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	ef.Spec.ConfigPatches = append(ef.Spec.ConfigPatches, &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: api_networking_v1alpha3.EnvoyFilter_CLUSTER,
+		Match: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+			Context:     api_networking_v1alpha3.EnvoyFilter_SIDECAR_OUTBOUND,
+			ObjectTypes: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Cluster{Cluster: cluster},
+		},
+		Patch: &api_networking_v1alpha3.EnvoyFilter_Patch{Operation: operation},
+	})
+	return ef
+}
+
+func AddProxyVersionToEnvoyFilter(proxyVersion string, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	for _, patch := range ef.Spec.ConfigPatches {
+		patch.Match.Proxy = &api_networking_v1alpha3.EnvoyFilter_ProxyMatch{ProxyVersion: proxyVersion}
+	}
+	return ef
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiali/kiali/kubernetes"
@@ -100,3 +101,89 @@ func UpdateConditionWithError(k8sgw *k8s_networking_v1beta1.Gateway) *k8s_networ
 
 	return k8sgw
 }
+
+func CreateGRPCRoute(name, namespace, gateway string) *k8s_networking_v1alpha2.GRPCRoute {
+	rt := k8s_networking_v1alpha2.GRPCRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, CreateParentRef(gateway, namespace))
+	return &rt
+}
+
+func AddBackendRefToGRPCRoute(name, namespace string, rt *k8s_networking_v1alpha2.GRPCRoute) *k8s_networking_v1alpha2.GRPCRoute {
+	rule := k8s_networking_v1alpha2.GRPCRouteRule{}
+	rule.BackendRefs = append(rule.BackendRefs, k8s_networking_v1alpha2.GRPCBackendRef{BackendRefs: CreateBackendRef(name, namespace)})
+	rt.Spec.Rules = append(rt.Spec.Rules, rule)
+	return rt
+}
+
+func CreateTCPRoute(name, namespace, gateway string) *k8s_networking_v1alpha2.TCPRoute {
+	rt := k8s_networking_v1alpha2.TCPRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, CreateParentRef(gateway, namespace))
+	return &rt
+}
+
+func AddBackendRefToTCPRoute(name, namespace string, rt *k8s_networking_v1alpha2.TCPRoute) *k8s_networking_v1alpha2.TCPRoute {
+	rule := k8s_networking_v1alpha2.TCPRouteRule{}
+	rule.BackendRefs = append(rule.BackendRefs, CreateBackendRef(name, namespace))
+	rt.Spec.Rules = append(rt.Spec.Rules, rule)
+	return rt
+}
+
+func CreateTLSRoute(name, namespace, gateway string) *k8s_networking_v1alpha2.TLSRoute {
+	rt := k8s_networking_v1alpha2.TLSRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, CreateParentRef(gateway, namespace))
+	return &rt
+}
+
+func AddBackendRefToTLSRoute(name, namespace string, rt *k8s_networking_v1alpha2.TLSRoute) *k8s_networking_v1alpha2.TLSRoute {
+	rule := k8s_networking_v1alpha2.TLSRouteRule{}
+	rule.BackendRefs = append(rule.BackendRefs, CreateBackendRef(name, namespace))
+	rt.Spec.Rules = append(rt.Spec.Rules, rule)
+	return rt
+}
+
+func CreateParentRef(name, namespace string) k8s_networking_v1alpha2.ParentReference {
+	ns := k8s_networking_v1alpha2.Namespace(namespace)
+	group := k8s_networking_v1alpha2.Group(kubernetes.K8sNetworkingGroupVersionV1Alpha2.Group)
+	kind := k8s_networking_v1alpha2.Kind(kubernetes.K8sActualGatewayType)
+	return k8s_networking_v1alpha2.ParentReference{
+		Name:      k8s_networking_v1alpha2.ObjectName(name),
+		Namespace: &ns,
+		Group:     &group,
+		Kind:      &kind,
+	}
+}
+
+func CreateBackendRef(name, namespace string) k8s_networking_v1alpha2.BackendRef {
+	kind := k8s_networking_v1alpha2.Kind("Service")
+	ns := k8s_networking_v1alpha2.Namespace(namespace)
+	return k8s_networking_v1alpha2.BackendRef{
+		BackendObjectReference: k8s_networking_v1alpha2.BackendObjectReference{
+			Kind:      &kind,
+			Name:      k8s_networking_v1alpha2.ObjectName(name),
+			Namespace: &ns,
+		},
+	}
+}
+
+// CreateReferenceGrant returns a ReferenceGrant in the namespace allowing the routes of the kind from the other
+// namespace to refer to its Services
+func CreateReferenceGrant(name, namespace, fromNamespace, fromKind string) *k8s_networking_v1alpha2.ReferenceGrant {
+	rg := k8s_networking_v1alpha2.ReferenceGrant{}
+	rg.Name = name
+	rg.Namespace = namespace
+	rg.Spec.From = append(rg.Spec.From, k8s_networking_v1alpha2.ReferenceGrantFrom{
+		Group:     k8s_networking_v1alpha2.Group(kubernetes.K8sNetworkingGroupVersionV1Alpha2.Group),
+		Kind:      k8s_networking_v1alpha2.Kind(fromKind),
+		Namespace: k8s_networking_v1alpha2.Namespace(fromNamespace),
+	})
+	rg.Spec.To = append(rg.Spec.To, k8s_networking_v1alpha2.ReferenceGrantTo{
+		Kind: k8s_networking_v1alpha2.Kind("Service"),
+	})
+	return &rg
+}
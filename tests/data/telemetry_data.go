@@ -0,0 +1,33 @@
+package data
+
+import (
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+)
+
+func CreateTelemetry(name string, namespace string, selector map[string]string) *telemetry_v1alpha1.Telemetry {
+	t := telemetry_v1alpha1.Telemetry{}
+	t.Name = name
+	t.Namespace = namespace
+	if len(selector) > 0 {
+		t.Spec.Selector = &api_v1beta1.WorkloadSelector{
+			MatchLabels: selector,
+		}
+	}
+	return &t
+}
+
+func AddTracingProviderToTelemetry(provider string, t *telemetry_v1alpha1.Telemetry) *telemetry_v1alpha1.Telemetry {
+	t.Spec.Tracing = append(t.Spec.Tracing, &api_telemetry_v1alpha1.Tracing{
+		Providers: []*api_telemetry_v1alpha1.ProviderRef{{Name: provider}},
+	})
+	return t
+}
+
+func AddAccessLoggingProviderToTelemetry(provider string, t *telemetry_v1alpha1.Telemetry) *telemetry_v1alpha1.Telemetry {
+	t.Spec.AccessLogging = append(t.Spec.AccessLogging, &api_telemetry_v1alpha1.AccessLogging{
+		Providers: []*api_telemetry_v1alpha1.ProviderRef{{Name: provider}},
+	})
+	return t
+}
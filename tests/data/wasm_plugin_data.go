@@ -0,0 +1,25 @@
+package data
+
+import (
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+)
+
+func CreateWasmPlugin(name string, namespace string, url string, selector map[string]string) *extensions_v1alpha1.WasmPlugin {
+	wp := extensions_v1alpha1.WasmPlugin{}
+	wp.Name = name
+	wp.Namespace = namespace
+	wp.Spec.Url = url
+	if len(selector) > 0 {
+		wp.Spec.Selector = &api_v1beta1.WorkloadSelector{
+			MatchLabels: selector,
+		}
+	}
+	return &wp
+}
+
+func AddPhaseToWasmPlugin(phase api_extensions_v1alpha1.PluginPhase, wp *extensions_v1alpha1.WasmPlugin) *extensions_v1alpha1.WasmPlugin {
+	wp.Spec.Phase = phase
+	return wp
+}
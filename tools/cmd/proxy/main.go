@@ -14,6 +14,7 @@ import (
 
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/kiali/kiali/graph/api"
 	"github.com/kiali/kiali/graph/config/cytoscape"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/tools/cmd"
@@ -37,6 +38,7 @@ var (
 	dataDirFlag  string
 	httpsFlag    bool
 	keyFileFlag  string
+	snapshotFlag string
 )
 
 func init() {
@@ -45,6 +47,7 @@ func init() {
 	flag.StringVar(&dataDirFlag, "data-dir", "", "path to dir where json graph data is.")
 	flag.BoolVar(&httpsFlag, "https", false, "use https. Uses minikube certs by default")
 	flag.StringVar(&keyFileFlag, "key-file", filepath.Join(homeDir, ".minikube/ca.key"), "path to key file for https")
+	flag.StringVar(&snapshotFlag, "snapshot", "", "path to a graph snapshot file, exported from /api/namespaces/graph/snapshot, to replay.")
 
 	// Generate flags
 	flag.BoolVar(&boxFlag, "box", false, "adds boxing to the graph")
@@ -54,6 +57,25 @@ func init() {
 	flag.Var(&popStratFlag, "population-strategy", "whether the graph should have many or few connections")
 }
 
+func loadGraphFromSnapshot(filename string) (*cytoscape.Config, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := api.ParseSnapshot(contents)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{}
+	for _, ns := range snapshot.Telemetry.Namespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+	log.Infof("Replaying graph snapshot of namespaces [%s], generated by Kiali %s", strings.Join(namespaces, ","), snapshot.Telemetry.KialiVersion)
+
+	return &snapshot.Graph, nil
+}
+
 func loadGraphFromFile(filename string) (*cytoscape.Config, error) {
 	contents, err := os.ReadFile(filename)
 	if err != nil {
@@ -158,7 +180,17 @@ func main() {
 	}
 
 	var graph *cytoscape.Config
-	if dataDirFlag == "" {
+	if snapshotFlag != "" {
+		graph, err = loadGraphFromSnapshot(snapshotFlag)
+		if err != nil {
+			log.Fatalf("Unable to load graph from snapshot. Err: %s", err)
+		}
+
+		err = gen.EnsureNamespaces(*graph)
+		if err != nil {
+			log.Fatalf("Unable to ensure namespaces. Err: %s", err)
+		}
+	} else if dataDirFlag == "" {
 		log.Info("Populating graph data...")
 		g := gen.Generate()
 		graph = &g
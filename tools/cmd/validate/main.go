@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/tools/cmd"
+	"github.com/kiali/kiali/tools/validator"
+)
+
+const (
+	// exit code when the validations fail
+	exitValidationFailed = 1
+	// exit code when the manifests can't be validated
+	exitError = 2
+)
+
+var (
+	configFlag         string
+	failOnWarningsFlag bool
+	namespaceFlag      string
+	outputFlag         string
+	outputFileFlag     string
+)
+
+func init() {
+	flag.StringVar(&configFlag, "config", "", "path to a Kiali config file, used for the validation settings e.g. the ignored checks and the custom rules")
+	flag.BoolVar(&failOnWarningsFlag, "fail-on-warnings", false, "exit with a non-zero code when there are warnings, not only errors")
+	flag.StringVar(&namespaceFlag, "namespace", "default", "namespace of the objects that don't define one")
+	flag.StringVar(&outputFlag, "output", validator.TextFormat, "output format, one of: text, json, junit")
+	flag.StringVar(&outputFileFlag, "output-file", "", "path to write the validations to, stdout when not set")
+}
+
+func main() {
+	flag.Usage = cmd.Usage("validate", "<file or dir>...")
+	flag.Parse()
+
+	// the validations are written to stdout, keep the logs in stderr and only log the problems by default
+	if _, found := os.LookupEnv("LOG_LEVEL"); !found {
+		os.Setenv("LOG_LEVEL", "warn")
+	}
+	log.InitializeLogger()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(exitError)
+	}
+
+	conf := config.NewConfig()
+	if configFlag != "" {
+		var err error
+		if conf, err = config.LoadFromFile(configFlag); err != nil {
+			log.Errorf("Unable to load the Kiali config: %s", err)
+			os.Exit(exitError)
+		}
+	}
+	config.Set(conf)
+
+	loader := validator.NewLoader(namespaceFlag)
+	if err := loader.Load(flag.Args()...); err != nil {
+		log.Errorf("Unable to load the manifests: %s", err)
+		os.Exit(exitError)
+	}
+	manifests := loader.Manifests()
+
+	validations := business.GetOfflineValidations(manifests.IstioConfigList, manifests.Namespaces, manifests.WorkloadsPerNamespace, manifests.RegistryServices, manifests.MeshConfig)
+
+	var out io.Writer = os.Stdout
+	if outputFileFlag != "" {
+		f, err := os.Create(outputFileFlag)
+		if err != nil {
+			log.Errorf("Unable to create the output file: %s", err)
+			os.Exit(exitError)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := validator.Write(out, outputFlag, validations); err != nil {
+		log.Errorf("Unable to write the validations: %s", err)
+		os.Exit(exitError)
+	}
+
+	summary := validator.Summarize(validations)
+	if summary.Errors > 0 || (failOnWarningsFlag && summary.Warnings > 0) {
+		// deferred calls don't run on os.Exit
+		if f, ok := out.(*os.File); ok && f != os.Stdout {
+			f.Close()
+		}
+		os.Exit(exitValidationFailed)
+	}
+}
@@ -0,0 +1,368 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// Manifests holds the objects loaded from the manifest files, in the shape consumed by the validations
+type Manifests struct {
+	IstioConfigList       models.IstioConfigList
+	Namespaces            models.Namespaces
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	RegistryServices      []*kubernetes.RegistryService
+	MeshConfig            kubernetes.IstioMeshConfig
+}
+
+// Loader loads Istio and Kubernetes manifests from files. Objects without namespace are placed in the
+// DefaultNamespace, as kubectl apply would do.
+type Loader struct {
+	DefaultNamespace string
+
+	manifests  Manifests
+	namespaces map[string]models.Namespace
+}
+
+// NewLoader returns a Loader placing the objects without namespace in the given namespace
+func NewLoader(defaultNamespace string) *Loader {
+	return &Loader{
+		DefaultNamespace: defaultNamespace,
+		manifests: Manifests{
+			WorkloadsPerNamespace: map[string]models.WorkloadList{},
+			RegistryServices:      []*kubernetes.RegistryService{},
+		},
+		namespaces: map[string]models.Namespace{},
+	}
+}
+
+// Load loads the manifests of the given files. Directories are walked recursively, loading their .yaml, .yml
+// and .json files.
+func (l *Loader) Load(paths ...string) error {
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			if file != path && !isManifestFile(file) {
+				return nil
+			}
+			return l.loadFile(file)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Manifests returns the loaded objects
+func (l *Loader) Manifests() *Manifests {
+	manifests := l.manifests
+
+	names := make([]string, 0, len(l.namespaces))
+	for name := range l.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	manifests.Namespaces = models.Namespaces{}
+	for _, name := range names {
+		manifests.Namespaces = append(manifests.Namespaces, l.namespaces[name])
+	}
+
+	return &manifests
+}
+
+func isManifestFile(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func (l *Loader) loadFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		obj := map[string]interface{}{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("unable to parse [%s]: %w", file, err)
+		}
+		if err := l.loadObject(obj); err != nil {
+			return fmt.Errorf("unable to load [%s]: %w", file, err)
+		}
+	}
+}
+
+func (l *Loader) loadObject(obj map[string]interface{}) error {
+	if len(obj) == 0 {
+		return nil
+	}
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+
+	if kind == "List" {
+		items, _ := obj["items"].([]interface{})
+		for _, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok {
+				if err := l.loadObject(itemObj); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	group := ""
+	if i := strings.Index(apiVersion, "/"); i >= 0 {
+		group = apiVersion[:i]
+	}
+
+	list := &l.manifests.IstioConfigList
+	var err error
+	switch group + "/" + kind {
+	case "networking.istio.io/DestinationRule":
+		dr := &networking_v1beta1.DestinationRule{}
+		if err = l.decode(obj, dr, &dr.ObjectMeta.Namespace); err == nil {
+			list.DestinationRules = append(list.DestinationRules, dr)
+		}
+	case "networking.istio.io/EnvoyFilter":
+		ef := &networking_v1alpha3.EnvoyFilter{}
+		if err = l.decode(obj, ef, &ef.ObjectMeta.Namespace); err == nil {
+			list.EnvoyFilters = append(list.EnvoyFilters, ef)
+		}
+	case "networking.istio.io/Gateway":
+		gw := &networking_v1beta1.Gateway{}
+		if err = l.decode(obj, gw, &gw.ObjectMeta.Namespace); err == nil {
+			list.Gateways = append(list.Gateways, gw)
+		}
+	case "networking.istio.io/ServiceEntry":
+		se := &networking_v1beta1.ServiceEntry{}
+		if err = l.decode(obj, se, &se.ObjectMeta.Namespace); err == nil {
+			list.ServiceEntries = append(list.ServiceEntries, se)
+		}
+	case "networking.istio.io/Sidecar":
+		sc := &networking_v1beta1.Sidecar{}
+		if err = l.decode(obj, sc, &sc.ObjectMeta.Namespace); err == nil {
+			list.Sidecars = append(list.Sidecars, sc)
+		}
+	case "networking.istio.io/VirtualService":
+		vs := &networking_v1beta1.VirtualService{}
+		if err = l.decode(obj, vs, &vs.ObjectMeta.Namespace); err == nil {
+			list.VirtualServices = append(list.VirtualServices, vs)
+		}
+	case "networking.istio.io/WorkloadEntry":
+		we := &networking_v1beta1.WorkloadEntry{}
+		if err = l.decode(obj, we, &we.ObjectMeta.Namespace); err == nil {
+			list.WorkloadEntries = append(list.WorkloadEntries, we)
+		}
+	case "networking.istio.io/WorkloadGroup":
+		wg := &networking_v1beta1.WorkloadGroup{}
+		if err = l.decode(obj, wg, &wg.ObjectMeta.Namespace); err == nil {
+			list.WorkloadGroups = append(list.WorkloadGroups, wg)
+		}
+	case "security.istio.io/AuthorizationPolicy":
+		ap := &security_v1beta1.AuthorizationPolicy{}
+		if err = l.decode(obj, ap, &ap.ObjectMeta.Namespace); err == nil {
+			list.AuthorizationPolicies = append(list.AuthorizationPolicies, ap)
+		}
+	case "security.istio.io/PeerAuthentication":
+		pa := &security_v1beta1.PeerAuthentication{}
+		if err = l.decode(obj, pa, &pa.ObjectMeta.Namespace); err == nil {
+			list.PeerAuthentications = append(list.PeerAuthentications, pa)
+		}
+	case "security.istio.io/RequestAuthentication":
+		ra := &security_v1beta1.RequestAuthentication{}
+		if err = l.decode(obj, ra, &ra.ObjectMeta.Namespace); err == nil {
+			list.RequestAuthentications = append(list.RequestAuthentications, ra)
+		}
+	case "telemetry.istio.io/Telemetry":
+		tm := &telemetry_v1alpha1.Telemetry{}
+		if err = l.decode(obj, tm, &tm.ObjectMeta.Namespace); err == nil {
+			list.Telemetries = append(list.Telemetries, tm)
+		}
+	case "extensions.istio.io/WasmPlugin":
+		wp := &extensions_v1alpha1.WasmPlugin{}
+		if err = l.decode(obj, wp, &wp.ObjectMeta.Namespace); err == nil {
+			list.WasmPlugins = append(list.WasmPlugins, wp)
+		}
+	case "gateway.networking.k8s.io/Gateway":
+		gw := &k8s_networking_v1beta1.Gateway{}
+		if err = l.decode(obj, gw, &gw.ObjectMeta.Namespace); err == nil {
+			list.K8sGateways = append(list.K8sGateways, gw)
+		}
+	case "gateway.networking.k8s.io/HTTPRoute":
+		route := &k8s_networking_v1beta1.HTTPRoute{}
+		if err = l.decode(obj, route, &route.ObjectMeta.Namespace); err == nil {
+			list.K8sHTTPRoutes = append(list.K8sHTTPRoutes, route)
+		}
+	case "gateway.networking.k8s.io/GRPCRoute":
+		route := &k8s_networking_v1alpha2.GRPCRoute{}
+		if err = l.decode(obj, route, &route.ObjectMeta.Namespace); err == nil {
+			list.K8sGRPCRoutes = append(list.K8sGRPCRoutes, route)
+		}
+	case "gateway.networking.k8s.io/ReferenceGrant":
+		rg := &k8s_networking_v1alpha2.ReferenceGrant{}
+		if err = l.decode(obj, rg, &rg.ObjectMeta.Namespace); err == nil {
+			list.K8sReferenceGrants = append(list.K8sReferenceGrants, rg)
+		}
+	case "gateway.networking.k8s.io/TCPRoute":
+		route := &k8s_networking_v1alpha2.TCPRoute{}
+		if err = l.decode(obj, route, &route.ObjectMeta.Namespace); err == nil {
+			list.K8sTCPRoutes = append(list.K8sTCPRoutes, route)
+		}
+	case "gateway.networking.k8s.io/TLSRoute":
+		route := &k8s_networking_v1alpha2.TLSRoute{}
+		if err = l.decode(obj, route, &route.ObjectMeta.Namespace); err == nil {
+			list.K8sTLSRoutes = append(list.K8sTLSRoutes, route)
+		}
+	case "/Namespace":
+		ns := &core_v1.Namespace{}
+		if err = decode(obj, ns); err == nil {
+			l.namespaces[ns.Name] = models.Namespace{Name: ns.Name, Labels: ns.Labels, Annotations: ns.Annotations}
+		}
+	case "/Service":
+		svc := &core_v1.Service{}
+		if err = l.decode(obj, svc, &svc.ObjectMeta.Namespace); err == nil {
+			l.addService(svc)
+		}
+	case "/ConfigMap":
+		cm := &core_v1.ConfigMap{}
+		if err = l.decode(obj, cm, &cm.ObjectMeta.Namespace); err == nil {
+			l.addConfigMap(cm)
+		}
+	case "/Pod":
+		pod := &core_v1.Pod{}
+		if err = l.decode(obj, pod, &pod.ObjectMeta.Namespace); err == nil {
+			w := models.Workload{}
+			w.ParsePod(pod)
+			l.addWorkload(pod.Namespace, &w, pod.Spec.ServiceAccountName)
+		}
+	case "apps/Deployment":
+		d := &apps_v1.Deployment{}
+		if err = l.decode(obj, d, &d.ObjectMeta.Namespace); err == nil {
+			w := models.Workload{}
+			w.ParseDeployment(d)
+			l.addWorkload(d.Namespace, &w, d.Spec.Template.Spec.ServiceAccountName)
+		}
+	case "apps/StatefulSet":
+		s := &apps_v1.StatefulSet{}
+		if err = l.decode(obj, s, &s.ObjectMeta.Namespace); err == nil {
+			w := models.Workload{}
+			w.ParseStatefulSet(s)
+			l.addWorkload(s.Namespace, &w, s.Spec.Template.Spec.ServiceAccountName)
+		}
+	case "apps/DaemonSet":
+		ds := &apps_v1.DaemonSet{}
+		if err = l.decode(obj, ds, &ds.ObjectMeta.Namespace); err == nil {
+			w := models.Workload{}
+			w.ParseDaemonSet(ds)
+			l.addWorkload(ds.Namespace, &w, ds.Spec.Template.Spec.ServiceAccountName)
+		}
+	default:
+		log.Debugf("Skipping unsupported object [%s %s]", apiVersion, kind)
+	}
+	return err
+}
+
+// decode converts the object into the typed object, setting the default namespace when the object has none
+func (l *Loader) decode(obj map[string]interface{}, into interface{}, namespace *string) error {
+	if err := decode(obj, into); err != nil {
+		return err
+	}
+	if *namespace == "" {
+		*namespace = l.DefaultNamespace
+	}
+	if _, found := l.namespaces[*namespace]; !found {
+		l.namespaces[*namespace] = models.Namespace{Name: *namespace}
+	}
+	return nil
+}
+
+func decode(obj map[string]interface{}, into interface{}) error {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, into)
+}
+
+func (l *Loader) addWorkload(namespace string, w *models.Workload, serviceAccount string) {
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	item := models.WorkloadListItem{}
+	item.ParseWorkload(w)
+	item.ServiceAccountNames = []string{serviceAccount}
+
+	workloadList, found := l.manifests.WorkloadsPerNamespace[namespace]
+	if !found {
+		workloadList = models.WorkloadList{Namespace: models.Namespace{Name: namespace}, Workloads: []models.WorkloadListItem{}}
+	}
+	workloadList.Workloads = append(workloadList.Workloads, item)
+	l.manifests.WorkloadsPerNamespace[namespace] = workloadList
+}
+
+// addService adds the service as it would be found in the Istio registry
+func (l *Loader) addService(svc *core_v1.Service) {
+	rs := &kubernetes.RegistryService{}
+	rs.Hostname = fmt.Sprintf("%s.%s.%s", svc.Name, svc.Namespace, config.Get().ExternalServices.Istio.IstioIdentityDomain)
+	rs.Attributes.ServiceRegistry = "Kubernetes"
+	rs.Attributes.Name = svc.Name
+	rs.Attributes.Namespace = svc.Namespace
+	rs.Attributes.Labels = svc.Labels
+	rs.Attributes.LabelSelectors = svc.Spec.Selector
+	rs.Attributes.ExportTo = map[string]bool{"*": true}
+	for _, port := range svc.Spec.Ports {
+		rs.Ports = append(rs.Ports, struct {
+			Name     string `json:"name,omitempty"`
+			Port     int    `json:"port"`
+			Protocol string `json:"protocol,omitempty"`
+		}{Name: port.Name, Port: int(port.Port), Protocol: string(port.Protocol)})
+	}
+	l.manifests.RegistryServices = append(l.manifests.RegistryServices, rs)
+}
+
+// addConfigMap loads the Istio mesh config, other config maps are ignored
+func (l *Loader) addConfigMap(cm *core_v1.ConfigMap) {
+	cfg := config.Get()
+	if cm.Name != cfg.ExternalServices.Istio.ConfigMapName || cm.Namespace != cfg.IstioNamespace {
+		return
+	}
+	if meshConfig, err := kubernetes.GetIstioConfigMap(cm); err == nil {
+		l.manifests.MeshConfig = *meshConfig
+	}
+}
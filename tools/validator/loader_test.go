@@ -0,0 +1,196 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+)
+
+func writeManifest(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	return file
+}
+
+func TestLoad(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	dir := t.TempDir()
+	writeManifest(t, dir, "bookinfo.yaml", `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+  labels:
+    istio-injection: enabled
+---
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+---
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+---
+`)
+	writeManifest(t, dir, "nested/workloads.yml", `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: reviews
+    namespace: bookinfo
+  spec:
+    selector:
+      app: reviews
+    ports:
+    - name: http
+      port: 9080
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: reviews-v1
+    namespace: bookinfo
+  spec:
+    selector:
+      matchLabels:
+        app: reviews
+    template:
+      metadata:
+        labels:
+          app: reviews
+          version: v1
+      spec:
+        serviceAccountName: bookinfo-reviews
+        containers:
+        - name: reviews
+          image: reviews
+`)
+	writeManifest(t, dir, "gateway.json", `{"apiVersion": "gateway.networking.k8s.io/v1beta1", "kind": "Gateway", "metadata": {"name": "gateway", "namespace": "istio-system"}, "spec": {"gatewayClassName": "istio"}}`)
+	// files with other extensions are skipped, unsupported kinds are ignored
+	writeManifest(t, dir, "README.md", "not a manifest")
+	writeManifest(t, dir, "secret.yaml", `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: registry
+`)
+
+	loader := NewLoader("default")
+	require.NoError(loader.Load(dir))
+	manifests := loader.Manifests()
+
+	require.Len(manifests.IstioConfigList.VirtualServices, 1)
+	assert.Equal("bookinfo", manifests.IstioConfigList.VirtualServices[0].Namespace)
+	require.Len(manifests.IstioConfigList.DestinationRules, 1)
+	// objects without namespace are placed in the default namespace
+	assert.Equal("default", manifests.IstioConfigList.DestinationRules[0].Namespace)
+	require.Len(manifests.IstioConfigList.K8sGateways, 1)
+	assert.Equal("istio-system", manifests.IstioConfigList.K8sGateways[0].Namespace)
+
+	// the namespaces are the declared ones and the ones of the objects, sorted by name
+	require.Len(manifests.Namespaces, 3)
+	assert.Equal("bookinfo", manifests.Namespaces[0].Name)
+	assert.Equal("enabled", manifests.Namespaces[0].Labels["istio-injection"])
+	assert.Equal("default", manifests.Namespaces[1].Name)
+	assert.Equal("istio-system", manifests.Namespaces[2].Name)
+
+	require.Len(manifests.RegistryServices, 1)
+	assert.Equal("reviews.bookinfo.svc.cluster.local", manifests.RegistryServices[0].Hostname)
+	assert.Equal(map[string]string{"app": "reviews"}, manifests.RegistryServices[0].Attributes.LabelSelectors)
+	require.Len(manifests.RegistryServices[0].Ports, 1)
+	assert.Equal(9080, manifests.RegistryServices[0].Ports[0].Port)
+
+	require.Len(manifests.WorkloadsPerNamespace["bookinfo"].Workloads, 1)
+	workload := manifests.WorkloadsPerNamespace["bookinfo"].Workloads[0]
+	assert.Equal("reviews-v1", workload.Name)
+	assert.Equal("v1", workload.Labels["version"])
+	assert.Equal([]string{"bookinfo-reviews"}, workload.ServiceAccountNames)
+}
+
+func TestLoadMeshConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	dir := t.TempDir()
+	file := writeManifest(t, dir, "istio.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  mesh: |-
+    enableAutoMtls: false
+    outboundTrafficPolicy:
+      mode: REGISTRY_ONLY
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+  namespace: istio-system
+data:
+  mesh: |-
+    outboundTrafficPolicy:
+      mode: ALLOW_ANY
+`)
+
+	loader := NewLoader("default")
+	require.NoError(loader.Load(file))
+	meshConfig := loader.Manifests().MeshConfig
+	assert.False(meshConfig.GetEnableAutoMtls())
+	assert.Equal("REGISTRY_ONLY", meshConfig.GetOutboundTrafficPolicyMode())
+
+	// Without the Istio config map, the mesh config has the Istio defaults
+	loader = NewLoader("default")
+	meshConfig = loader.Manifests().MeshConfig
+	assert.True(meshConfig.GetEnableAutoMtls())
+	assert.Equal("ALLOW_ANY", meshConfig.GetOutboundTrafficPolicyMode())
+}
+
+func TestLoadErrors(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	dir := t.TempDir()
+	file := writeManifest(t, dir, "invalid.yaml", "kind: [VirtualService")
+	assert.Error(NewLoader("default").Load(file))
+
+	// the fields must have the types of the object
+	file = writeManifest(t, dir, "vs.yaml", `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts: reviews
+`)
+	assert.Error(NewLoader("default").Load(file))
+
+	assert.Error(NewLoader("default").Load(filepath.Join(dir, "missing.yaml")))
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/kiali/kiali/models"
+)
+
+// Output formats
+const (
+	TextFormat  = "text"
+	JSONFormat  = "json"
+	JUnitFormat = "junit"
+)
+
+// Summary counts the checks of a set of validations
+type Summary struct {
+	Objects  int
+	Errors   int
+	Warnings int
+}
+
+// Summarize counts the validated objects and their error and warning checks
+func Summarize(validations models.IstioValidations) Summary {
+	summary := Summary{Objects: len(validations)}
+	for _, validation := range validations {
+		for _, check := range validation.Checks {
+			switch check.Severity {
+			case models.ErrorSeverity:
+				summary.Errors++
+			case models.WarningSeverity:
+				summary.Warnings++
+			}
+		}
+	}
+	return summary
+}
+
+// Write writes the validations to the writer in the given format
+func Write(w io.Writer, format string, validations models.IstioValidations) error {
+	switch format {
+	case TextFormat:
+		return writeText(w, validations)
+	case JSONFormat:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(validations)
+	case JUnitFormat:
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		encoder := xml.NewEncoder(w)
+		encoder.Indent("", "  ")
+		if err := encoder.Encode(validations.JUnit("kiali-validations")); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	}
+	return fmt.Errorf("unsupported output format [%s]", format)
+}
+
+// writeText writes a line per check, followed by a summary line
+func writeText(w io.Writer, validations models.IstioValidations) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, key := range validations.SortedKeys() {
+		for _, check := range validations[key].Checks {
+			fmt.Fprintf(tw, "%s\t%s\t%s/%s\t%s\t%s\t%s\n", check.Severity, key.ObjectType, key.Namespace, key.Name, check.Code, check.Path, check.Message)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	summary := Summarize(validations)
+	_, err := fmt.Fprintf(w, "%d error(s), %d warning(s) in %d validated object(s)\n", summary.Errors, summary.Warnings, summary.Objects)
+	return err
+}
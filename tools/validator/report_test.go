@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/models"
+)
+
+func fakeValidations() models.IstioValidations {
+	return models.IstioValidations{
+		models.BuildKey("virtualservice", "reviews", "bookinfo"): &models.IstioValidation{
+			Name:       "reviews",
+			ObjectType: "virtualservice",
+			Valid:      false,
+			Checks: []*models.IstioCheck{
+				{Code: "KIA1101", Message: "DestinationWeight on route doesn't have a valid service (host not found)", Severity: models.ErrorSeverity, Path: "spec/http[0]/route[0]/destination/host"},
+				{Code: "KIA1104", Message: "The weight is assumed to be 100 because there is only one route destination", Severity: models.WarningSeverity, Path: "spec/http[0]/route[0]/weight"},
+			},
+		},
+		models.BuildKey("destinationrule", "reviews", "bookinfo"): &models.IstioValidation{
+			Name:       "reviews",
+			ObjectType: "destinationrule",
+			Valid:      true,
+			Checks:     []*models.IstioCheck{},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	assert.Equal(t, Summary{Objects: 2, Errors: 1, Warnings: 1}, Summarize(fakeValidations()))
+	assert.Equal(t, Summary{}, Summarize(models.IstioValidations{}))
+}
+
+func TestWriteText(t *testing.T) {
+	assert := assert.New(t)
+
+	out := bytes.Buffer{}
+	require.NoError(t, Write(&out, TextFormat, fakeValidations()))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal([]string{"error", "virtualservice", "bookinfo/reviews", "KIA1101", "spec/http[0]/route[0]/destination/host"}, strings.Fields(lines[0])[:5])
+	assert.Equal([]string{"warning", "virtualservice", "bookinfo/reviews", "KIA1104", "spec/http[0]/route[0]/weight"}, strings.Fields(lines[1])[:5])
+	assert.Equal("1 error(s), 1 warning(s) in 2 validated object(s)", lines[2])
+}
+
+func TestWriteJSON(t *testing.T) {
+	out := bytes.Buffer{}
+	require.NoError(t, Write(&out, JSONFormat, fakeValidations()))
+
+	validations := map[string]map[string]*models.IstioValidation{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &validations))
+	assert.Len(t, validations["virtualservice"]["reviews.bookinfo"].Checks, 2)
+	assert.True(t, validations["destinationrule"]["reviews.bookinfo"].Valid)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	assert := assert.New(t)
+
+	out := bytes.Buffer{}
+	require.NoError(t, Write(&out, JUnitFormat, fakeValidations()))
+
+	report := out.String()
+	assert.True(strings.HasPrefix(report, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(report, `<testsuites name="kiali-validations" tests="2" failures="1">`)
+	assert.Contains(report, `<testsuite name="virtualservice" tests="1" failures="1">`)
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	assert.Error(t, Write(&bytes.Buffer{}, "yaml", fakeValidations()))
+}